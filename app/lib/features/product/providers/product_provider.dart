@@ -18,8 +18,7 @@ final productListProvider = FutureProvider<List<Product>>((ref) async {
   if (searchQuery.isNotEmpty) {
     queryParams['search'] = searchQuery;
   }
-  // 假设后端支持 category_id 筛选，如果后端还没实现，这里暂时只是客户端筛选或忽略
-  // 为了演示，我们先加上参数，如果后端忽略也没关系
+  // 后端按分类筛选时包含子分类
   if (categoryId > 0) {
     queryParams['category_id'] = categoryId;
   }
@@ -29,15 +28,9 @@ final productListProvider = FutureProvider<List<Product>>((ref) async {
     queryParameters: queryParams.isNotEmpty ? queryParams : null,
   );
 
-  final List<dynamic> data = response.data;
-  var products = data.map((json) => Product.fromJson(json)).toList();
-
-  // 如果后端未实现分类筛选，我们在前端做一层过滤 (临时方案)
-  if (categoryId > 0) {
-    products = products.where((p) => p.categoryId == categoryId).toList();
-  }
-
-  return products;
+  // 响应为分页结构: { items, total, page, page_size, pages, facets }
+  final List<dynamic> data = response.data['items'];
+  return data.map((json) => Product.fromJson(json)).toList();
 });
 
 /// 单个商品详情 Provider Family
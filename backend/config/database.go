@@ -36,7 +36,16 @@ func ConnectDatabase() {
 		&models.User{},
 		&models.Product{},
 		&models.Category{},
+		&models.Brand{},
 		&models.ProductSKU{},
+		&models.ProductSpec{},
+		&models.ProductSpecValue{},
+		&models.CategoryAttribute{},
+		&models.ProductAttributeValue{},
+		&models.PriceHistory{},
+		&models.PriceAlert{},
+		&models.Favorite{},
+		&models.ProductSimilarity{},
 		&models.CartItem{},
 		&models.Order{},
 		&models.OrderItem{},
@@ -45,6 +54,19 @@ func ConnectDatabase() {
 		&models.ChatMessage{},
 		&models.Notification{},
 		&models.Review{},
+		&models.CouponTemplate{},
+		&models.UserCoupon{},
+		&models.Promotion{},
+		&models.FreightTemplate{},
+		&models.FreightRule{},
+		&models.PointsAccount{},
+		&models.PointsTransaction{},
+		&models.GiftCard{},
+		&models.Wallet{},
+		&models.LedgerEntry{},
+		&models.SearchTerm{},
+		&models.SearchSynonym{},
+		&models.SearchStopword{},
 	)
 	if err != nil {
 		log.Fatal("Failed to migrate database:", err)
@@ -0,0 +1,10 @@
+package config
+
+// BrowsingHistoryRetentionDays 浏览历史保留天数，超过后由 MongoDB TTL 索引自动删除，0 表示永久保留
+// 商品浏览量统计基于浏览历史，因此也只覆盖保留期内的数据
+// 通过环境变量 BROWSING_HISTORY_RETENTION_DAYS 配置
+var BrowsingHistoryRetentionDays = getEnvInt("BROWSING_HISTORY_RETENTION_DAYS", 90)
+
+// BrowsingHistoryMaxPerUser 每个用户最多保留的浏览记录条数 (每个商品每天一条)，超出时删除最旧的记录，0 表示不限制
+// 通过环境变量 BROWSING_HISTORY_MAX_PER_USER 配置
+var BrowsingHistoryMaxPerUser = getEnvInt("BROWSING_HISTORY_MAX_PER_USER", 500)
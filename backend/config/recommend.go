@@ -0,0 +1,13 @@
+package config
+
+// RecommendOrderDays 计算共同购买相似度和用户购买偏好时统计的订单天数
+// 通过环境变量 RECOMMEND_ORDER_DAYS 配置
+var RecommendOrderDays = getEnvInt("RECOMMEND_ORDER_DAYS", 180)
+
+// RecommendRelatedPerProduct 每个商品保留的相似商品数量
+// 通过环境变量 RECOMMEND_RELATED_PER_PRODUCT 配置
+var RecommendRelatedPerProduct = getEnvInt("RECOMMEND_RELATED_PER_PRODUCT", 20)
+
+// RecommendRefreshMinutes 共同购买相似度的重算间隔 (分钟)
+// 通过环境变量 RECOMMEND_REFRESH_MINUTES 配置
+var RecommendRefreshMinutes = getEnvInt("RECOMMEND_REFRESH_MINUTES", 60)
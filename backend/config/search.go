@@ -0,0 +1,45 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+// SearchBackend 商品搜索后端: postgres (默认) 或 bleve (本地磁盘上的嵌入式索引，无需数据库扩展)
+// 通过环境变量 SEARCH_BACKEND 配置
+var SearchBackend = getEnv("SEARCH_BACKEND", "postgres")
+
+// SearchTSConfig PostgreSQL 全文检索的中文分词配置名 (如安装 zhparser 后创建的 "chinese")
+// 为空或数据库中不存在该配置时，使用应用层 n-gram 分词 + simple 配置
+// 通过环境变量 SEARCH_TS_CONFIG 配置
+var SearchTSConfig = getEnv("SEARCH_TS_CONFIG", "")
+
+// SearchIndexPath bleve 索引在本地磁盘上的目录
+// 通过环境变量 SEARCH_INDEX_PATH 配置
+var SearchIndexPath = getEnv("SEARCH_INDEX_PATH", "data/search.bleve")
+
+// SearchHistoryRetentionDays 搜索历史保留天数，超过后由 MongoDB TTL 索引自动删除，0 表示永久保留
+// 通过环境变量 SEARCH_HISTORY_RETENTION_DAYS 配置
+var SearchHistoryRetentionDays = getEnvInt("SEARCH_HISTORY_RETENTION_DAYS", 90)
+
+// SearchHistoryMaxPerUser 每个用户最多保留的搜索历史条数，超出时删除最旧的记录
+// 通过环境变量 SEARCH_HISTORY_MAX_PER_USER 配置
+var SearchHistoryMaxPerUser = getEnvInt("SEARCH_HISTORY_MAX_PER_USER", 20)
+
+// getEnv 读取环境变量，未设置时返回默认值
+func getEnv(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return fallback
+}
+
+// getEnvInt 读取整数环境变量，未设置或格式错误时返回默认值
+func getEnvInt(key string, fallback int) int {
+	if v, ok := os.LookupEnv(key); ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	}
+	return fallback
+}
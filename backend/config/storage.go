@@ -0,0 +1,30 @@
+package config
+
+// StorageBackend 上传文件的存储后端: local (默认，保存到本地磁盘并由本服务提供访问) 或 s3 (S3 兼容对象存储，如 AWS S3、MinIO、OSS)
+// 通过环境变量 STORAGE_BACKEND 配置
+var StorageBackend = getEnv("STORAGE_BACKEND", "local")
+
+// UploadDir 本地存储的根目录
+// 通过环境变量 UPLOAD_DIR 配置
+var UploadDir = getEnv("UPLOAD_DIR", "data/uploads")
+
+// UploadBaseURL 本地存储文件的访问 URL 前缀，本服务在该路径下提供静态文件
+// 通过环境变量 UPLOAD_BASE_URL 配置，可改为完整地址 (如 CDN 域名)
+var UploadBaseURL = getEnv("UPLOAD_BASE_URL", "/uploads")
+
+// UploadMaxSizeMB 单张图片的最大体积 (MB)，头像固定为不超过 2MB
+// 通过环境变量 UPLOAD_MAX_SIZE_MB 配置
+var UploadMaxSizeMB = getEnvInt("UPLOAD_MAX_SIZE_MB", 10)
+
+// S3 兼容存储配置，STORAGE_BACKEND=s3 时使用
+// S3Endpoint 不含协议，如 "s3.amazonaws.com"、"127.0.0.1:9000"
+// S3PublicURL 文件访问 URL 前缀 (如 CDN 域名)，为空时使用 endpoint/bucket 路径
+var (
+	S3Endpoint  = getEnv("S3_ENDPOINT", "")
+	S3Region    = getEnv("S3_REGION", "")
+	S3Bucket    = getEnv("S3_BUCKET", "")
+	S3AccessKey = getEnv("S3_ACCESS_KEY", "")
+	S3SecretKey = getEnv("S3_SECRET_KEY", "")
+	S3UseSSL    = getEnv("S3_USE_SSL", "true") == "true"
+	S3PublicURL = getEnv("S3_PUBLIC_URL", "")
+)
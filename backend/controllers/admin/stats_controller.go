@@ -1,10 +1,13 @@
 package admin
 
 import (
+	"log"
 	"net/http"
+	"time"
 
 	"go-flutter-mall/backend/config"
 	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/browsing"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,6 +18,8 @@ type DashboardStats struct {
 	TotalOrders   int64   `json:"total_orders"`
 	TotalSales    float64 `json:"total_sales"`
 	TotalProducts int64   `json:"total_products"`
+
+	TopViewedProducts []browsing.ProductViews `json:"top_viewed_products"` // 最近 7 天浏览量前 5 的商品，MongoDB 不可用时为空
 }
 
 // GetDashboardStats 获取仪表盘统计数据
@@ -60,5 +65,15 @@ func GetDashboardStats(c *gin.Context) {
 		return
 	}
 
+	// 5. 最近 7 天 (含今天) 浏览量最高的商品，统计失败不影响其他数据
+	stats.TopViewedProducts = []browsing.ProductViews{}
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local).Add(24 * time.Hour)
+	if top, err := browsing.TopViewed(to.AddDate(0, 0, -7), to, 5); err == nil {
+		stats.TopViewedProducts = top
+	} else if err != browsing.ErrDisabled {
+		log.Printf("Failed to fetch top viewed products: %v", err)
+	}
+
 	c.JSON(http.StatusOK, stats)
 }
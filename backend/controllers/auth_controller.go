@@ -149,7 +149,7 @@ func Login(c *gin.Context) {
 	}
 
 	// 4. 生成 JWT Token
-	token, err := utils.GenerateToken(user.ID)
+	token, err := utils.GenerateToken(user.ID, user.Role)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
@@ -0,0 +1,245 @@
+package brand
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/cache"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BrandInput 创建/更新品牌的输入参数
+type BrandInput struct {
+	Name        string `json:"name" binding:"required"`
+	Logo        string `json:"logo"`
+	Description string `json:"description"`
+	SortOrder   int    `json:"sort_order"`
+}
+
+// BrandWithCount 品牌及其上架商品数
+type BrandWithCount struct {
+	models.Brand
+	ProductCount int64 `json:"product_count"`
+}
+
+// BrandLandingResponse 品牌落地页响应
+type BrandLandingResponse struct {
+	Brand    models.Brand     `json:"brand"`
+	Items    []models.Product `json:"items"`
+	Total    int64            `json:"total"`
+	Page     int              `json:"page"`
+	PageSize int              `json:"page_size"`
+	Pages    int              `json:"pages"`
+}
+
+// 品牌落地页商品排序方式
+var brandProductSorts = map[string]string{
+	"newest":     "created_at DESC",
+	"price_asc":  "price ASC",
+	"price_desc": "price DESC",
+}
+
+// nameTaken 品牌名称不能重复
+func nameTaken(name string, excludeID uint) bool {
+	var count int64
+	config.DB.Model(&models.Brand{}).Where("name = ? AND id <> ?", name, excludeID).Count(&count)
+	return count > 0
+}
+
+// GetBrands 获取品牌列表
+// @Summary      Get Brands
+// @Description  List all brands ordered by sort_order, with the number of on-shelf products
+// @Tags         Brand
+// @Produce      json
+// @Success      200  {array}   BrandWithCount
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /brands [get]
+func GetBrands(c *gin.Context) {
+	brands := []BrandWithCount{}
+	if err := config.DB.Model(&models.Brand{}).
+		Select("brands.*, (SELECT COUNT(*) FROM products p WHERE p.brand_id = brands.id AND p.status = 1 AND p.deleted_at IS NULL) AS product_count").
+		Order("sort_order asc, id asc").
+		Scan(&brands).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch brands"})
+		return
+	}
+
+	c.JSON(http.StatusOK, brands)
+}
+
+// GetBrandLanding 品牌落地页
+// @Summary      Get Brand Landing
+// @Description  Get a brand with a paginated list of its on-shelf products
+// @Tags         Brand
+// @Produce      json
+// @Param        id         path      int     true   "Brand ID"
+// @Param        page       query     int     false  "Page number" default(1)
+// @Param        page_size  query     int     false  "Page size" default(10)
+// @Param        sort       query     string  false  "Sort: newest (default), price_asc, price_desc"
+// @Success      200        {object}  BrandLandingResponse
+// @Failure      400        {object}  map[string]interface{}
+// @Failure      404        {object}  map[string]interface{}
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /brands/{id} [get]
+func GetBrandLanding(c *gin.Context) {
+	var brand models.Brand
+	if err := config.DB.First(&brand, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Brand not found"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	orderBy, ok := brandProductSorts[c.DefaultQuery("sort", "newest")]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sort parameter"})
+		return
+	}
+
+	resp := BrandLandingResponse{Brand: brand, Items: []models.Product{}, Page: page, PageSize: pageSize}
+	query := config.DB.Model(&models.Product{}).Where("brand_id = ? AND status = ?", brand.ID, 1)
+	if err := query.Count(&resp.Total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch products"})
+		return
+	}
+	resp.Pages = int((resp.Total + int64(pageSize) - 1) / int64(pageSize))
+
+	if err := config.DB.Preload("SKUs").Where("brand_id = ? AND status = ?", brand.ID, 1).
+		Order(orderBy).Order("id DESC").
+		Limit(pageSize).Offset((page - 1) * pageSize).Find(&resp.Items).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch products"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateBrand 管理员创建品牌
+// @Summary      Create Brand
+// @Description  Create a new brand (Admin only)
+// @Tags         Brand
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input  body      BrandInput  true  "Brand Info"
+// @Success      201    {object}  models.Brand
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /brands/admin [post]
+func CreateBrand(c *gin.Context) {
+	var input BrandInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" || nameTaken(input.Name, 0) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Brand name is empty or already exists"})
+		return
+	}
+
+	brand := models.Brand{Name: input.Name, Logo: input.Logo, Description: input.Description, SortOrder: input.SortOrder}
+	if err := config.DB.Create(&brand).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create brand"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, brand)
+}
+
+// UpdateBrand 管理员更新品牌
+// @Summary      Update Brand
+// @Description  Update a brand (Admin only)
+// @Tags         Brand
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      int         true  "Brand ID"
+// @Param        input  body      BrandInput  true  "Brand Info"
+// @Success      200    {object}  models.Brand
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /brands/admin/{id} [put]
+func UpdateBrand(c *gin.Context) {
+	var brand models.Brand
+	if err := config.DB.First(&brand, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Brand not found"})
+		return
+	}
+
+	var input BrandInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" || nameTaken(input.Name, brand.ID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Brand name is empty or already exists"})
+		return
+	}
+
+	brand.Name = input.Name
+	brand.Logo = input.Logo
+	brand.Description = input.Description
+	brand.SortOrder = input.SortOrder
+	if err := config.DB.Save(&brand).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update brand"})
+		return
+	}
+
+	// 商品详情和列表品牌分面中包含品牌信息
+	var productIDs []uint
+	config.DB.Model(&models.Product{}).Where("brand_id = ?", brand.ID).Pluck("id", &productIDs)
+	cache.InvalidateProducts(productIDs...)
+
+	c.JSON(http.StatusOK, brand)
+}
+
+// DeleteBrand 管理员删除品牌
+// @Summary      Delete Brand
+// @Description  Delete a brand that no product references (Admin only)
+// @Tags         Brand
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Brand ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /brands/admin/{id} [delete]
+func DeleteBrand(c *gin.Context) {
+	var brand models.Brand
+	if err := config.DB.First(&brand, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Brand not found"})
+		return
+	}
+
+	var products int64
+	if err := config.DB.Model(&models.Product{}).Where("brand_id = ?", brand.ID).Count(&products).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check brand products"})
+		return
+	}
+	if products > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Brand still has products"})
+		return
+	}
+
+	// 没有商品引用，直接物理删除以释放唯一的品牌名称
+	if err := config.DB.Unscoped().Delete(&brand).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete brand"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Brand deleted successfully"})
+}
@@ -0,0 +1,249 @@
+package browsing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	browsingpkg "go-flutter-mall/backend/pkg/browsing"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// HistoryItem 浏览记录及商品信息
+type HistoryItem struct {
+	models.BrowsingHistory
+	Product *models.Product `json:"product"` // 商品已删除时为 null
+}
+
+// HistoryListResponse 浏览历史分页响应
+type HistoryListResponse struct {
+	Items    []HistoryItem `json:"items"`
+	Total    int64         `json:"total"`
+	Page     int           `json:"page"`
+	PageSize int           `json:"page_size"`
+}
+
+// historyUnavailable MongoDB 不可用时返回 503
+func historyUnavailable(c *gin.Context) bool {
+	if config.MongoDB == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Browsing history is not available"})
+		return true
+	}
+	return false
+}
+
+// GetBrowsingHistory 获取我的浏览历史
+// 同一商品每天一条记录，按最后浏览时间倒序
+// @Summary      Get Browsing History
+// @Description  Get a paginated list of products the current user viewed, one entry per product per day, newest first
+// @Tags         BrowsingHistory
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page       query     int  false  "Page number" default(1)
+// @Param        page_size  query     int  false  "Page size" default(20)
+// @Success      200        {object}  HistoryListResponse
+// @Failure      500        {object}  map[string]interface{}
+// @Failure      503        {object}  map[string]interface{}
+// @Router       /browsing-history [get]
+func GetBrowsingHistory(c *gin.Context) {
+	if historyUnavailable(c) {
+		return
+	}
+	userID := c.GetUint("userID")
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	collection := config.MongoDB.Collection(browsingpkg.HistoryCollection)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"user_id": userID}
+	resp := HistoryListResponse{Items: []HistoryItem{}, Page: page, PageSize: pageSize}
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch browsing history"})
+		return
+	}
+	resp.Total = total
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "viewed_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64((page - 1) * pageSize)).
+		SetLimit(int64(pageSize))
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch browsing history"})
+		return
+	}
+	defer cursor.Close(ctx)
+
+	var history []models.BrowsingHistory
+	if err := cursor.All(ctx, &history); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to decode browsing history"})
+		return
+	}
+
+	ids := make([]uint, 0, len(history))
+	for _, h := range history {
+		ids = append(ids, h.ProductID)
+	}
+	var products []models.Product
+	if len(ids) > 0 {
+		if err := config.DB.Where("id IN ?", ids).Find(&products).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch products"})
+			return
+		}
+	}
+	byID := make(map[uint]*models.Product, len(products))
+	for i := range products {
+		byID[products[i].ID] = &products[i]
+	}
+
+	for _, h := range history {
+		resp.Items = append(resp.Items, HistoryItem{BrowsingHistory: h, Product: byID[h.ProductID]})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteBrowsingHistory 删除一条浏览记录
+// @Summary      Delete Browsing History Entry
+// @Description  Delete one entry from the current user's browsing history
+// @Tags         BrowsingHistory
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Browsing history entry ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Failure      503  {object}  map[string]interface{}
+// @Router       /browsing-history/{id} [delete]
+func DeleteBrowsingHistory(c *gin.Context) {
+	if historyUnavailable(c) {
+		return
+	}
+	userID := c.GetUint("userID")
+
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid history id"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := config.MongoDB.Collection(browsingpkg.HistoryCollection).DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete browsing history"})
+		return
+	}
+	if result.DeletedCount == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Browsing history entry not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Browsing history entry deleted"})
+}
+
+// ClearBrowsingHistory 清空浏览历史
+// @Summary      Clear Browsing History
+// @Description  Clear all browsing history of the current user
+// @Tags         BrowsingHistory
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Failure      503  {object}  map[string]interface{}
+// @Router       /browsing-history [delete]
+func ClearBrowsingHistory(c *gin.Context) {
+	if historyUnavailable(c) {
+		return
+	}
+	userID := c.GetUint("userID")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := config.MongoDB.Collection(browsingpkg.HistoryCollection).DeleteMany(ctx, bson.M{"user_id": userID}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clear browsing history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Browsing history cleared"})
+}
+
+// GetTopViewedProducts 商品浏览量排行
+// 只统计登录用户的浏览，且受浏览历史保留天数限制
+// @Summary      Top Viewed Products
+// @Description  Products ranked by views in a time range, with distinct visitors (Admin only). Only views of logged-in users within the retention period are counted
+// @Tags         BrowsingHistory
+// @Produce      json
+// @Security     BearerAuth
+// @Param        from   query     string  false  "Start date (2006-01-02), default 7 days ago"
+// @Param        to     query     string  false  "End date inclusive (2006-01-02), default today"
+// @Param        limit  query     int     false  "Max products" default(20)
+// @Success      200    {array}   browsingpkg.ProductViews
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Failure      503    {object}  map[string]interface{}
+// @Router       /browsing-history/admin/views [get]
+func GetTopViewedProducts(c *gin.Context) {
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local).Add(24 * time.Hour)
+	from := to.AddDate(0, 0, -7)
+
+	if v := c.Query("to"); v != "" {
+		day, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date range"})
+			return
+		}
+		to = day.Add(24 * time.Hour)
+	}
+	if v := c.Query("from"); v != "" {
+		day, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date range"})
+			return
+		}
+		from = day
+	}
+	if !from.Before(to) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date range"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	stats, err := browsingpkg.TopViewed(from, to, limit)
+	if errors.Is(err, browsingpkg.ErrDisabled) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Browsing history is not available"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate view report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
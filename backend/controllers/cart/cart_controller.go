@@ -2,9 +2,11 @@ package cart
 
 import (
 	"net/http"
+	"strconv"
 
 	"go-flutter-mall/backend/config"
 	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/pricing"
 
 	"github.com/gin-gonic/gin"
 )
@@ -17,6 +19,7 @@ type AddToCartInput struct {
 }
 
 // GetCart 获取当前用户的购物车列表
+// 商品被删除、下架或 SKU 被删除的购物车项会标记为不可购买 (available=false)，由用户自行删除
 // @Summary      Get Cart
 // @Description  Get list of items in the user's shopping cart
 // @Tags         Cart
@@ -35,6 +38,10 @@ func GetCart(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch cart"})
 		return
 	}
+	if err := pricing.MarkAvailability(config.DB, cartItems); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check cart items"})
+		return
+	}
 
 	c.JSON(http.StatusOK, cartItems)
 }
@@ -50,6 +57,7 @@ func GetCart(c *gin.Context) {
 // @Success      200    {object}  models.CartItem
 // @Success      201    {object}  models.CartItem
 // @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
 // @Failure      500    {object}  map[string]interface{}
 // @Router       /cart [post]
 func AddToCart(c *gin.Context) {
@@ -61,6 +69,25 @@ func AddToCart(c *gin.Context) {
 		return
 	}
 
+	// 校验商品在售，指定的 SKU 属于该商品且未被删除
+	var product models.Product
+	if err := config.DB.First(&product, input.ProductID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+	if product.Status != models.ProductStatusOn {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Product is off shelf"})
+		return
+	}
+	if input.SKUID != 0 {
+		var count int64
+		config.DB.Model(&models.ProductSKU{}).Where("id = ? AND product_id = ?", input.SKUID, input.ProductID).Count(&count)
+		if count == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "SKU not found"})
+			return
+		}
+	}
+
 	// 检查该商品是否已在购物车中
 	var existingItem models.CartItem
 	// 使用 Limit(1).Find 避免 First 抛出 record not found 错误日志
@@ -157,3 +184,47 @@ func GetCartCount(c *gin.Context) {
 	// 注意: 这里简单返回条目数，也可以改为返回商品总件数 (Sum(Quantity))
 	c.JSON(http.StatusOK, gin.H{"count": count})
 }
+
+// GetCartSummary 获取购物车选中商品的价格汇总
+// 不可购买的购物车项不参与计价
+// @Summary      Get Cart Summary
+// @Description  Get the price breakdown (promotions and optional coupon) of selected cart items
+// @Tags         Cart
+// @Produce      json
+// @Security     BearerAuth
+// @Param        coupon_id  query     int  false  "User Coupon ID"
+// @Success      200        {object}  pricing.Quote
+// @Failure      400        {object}  map[string]interface{}
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /cart/summary [get]
+func GetCartSummary(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	couponID, _ := strconv.Atoi(c.DefaultQuery("coupon_id", "0"))
+
+	var cartItems []models.CartItem
+	if err := config.DB.Preload("Product").Where("user_id = ? AND selected = ?", userID, true).Find(&cartItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch cart"})
+		return
+	}
+	if err := pricing.MarkAvailability(config.DB, cartItems); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check cart items"})
+		return
+	}
+	cartItems, _ = pricing.AvailableItems(cartItems)
+
+	// 使用默认收货地址估算运费
+	pricingInput := pricing.Input{UserID: userID.(uint), Items: cartItems, CouponID: uint(couponID)}
+	var address models.Address
+	if result := config.DB.Where("user_id = ?", userID).Order("is_default desc, created_at desc").Limit(1).Find(&address); result.Error == nil && result.RowsAffected > 0 {
+		pricingInput.Address = &address
+	}
+
+	// 与结算预览、创建订单使用同一套计价逻辑
+	quote, err := pricing.Calculate(config.DB, pricingInput)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
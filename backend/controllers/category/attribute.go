@@ -0,0 +1,231 @@
+package category
+
+import (
+	"net/http"
+	"strings"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/attribute"
+	"go-flutter-mall/backend/pkg/cache"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AttributeInput 创建/更新分类属性模板的输入参数
+type AttributeInput struct {
+	Name       string   `json:"name" binding:"required"`
+	Type       string   `json:"type" binding:"required,oneof=text number enum bool"`
+	Required   bool     `json:"required"`
+	Options    []string `json:"options"` // enum 类型的可选值
+	Unit       string   `json:"unit"`
+	Filterable bool     `json:"filterable"` // 是否可在商品列表中筛选
+	SortOrder  int      `json:"sort_order"`
+}
+
+// normalize 整理输入: 去除空白，enum 类型要求至少一个不重复的可选值，其他类型忽略可选值
+func (input *AttributeInput) normalize() string {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return "Attribute name is required"
+	}
+	if input.Type != models.AttributeEnum {
+		input.Options = nil
+		return ""
+	}
+
+	var options []string
+	seen := make(map[string]bool)
+	for _, o := range input.Options {
+		o = strings.TrimSpace(o)
+		if o == "" || seen[o] {
+			continue
+		}
+		seen[o] = true
+		options = append(options, o)
+	}
+	if len(options) == 0 {
+		return "Enum attribute needs at least one option"
+	}
+	input.Options = options
+	return ""
+}
+
+// apply 将输入写入属性模板
+func (input AttributeInput) apply(attr *models.CategoryAttribute) {
+	attr.Name = input.Name
+	attr.Type = input.Type
+	attr.Required = input.Required
+	attr.Options = input.Options
+	attr.Unit = input.Unit
+	attr.Filterable = input.Filterable
+	attr.SortOrder = input.SortOrder
+}
+
+// nameTaken 同一分类下属性名称不能重复
+func nameTaken(categoryID uint, name string, excludeID uint) bool {
+	var count int64
+	config.DB.Model(&models.CategoryAttribute{}).
+		Where("category_id = ? AND name = ? AND id <> ?", categoryID, name, excludeID).
+		Count(&count)
+	return count > 0
+}
+
+// GetCategoryAttributes 获取分类生效的属性模板
+// @Summary      Get Category Attributes
+// @Description  List attribute templates that apply to products of a category, including ones inherited from parent categories
+// @Tags         Category
+// @Produce      json
+// @Param        id   path      int  true  "Category ID"
+// @Success      200  {array}   models.CategoryAttribute
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /categories/{id}/attributes [get]
+func GetCategoryAttributes(c *gin.Context) {
+	var category models.Category
+	if err := config.DB.First(&category, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+		return
+	}
+
+	attrs, err := attribute.ForCategory(config.DB, category.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch attributes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, attrs)
+}
+
+// CreateCategoryAttribute 管理员为分类添加属性模板
+// @Summary      Create Category Attribute
+// @Description  Add an attribute template to a category; it applies to products of the category and its subcategories (Admin only)
+// @Tags         Category
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int             true  "Category ID"
+// @Param        input  body      AttributeInput  true  "Attribute Info"
+// @Success      201    {object}  models.CategoryAttribute
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /categories/admin/{id}/attributes [post]
+func CreateCategoryAttribute(c *gin.Context) {
+	var category models.Category
+	if err := config.DB.First(&category, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+		return
+	}
+
+	var input AttributeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if msg := input.normalize(); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	if nameTaken(category.ID, input.Name, 0) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Attribute name already exists in this category"})
+		return
+	}
+
+	attr := models.CategoryAttribute{CategoryID: category.ID}
+	input.apply(&attr)
+	if err := config.DB.Create(&attr).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create attribute"})
+		return
+	}
+	// 新属性出现在商品列表的属性分面中
+	cache.InvalidateProducts()
+
+	c.JSON(http.StatusCreated, attr)
+}
+
+// UpdateCategoryAttribute 管理员更新属性模板
+// 修改类型或可选值后，不符合新模板的已有商品在下次保存时需要修正属性值
+// @Summary      Update Category Attribute
+// @Description  Update an attribute template (Admin only)
+// @Tags         Category
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int             true  "Attribute ID"
+// @Param        input  body      AttributeInput  true  "Attribute Info"
+// @Success      200    {object}  models.CategoryAttribute
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /categories/admin/attributes/{id} [put]
+func UpdateCategoryAttribute(c *gin.Context) {
+	var attr models.CategoryAttribute
+	if err := config.DB.First(&attr, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Attribute not found"})
+		return
+	}
+
+	var input AttributeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if msg := input.normalize(); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	if nameTaken(attr.CategoryID, input.Name, attr.ID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Attribute name already exists in this category"})
+		return
+	}
+
+	input.apply(&attr)
+	if err := config.DB.Save(&attr).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update attribute"})
+		return
+	}
+	cache.InvalidateProducts(attributeProducts(attr.ID)...)
+
+	c.JSON(http.StatusOK, attr)
+}
+
+// attributeProducts 设置了该属性值的商品 ID，属性模板修改后需要失效这些商品的详情缓存
+func attributeProducts(attributeID uint) []uint {
+	var ids []uint
+	config.DB.Model(&models.ProductAttributeValue{}).Where("attribute_id = ?", attributeID).Distinct().Pluck("product_id", &ids)
+	return ids
+}
+
+// DeleteCategoryAttribute 管理员删除属性模板，同时删除商品上的该属性值
+// @Summary      Delete Category Attribute
+// @Description  Delete an attribute template and the values products have for it (Admin only)
+// @Tags         Category
+// @Produce      json
+// @Param        id   path      int  true  "Attribute ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /categories/admin/attributes/{id} [delete]
+func DeleteCategoryAttribute(c *gin.Context) {
+	var attr models.CategoryAttribute
+	if err := config.DB.First(&attr, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Attribute not found"})
+		return
+	}
+
+	products := attributeProducts(attr.ID)
+	tx := config.DB.Begin()
+	if err := tx.Where("attribute_id = ?", attr.ID).Delete(&models.ProductAttributeValue{}).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete attribute values"})
+		return
+	}
+	if err := tx.Delete(&attr).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete attribute"})
+		return
+	}
+	tx.Commit()
+	cache.InvalidateProducts(products...)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Attribute deleted successfully"})
+}
@@ -0,0 +1,376 @@
+package category
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/cache"
+	categorypkg "go-flutter-mall/backend/pkg/category"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CategoryInput 创建/更新分类的输入参数
+type CategoryInput struct {
+	ParentID  uint   `json:"parent_id"` // 父分类 ID，0 表示一级分类
+	Name      string `json:"name" binding:"required"`
+	SortOrder int    `json:"sort_order"`
+	Icon      string `json:"icon"`
+}
+
+// SortCategoryInput 分类排序的输入参数
+type SortCategoryInput struct {
+	ID        uint `json:"id" binding:"required"`
+	SortOrder int  `json:"sort_order"`
+}
+
+// resolveLevel 校验父分类并返回新分类的层级
+// id 为正在更新的分类 ID (创建时为 0)，不能把分类移动到自身或子孙分类下
+func resolveLevel(list []models.Category, id uint, parentID uint) (int, string) {
+	if parentID == 0 {
+		return 1, ""
+	}
+	if parentID == id {
+		return 0, "Category cannot be its own parent"
+	}
+	var parent *models.Category
+	for i := range list {
+		if list[i].ID == parentID {
+			parent = &list[i]
+			break
+		}
+	}
+	if parent == nil {
+		return 0, "Parent category not found"
+	}
+	if id != 0 {
+		for _, d := range categorypkg.Descendants(list, id) {
+			if d == parentID {
+				return 0, "Category cannot be moved under its descendant"
+			}
+		}
+	}
+	return parent.Level + 1, ""
+}
+
+// subtreeDepth 返回以 id 为根的子树深度 (只有自身时为 1)
+func subtreeDepth(list []models.Category, id uint) int {
+	depth := 1
+	for _, c := range list {
+		if c.ParentID == id {
+			if d := subtreeDepth(list, c.ID) + 1; d > depth {
+				depth = d
+			}
+		}
+	}
+	return depth
+}
+
+// relevel 分类移动后重新计算子孙分类的层级
+func relevel(tx *gorm.DB, list []models.Category, id uint, level int) error {
+	for _, c := range list {
+		if c.ParentID == id {
+			if err := tx.Model(&models.Category{}).Where("id = ?", c.ID).Update("level", level+1).Error; err != nil {
+				return err
+			}
+			if err := relevel(tx, list, c.ID, level+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GetCategoryTree 获取分类树
+// 优先读取 Redis 缓存，分类写入后失效
+// @Summary      Get Category Tree
+// @Description  Get all categories as a tree ordered by sort_order
+// @Tags         Category
+// @Produce      json
+// @Success      200  {array}   models.Category
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /categories [get]
+func GetCategoryTree(c *gin.Context) {
+	tree, err := cache.Fetch(cache.CategoryTreeKey, cache.CategoryTreeTTL, func() ([]models.Category, error) {
+		list, err := categorypkg.LoadAll(config.DB)
+		if err != nil {
+			return nil, err
+		}
+		return categorypkg.BuildTree(list), nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch categories"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
+
+// GetCategoryProducts 获取分类下的商品 (包含子孙分类)
+// @Summary      Get Category Products
+// @Description  Get on-shelf products of a category and all its descendants
+// @Tags         Category
+// @Produce      json
+// @Param        id         path      int  true   "Category ID"
+// @Param        page       query     int  false  "Page number" default(1)
+// @Param        page_size  query     int  false  "Page size" default(10)
+// @Success      200        {array}   models.Product
+// @Failure      404        {object}  map[string]interface{}
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /categories/{id}/products [get]
+func GetCategoryProducts(c *gin.Context) {
+	var category models.Category
+	if err := config.DB.First(&category, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+		return
+	}
+
+	ids, err := categorypkg.WithDescendants(config.DB, category.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch categories"})
+		return
+	}
+
+	// 解析分页参数
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	offset := (page - 1) * pageSize
+
+	products := []models.Product{}
+	if err := config.DB.Preload("SKUs").Where("category_id IN ? AND status = ?", ids, 1).
+		Order("id desc").Limit(pageSize).Offset(offset).Find(&products).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch products"})
+		return
+	}
+
+	c.JSON(http.StatusOK, products)
+}
+
+// GetAllCategories 管理员获取扁平分类列表
+// @Summary      Get All Categories
+// @Description  Get all categories as a flat list (Admin only)
+// @Tags         Category
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   models.Category
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /categories/admin/all [get]
+func GetAllCategories(c *gin.Context) {
+	list, err := categorypkg.LoadAll(config.DB)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch categories"})
+		return
+	}
+	if list == nil {
+		list = []models.Category{}
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// CreateCategory 管理员创建分类
+// @Summary      Create Category
+// @Description  Create a new category, up to three levels deep (Admin only)
+// @Tags         Category
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input  body      CategoryInput  true  "Category Info"
+// @Success      201    {object}  models.Category
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /categories/admin [post]
+func CreateCategory(c *gin.Context) {
+	var input CategoryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	list, err := categorypkg.LoadAll(config.DB)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch categories"})
+		return
+	}
+	level, msg := resolveLevel(list, 0, input.ParentID)
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	if level > categorypkg.MaxLevel {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Categories can be nested at most three levels"})
+		return
+	}
+
+	category := models.Category{
+		ParentID:  input.ParentID,
+		Level:     level,
+		Name:      input.Name,
+		SortOrder: input.SortOrder,
+		Icon:      input.Icon,
+	}
+	if err := config.DB.Create(&category).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create category"})
+		return
+	}
+	cache.InvalidateCategories()
+
+	c.JSON(http.StatusCreated, category)
+}
+
+// UpdateCategory 管理员更新分类
+// 修改父分类时同步更新子孙分类的层级
+// @Summary      Update Category
+// @Description  Update a category, optionally moving it under another parent (Admin only)
+// @Tags         Category
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      int            true  "Category ID"
+// @Param        input  body      CategoryInput  true  "Category Info"
+// @Success      200    {object}  models.Category
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /categories/admin/{id} [put]
+func UpdateCategory(c *gin.Context) {
+	var category models.Category
+	if err := config.DB.First(&category, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+		return
+	}
+
+	var input CategoryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	list, err := categorypkg.LoadAll(config.DB)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch categories"})
+		return
+	}
+	level, msg := resolveLevel(list, category.ID, input.ParentID)
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	if level+subtreeDepth(list, category.ID)-1 > categorypkg.MaxLevel {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Categories can be nested at most three levels"})
+		return
+	}
+
+	tx := config.DB.Begin()
+
+	category.ParentID = input.ParentID
+	category.Level = level
+	category.Name = input.Name
+	category.SortOrder = input.SortOrder
+	category.Icon = input.Icon
+	if err := tx.Save(&category).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update category"})
+		return
+	}
+	if err := relevel(tx, list, category.ID, level); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update category levels"})
+		return
+	}
+
+	tx.Commit()
+	cache.InvalidateCategories()
+
+	c.JSON(http.StatusOK, category)
+}
+
+// SortCategories 管理员批量调整分类排序
+// @Summary      Sort Categories
+// @Description  Update sort_order of multiple categories at once (Admin only)
+// @Tags         Category
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input  body      []SortCategoryInput  true  "New Sort Orders"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /categories/admin/sort [put]
+func SortCategories(c *gin.Context) {
+	var input []SortCategoryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tx := config.DB.Begin()
+	for _, item := range input {
+		if err := tx.Model(&models.Category{}).Where("id = ?", item.ID).Update("sort_order", item.SortOrder).Error; err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to sort categories"})
+			return
+		}
+	}
+	tx.Commit()
+	cache.InvalidateCategories()
+
+	c.JSON(http.StatusOK, gin.H{"message": "Categories sorted successfully"})
+}
+
+// DeleteCategory 管理员删除分类
+// 仍有商品或子分类的分类不允许删除
+// @Summary      Delete Category
+// @Description  Delete a category that has no products and no subcategories (Admin only)
+// @Tags         Category
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Category ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /categories/admin/{id} [delete]
+func DeleteCategory(c *gin.Context) {
+	var category models.Category
+	if err := config.DB.First(&category, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+		return
+	}
+
+	var children int64
+	if err := config.DB.Model(&models.Category{}).Where("parent_id = ?", category.ID).Count(&children).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check subcategories"})
+		return
+	}
+	if children > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Category still has subcategories"})
+		return
+	}
+
+	var products int64
+	if err := config.DB.Model(&models.Product{}).Where("category_id = ?", category.ID).Count(&products).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check category products"})
+		return
+	}
+	if products > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Category still has products"})
+		return
+	}
+
+	// 分类下已没有商品，属性模板随分类一起删除
+	if err := config.DB.Where("category_id = ?", category.ID).Delete(&models.CategoryAttribute{}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete category attributes"})
+		return
+	}
+	if err := config.DB.Delete(&category).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete category"})
+		return
+	}
+	cache.InvalidateCategories()
+
+	c.JSON(http.StatusOK, gin.H{"message": "Category deleted successfully"})
+}
@@ -0,0 +1,318 @@
+package coupon
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	couponpkg "go-flutter-mall/backend/pkg/coupon"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CouponTemplateInput 创建/更新优惠券模板的输入参数
+type CouponTemplateInput struct {
+	Name         string    `json:"name" binding:"required"`
+	Type         string    `json:"type" binding:"required,oneof=fixed percent threshold free_shipping"`
+	Amount       float64   `json:"amount"`
+	Rate         float64   `json:"rate"`
+	MinAmount    float64   `json:"min_amount"`
+	MaxDiscount  float64   `json:"max_discount"`
+	ScopeType    string    `json:"scope_type" binding:"omitempty,oneof=all category product"`
+	ScopeIDs     []int64   `json:"scope_ids"`
+	StartAt      time.Time `json:"start_at" binding:"required"`
+	EndAt        time.Time `json:"end_at" binding:"required"`
+	ValidDays    int       `json:"valid_days"`
+	TotalCount   int       `json:"total_count"`
+	PerUserLimit int       `json:"per_user_limit"`
+	Status       *int      `json:"status"`
+	Description  string    `json:"description"`
+}
+
+// validate 校验模板参数的业务约束
+func (input *CouponTemplateInput) validate() error {
+	if !input.EndAt.After(input.StartAt) {
+		return errors.New("end_at must be after start_at")
+	}
+	switch input.Type {
+	case models.CouponTypeFixed, models.CouponTypeThreshold:
+		if input.Amount <= 0 {
+			return errors.New("amount must be greater than 0")
+		}
+		// 满减券必须有门槛，且门槛高于减免金额，否则等同于无门槛券甚至白送
+		if input.Type == models.CouponTypeThreshold {
+			if input.MinAmount <= 0 {
+				return errors.New("min_amount must be greater than 0 for threshold coupons")
+			}
+			if input.Amount >= input.MinAmount {
+				return errors.New("amount must be less than min_amount for threshold coupons")
+			}
+		}
+	case models.CouponTypePercent:
+		if input.Rate <= 0 || input.Rate >= 1 {
+			return errors.New("rate must be between 0 and 1")
+		}
+	}
+	if input.ScopeType != "" && input.ScopeType != models.ScopeAll && len(input.ScopeIDs) == 0 {
+		return errors.New("scope_ids is required for scoped coupons")
+	}
+	return nil
+}
+
+// apply 将输入参数写入模板
+func (input *CouponTemplateInput) apply(tpl *models.CouponTemplate) {
+	tpl.Name = input.Name
+	tpl.Type = input.Type
+	tpl.Amount = input.Amount
+	tpl.Rate = input.Rate
+	tpl.MinAmount = input.MinAmount
+	tpl.MaxDiscount = input.MaxDiscount
+	tpl.ScopeType = input.ScopeType
+	if tpl.ScopeType == "" {
+		tpl.ScopeType = models.ScopeAll
+	}
+	tpl.ScopeIDs = input.ScopeIDs
+	tpl.StartAt = input.StartAt
+	tpl.EndAt = input.EndAt
+	tpl.ValidDays = input.ValidDays
+	tpl.TotalCount = input.TotalCount
+	tpl.PerUserLimit = input.PerUserLimit
+	if tpl.PerUserLimit <= 0 {
+		tpl.PerUserLimit = 1
+	}
+	if input.Status != nil {
+		tpl.Status = *input.Status
+	}
+	tpl.Description = input.Description
+}
+
+// GetCoupons 获取可领取的优惠券列表
+// @Summary      Get Claimable Coupons
+// @Description  Get a list of coupon templates that are currently claimable
+// @Tags         Coupon
+// @Produce      json
+// @Success      200  {array}   models.CouponTemplate
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /coupons [get]
+func GetCoupons(c *gin.Context) {
+	templates := []models.CouponTemplate{}
+	now := time.Now()
+
+	// 只返回启用中、在有效期内且未领完的模板
+	if err := config.DB.Where("status = ? AND start_at <= ? AND end_at > ?", 1, now, now).
+		Where("total_count = 0 OR issued_count < total_count").
+		Order("end_at asc").Find(&templates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch coupons"})
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+// ClaimCoupon 领取优惠券
+// @Summary      Claim Coupon
+// @Description  Claim a coupon from a template into the user's wallet
+// @Tags         Coupon
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Coupon Template ID"
+// @Success      201  {object}  models.UserCoupon
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /coupons/{id}/claim [post]
+func ClaimCoupon(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	templateID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid coupon id"})
+		return
+	}
+
+	tx := config.DB.Begin()
+	userCoupon, err := couponpkg.Claim(tx, userID.(uint), uint(templateID))
+	if err != nil {
+		tx.Rollback()
+		switch {
+		case errors.Is(err, couponpkg.ErrCouponNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, couponpkg.ErrCouponUnavailable), errors.Is(err, couponpkg.ErrCouponSoldOut), errors.Is(err, couponpkg.ErrCouponLimit):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to claim coupon"})
+		}
+		return
+	}
+	tx.Commit()
+
+	c.JSON(http.StatusCreated, userCoupon)
+}
+
+// GetMyCoupons 获取我的优惠券 (券包)
+// @Summary      Get My Coupons
+// @Description  Get coupons in the user's wallet, filtered by status
+// @Tags         Coupon
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status  query     string  false  "unused, used or expired" default(unused)
+// @Success      200     {array}   models.UserCoupon
+// @Failure      500     {object}  map[string]interface{}
+// @Router       /coupons/mine [get]
+func GetMyCoupons(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	coupons := []models.UserCoupon{}
+	now := time.Now()
+
+	query := config.DB.Preload("Template", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).Where("user_id = ?", userID)
+
+	// 过期状态不落库，根据 expire_at 实时判断
+	switch c.DefaultQuery("status", "unused") {
+	case "used":
+		query = query.Where("status = ?", 1)
+	case "expired":
+		query = query.Where("status = ? AND expire_at <= ?", 0, now)
+	default:
+		query = query.Where("status = ? AND expire_at > ?", 0, now)
+	}
+
+	if err := query.Order("expire_at asc").Find(&coupons).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch coupons"})
+		return
+	}
+
+	c.JSON(http.StatusOK, coupons)
+}
+
+// GetCouponTemplates 管理员获取优惠券模板列表
+// @Summary      Get Coupon Templates
+// @Description  Get all coupon templates (Admin only)
+// @Tags         Coupon
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   models.CouponTemplate
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /coupons/admin/templates [get]
+func GetCouponTemplates(c *gin.Context) {
+	templates := []models.CouponTemplate{}
+
+	if err := config.DB.Order("created_at desc").Find(&templates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch coupon templates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+// CreateCouponTemplate 管理员创建优惠券模板
+// @Summary      Create Coupon Template
+// @Description  Create a new coupon template (Admin only)
+// @Tags         Coupon
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input  body      CouponTemplateInput  true  "Coupon Template Info"
+// @Success      201    {object}  models.CouponTemplate
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /coupons/admin/templates [post]
+func CreateCouponTemplate(c *gin.Context) {
+	var input CouponTemplateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := input.validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tpl := models.CouponTemplate{Status: 1}
+	input.apply(&tpl)
+
+	if err := config.DB.Create(&tpl).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create coupon template"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, tpl)
+}
+
+// UpdateCouponTemplate 管理员更新优惠券模板
+// @Summary      Update Coupon Template
+// @Description  Update an existing coupon template (Admin only)
+// @Tags         Coupon
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      int                  true  "Coupon Template ID"
+// @Param        input  body      CouponTemplateInput  true  "Coupon Template Info"
+// @Success      200    {object}  models.CouponTemplate
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /coupons/admin/templates/{id} [put]
+func UpdateCouponTemplate(c *gin.Context) {
+	id := c.Param("id")
+	var tpl models.CouponTemplate
+
+	if err := config.DB.First(&tpl, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Coupon template not found"})
+		return
+	}
+
+	var input CouponTemplateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := input.validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.TotalCount > 0 && input.TotalCount < tpl.IssuedCount {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "total_count cannot be less than issued count"})
+		return
+	}
+
+	input.apply(&tpl)
+
+	if err := config.DB.Save(&tpl).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update coupon template"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tpl)
+}
+
+// DeleteCouponTemplate 管理员删除优惠券模板
+// 已领取的优惠券不受影响，仍可在有效期内使用
+// @Summary      Delete Coupon Template
+// @Description  Delete a coupon template (Admin only)
+// @Tags         Coupon
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Coupon Template ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /coupons/admin/templates/{id} [delete]
+func DeleteCouponTemplate(c *gin.Context) {
+	id := c.Param("id")
+	var tpl models.CouponTemplate
+
+	if err := config.DB.First(&tpl, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Coupon template not found"})
+		return
+	}
+
+	if err := config.DB.Delete(&tpl).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete coupon template"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Coupon template deleted successfully"})
+}
@@ -0,0 +1,227 @@
+package favorite
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/cache"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FavoriteInput 收藏商品的输入参数
+type FavoriteInput struct {
+	ProductID uint `json:"product_id" binding:"required"`
+}
+
+// FavoriteListResponse 收藏列表分页响应
+type FavoriteListResponse struct {
+	Items    []models.Favorite `json:"items"`
+	Total    int64             `json:"total"`
+	Page     int               `json:"page"`
+	PageSize int               `json:"page_size"`
+	Pages    int               `json:"pages"`
+}
+
+// FavoriteRankItem 收藏排行项
+type FavoriteRankItem struct {
+	ProductID  uint    `json:"product_id"`
+	Name       string  `json:"name"`
+	CoverImage string  `json:"cover_image"`
+	Price      float64 `json:"price"`
+	Status     int     `json:"status"`
+	Favorites  int64   `json:"favorites"` // 统计时间范围内新增的收藏数 (未指定范围时为当前收藏总数)
+}
+
+// AddFavorite 收藏商品
+// 重复收藏不报错；收藏数在同一事务中原子加一
+// @Summary      Add Favorite
+// @Description  Add a product to the current user's favorites (idempotent)
+// @Tags         Favorite
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input  body      FavoriteInput  true  "Product"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /favorites [post]
+func AddFavorite(c *gin.Context) {
+	userID := c.GetUint("userID")
+
+	var input FavoriteInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var product models.Product
+	if err := config.DB.First(&product, input.ProductID).Error; err != nil || product.Status == models.ProductStatusDraft {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+
+	added := false
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Favorite{UserID: userID, ProductID: product.ID})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		added = true
+		return tx.Exec("UPDATE products SET favorite_count = favorite_count + 1 WHERE id = ?", product.ID).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add favorite"})
+		return
+	}
+	if added {
+		// 收藏数已变化，清除商品缓存
+		cache.InvalidateProducts(product.ID)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Product added to favorites"})
+}
+
+// RemoveFavorite 取消收藏
+// @Summary      Remove Favorite
+// @Description  Remove a product from the current user's favorites
+// @Tags         Favorite
+// @Produce      json
+// @Security     BearerAuth
+// @Param        productId  path      int  true  "Product ID"
+// @Success      200        {object}  map[string]interface{}
+// @Failure      404        {object}  map[string]interface{}
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /favorites/{productId} [delete]
+func RemoveFavorite(c *gin.Context) {
+	userID := c.GetUint("userID")
+	productID, err := strconv.ParseUint(c.Param("productId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Favorite not found"})
+		return
+	}
+
+	removed := false
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND product_id = ?", userID, productID).Delete(&models.Favorite{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		removed = true
+		return tx.Exec("UPDATE products SET favorite_count = GREATEST(favorite_count - 1, 0) WHERE id = ?", productID).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove favorite"})
+		return
+	}
+	if !removed {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Favorite not found"})
+		return
+	}
+	cache.InvalidateProducts(uint(productID))
+
+	c.JSON(http.StatusOK, gin.H{"message": "Product removed from favorites"})
+}
+
+// GetFavorites 获取我的收藏
+// 按收藏时间倒序，已删除的商品不显示
+// @Summary      Get Favorites
+// @Description  Get a paginated list of the current user's favorite products, newest first
+// @Tags         Favorite
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page       query     int  false  "Page number" default(1)
+// @Param        page_size  query     int  false  "Page size" default(10)
+// @Success      200        {object}  FavoriteListResponse
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /favorites [get]
+func GetFavorites(c *gin.Context) {
+	userID := c.GetUint("userID")
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	query := config.DB.Model(&models.Favorite{}).
+		Joins("JOIN products ON products.id = favorites.product_id AND products.deleted_at IS NULL").
+		Where("favorites.user_id = ?", userID)
+
+	resp := FavoriteListResponse{Items: []models.Favorite{}, Page: page, PageSize: pageSize}
+	if err := query.Count(&resp.Total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch favorites"})
+		return
+	}
+	resp.Pages = int((resp.Total + int64(pageSize) - 1) / int64(pageSize))
+
+	if err := query.Preload("Product").
+		Order("favorites.created_at DESC, favorites.id DESC").
+		Limit(pageSize).Offset((page - 1) * pageSize).
+		Find(&resp.Items).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch favorites"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetMostFavorited 收藏排行
+// @Summary      Get Most Favorited Products
+// @Description  Rank products by favorites (Admin only). With from/to, only favorites added in that range are counted
+// @Tags         Favorite
+// @Produce      json
+// @Security     BearerAuth
+// @Param        from   query     string  false  "Start date (YYYY-MM-DD)"
+// @Param        to     query     string  false  "End date (YYYY-MM-DD, inclusive)"
+// @Param        limit  query     int     false  "Number of products" default(20)
+// @Success      200    {array}   FavoriteRankItem
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /favorites/admin/top [get]
+func GetMostFavorited(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	query := config.DB.Table("favorites").
+		Select("products.id AS product_id, products.name, products.cover_image, products.price, products.status, COUNT(favorites.id) AS favorites").
+		Joins("JOIN products ON products.id = favorites.product_id AND products.deleted_at IS NULL")
+	if v := c.Query("from"); v != "" {
+		from, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date"})
+			return
+		}
+		query = query.Where("favorites.created_at >= ?", from)
+	}
+	if v := c.Query("to"); v != "" {
+		to, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date"})
+			return
+		}
+		query = query.Where("favorites.created_at < ?", to.AddDate(0, 0, 1))
+	}
+
+	items := []FavoriteRankItem{}
+	if err := query.Group("products.id, products.name, products.cover_image, products.price, products.status").
+		Order("favorites DESC, products.id ASC").
+		Limit(limit).
+		Scan(&items).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch favorite ranking"})
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
@@ -2,22 +2,32 @@ package order
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
 	"go-flutter-mall/backend/config"
 	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/cache"
+	couponpkg "go-flutter-mall/backend/pkg/coupon"
 	"go-flutter-mall/backend/pkg/kafka"
+	"go-flutter-mall/backend/pkg/points"
+	"go-flutter-mall/backend/pkg/pricing"
 	"go-flutter-mall/backend/pkg/scheduler"
+	"go-flutter-mall/backend/pkg/stock"
+	"go-flutter-mall/backend/pkg/wallet"
+	"go-flutter-mall/backend/utils"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 // CreateOrderInput 创建订单的输入参数
 type CreateOrderInput struct {
 	AddressID uint `json:"address_id" binding:"required"` // 收货地址 ID
+	CouponID  uint `json:"coupon_id"`                     // 使用的用户优惠券 ID (可选)
+	Points    int  `json:"points" binding:"gte=0"`        // 使用的积分 (可选)
 }
 
 // CreateOrder 创建新订单
@@ -59,6 +69,18 @@ func CreateOrder(c *gin.Context) {
 		return
 	}
 
+	// 商品删除、下架或 SKU 删除后购物车项不可下单，需用户先移除
+	if err := pricing.MarkAvailability(tx, cartItems); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check cart items"})
+		return
+	}
+	if _, unavailable := pricing.AvailableItems(cartItems); len(unavailable) > 0 {
+		tx.Rollback()
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Some cart items are no longer available", "cart_item_ids": unavailable})
+		return
+	}
+
 	// Redis 并发控制 (分布式锁)
 	ctx := context.Background()
 	for _, item := range cartItems {
@@ -80,53 +102,66 @@ func CreateOrder(c *gin.Context) {
 		}
 	}
 
-	// 2. 计算总金额并准备订单项
-	var totalAmount float64
-	var orderItems []models.OrderItem
-
-	for _, item := range cartItems {
-		price := item.Product.Price
-		// TODO: 如果有 SKU，应该取 SKU 的价格
-		// if item.SKUID != 0 { ... }
+	// 2. 校验收货地址归属，地址用于计算运费
+	var address models.Address
+	if err := tx.Where("id = ? AND user_id = ?", input.AddressID, userID).First(&address).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Address not found"})
+		return
+	}
 
-		totalAmount += price * float64(item.Quantity)
+	// 3. 计算价格 (SKU 单价、自动促销、会员折扣、运费、优惠券、积分)，与购物车汇总和结算预览共用同一套计价逻辑
+	quote, err := pricing.Calculate(tx, pricing.Input{UserID: userID.(uint), Items: cartItems, CouponID: input.CouponID, Address: &address, Points: input.Points})
+	if err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
+	var orderItems []models.OrderItem
+	for _, line := range quote.Lines {
 		orderItems = append(orderItems, models.OrderItem{
-			ProductID:    item.ProductID,
-			ProductName:  item.Product.Name,
-			ProductImage: item.Product.CoverImage,
-			SKUID:        item.SKUID,
-			Price:        price,
-			Quantity:     item.Quantity,
+			ProductID:      line.ProductID,
+			ProductName:    line.ProductName,
+			ProductImage:   line.ProductImage,
+			SKUID:          line.SKUID,
+			SKUName:        line.SKUName,
+			Price:          line.Price,
+			Quantity:       line.Quantity,
+			DiscountAmount: line.Discount,
 		})
+	}
 
-		// 3. 扣减库存
+	// 4. 扣减库存 (商品及所选 SKU)
+	for _, item := range cartItems {
 		// 关键修复：使用 WHERE 条件检查库存是否充足 (stock >= quantity)
-		result := tx.Model(&models.Product{}).
-			Where("id = ? AND stock >= ?", item.ProductID, item.Quantity).
-			UpdateColumn("stock", gorm.Expr("stock - ?", item.Quantity))
-
-		if result.Error != nil {
+		if err := stock.Deduct(tx, item.ProductID, item.SKUID, item.Quantity); err != nil {
 			tx.Rollback()
+			if errors.Is(err, stock.ErrInsufficientStock) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Insufficient stock for product: %s", item.Product.Name)})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update stock"})
 			return
 		}
-
-		if result.RowsAffected == 0 {
-			tx.Rollback()
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Insufficient stock for product: %s", item.Product.Name)})
-			return
-		}
 	}
 
-	// 4. 创建订单记录
+	// 5. 创建订单记录
 	order := models.Order{
-		OrderNo:     fmt.Sprintf("%d%d", time.Now().UnixNano(), userID.(uint)), // 生成唯一订单号
-		UserID:      userID.(uint),
-		TotalAmount: totalAmount,
-		Status:      0, // 待支付
-		AddressID:   input.AddressID,
-		Items:       orderItems,
+		OrderNo:           fmt.Sprintf("%d%d", time.Now().UnixNano(), userID.(uint)), // 生成唯一订单号
+		UserID:            userID.(uint),
+		ProductAmount:     quote.ProductAmount,
+		PromotionDiscount: quote.PromotionDiscount,
+		MemberDiscount:    quote.MemberDiscount,
+		CouponID:          quote.CouponID,
+		CouponDiscount:    quote.CouponDiscount,
+		ShippingFee:       quote.ShippingFee,
+		PointsUsed:        quote.PointsUsed,
+		PointsDiscount:    quote.PointsDiscount,
+		TotalAmount:       quote.TotalAmount,
+		Status:            0, // 待支付
+		AddressID:         input.AddressID,
+		Items:             orderItems,
 	}
 
 	if err := tx.Create(&order).Error; err != nil {
@@ -136,7 +171,23 @@ func CreateOrder(c *gin.Context) {
 		return
 	}
 
-	// 5. 清空购物车中已购买的商品
+	// 6. 核销优惠券 (与订单在同一事务中，失败则整体回滚)
+	if input.CouponID != 0 {
+		if err := couponpkg.Redeem(tx, userID.(uint), input.CouponID, order.ID); err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	// 7. 扣减抵扣使用的积分
+	if err := points.Spend(tx, userID.(uint), quote.PointsUsed, order.ID); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 8. 清空购物车中已购买的商品
 	if err := tx.Where("user_id = ? AND selected = ?", userID, true).Delete(&models.CartItem{}).Error; err != nil {
 		tx.Rollback()
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clear cart"})
@@ -146,7 +197,14 @@ func CreateOrder(c *gin.Context) {
 	// 提交事务
 	tx.Commit()
 
-	// 6. 发送消息通知 (Kafka)
+	// 库存已变化，清除商品缓存
+	productIDs := make([]uint, 0, len(cartItems))
+	for _, item := range cartItems {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	cache.InvalidateProducts(productIDs...)
+
+	// 9. 发送消息通知 (Kafka)
 	// 生产 "OrderCreated" 消息
 	kafka.SendOrderEvent(kafka.OrderEvent{
 		OrderID:   order.ID,
@@ -154,14 +212,14 @@ func CreateOrder(c *gin.Context) {
 		EventType: "created",
 	})
 
-	// 7. 添加到延时队列 (Redis ZSet)
+	// 10. 添加到延时队列 (Redis ZSet)
 	// 30分钟后触发超时
 	// 如果 Redis 不可用，这里可能会失败，记录错误但不影响主流程
 	if err := scheduler.AddToDelayQueue(order.ID, order.UserID, 30*time.Minute); err != nil {
 		fmt.Printf("Warning: Failed to add to delay queue (Redis down?): %v\n", err)
 	}
 
-	// 8. 创建本地通知 (DB) - 也可以移到 Kafka Consumer 中处理
+	// 11. 创建本地通知 (DB) - 也可以移到 Kafka Consumer 中处理
 	notification := models.Notification{
 		UserID:  userID.(uint),
 		Title:   "订单创建成功",
@@ -173,6 +231,86 @@ func CreateOrder(c *gin.Context) {
 	c.JSON(http.StatusCreated, order)
 }
 
+// PreviewOrderInput 结算预览的输入参数
+type PreviewOrderInput struct {
+	AddressID uint `json:"address_id"` // 收货地址 ID (可选，默认使用默认地址)
+	CouponID  uint `json:"coupon_id"`  // 使用的用户优惠券 ID (可选)
+	Points    int  `json:"points"`     // 使用的积分 (可选)
+}
+
+// PreviewOrder 结算预览
+// @Summary      Preview Order
+// @Description  Calculate the price breakdown of selected cart items without creating an order
+// @Tags         Order
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input  body      PreviewOrderInput  true  "Preview Info"
+// @Success      200    {object}  pricing.Quote
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /orders/preview [post]
+func PreviewOrder(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	var input PreviewOrderInput
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var cartItems []models.CartItem
+	if err := config.DB.Preload("Product").Where("user_id = ? AND selected = ?", userID, true).Find(&cartItems).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch cart items"})
+		return
+	}
+
+	if len(cartItems) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No items selected in cart"})
+		return
+	}
+
+	if err := pricing.MarkAvailability(config.DB, cartItems); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check cart items"})
+		return
+	}
+	if _, unavailable := pricing.AvailableItems(cartItems); len(unavailable) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Some cart items are no longer available", "cart_item_ids": unavailable})
+		return
+	}
+
+	// 未指定地址时使用默认地址计算运费
+	var address models.Address
+	query := config.DB.Where("user_id = ?", userID)
+	if input.AddressID != 0 {
+		query = query.Where("id = ?", input.AddressID)
+	} else {
+		query = query.Order("is_default desc, created_at desc")
+	}
+	result := query.Limit(1).Find(&address)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch address"})
+		return
+	}
+	if input.AddressID != 0 && result.RowsAffected == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Address not found"})
+		return
+	}
+
+	pricingInput := pricing.Input{UserID: userID.(uint), Items: cartItems, CouponID: input.CouponID, Points: input.Points}
+	if result.RowsAffected > 0 {
+		pricingInput.Address = &address
+	}
+
+	quote, err := pricing.Calculate(config.DB, pricingInput)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
 // GetOrders 获取订单列表
 // @Summary      Get Order List
 // @Description  Get a list of orders for the authenticated user
@@ -264,35 +402,177 @@ func GetOrderDetail(c *gin.Context) {
 	c.JSON(http.StatusOK, order)
 }
 
+// PayOrderInput 支付订单的输入参数 (请求体可省略)
+type PayOrderInput struct {
+	UseWallet bool `json:"use_wallet"` // 是否使用钱包余额，余额不足部分走第三方支付
+}
+
 // PayOrder 模拟支付订单
+// 支持钱包余额与第三方支付组合支付，支付成功后按实付金额发放积分并累计会员消费
 // @Summary      Pay Order
-// @Description  Simulate payment for an order
+// @Description  Pay for an order with wallet balance, the gateway (simulated), or both; awards loyalty points
 // @Tags         Order
+// @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id   path      int  true  "Order ID"
-// @Success      200  {object}  map[string]interface{}
-// @Failure      400  {object}  map[string]interface{}
-// @Failure      500  {object}  map[string]interface{}
+// @Param        id     path      int            true   "Order ID"
+// @Param        input  body      PayOrderInput  false  "Payment Options"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
 // @Router       /orders/{id}/pay [post]
 func PayOrder(c *gin.Context) {
 	id := c.Param("id")
 	userID, _ := c.Get("userID")
+	var input PayOrderInput
+
+	if err := c.ShouldBindJSON(&input); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tx := config.DB.Begin()
 
 	// 更新订单状态为已支付 (1)
 	// 必须确保订单状态为 0 (待支付) 才能支付
-	result := config.DB.Model(&models.Order{}).Where("id = ? AND user_id = ? AND status = ?", id, userID, 0).Update("status", 1)
+	result := tx.Model(&models.Order{}).Where("id = ? AND user_id = ? AND status = ?", id, userID, 0).Update("status", 1)
 	if result.Error != nil {
+		tx.Rollback()
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to pay order"})
 		return
 	}
 
 	if result.RowsAffected == 0 {
+		tx.Rollback()
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Order cannot be paid (maybe cancelled or already paid)"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Order paid successfully"})
+	var order models.Order
+	if err := tx.First(&order, id).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load order"})
+		return
+	}
+
+	// 计算钱包与第三方支付的拆分金额
+	order.PaymentMethod = "gateway"
+	order.GatewayAmount = order.TotalAmount
+	if input.UseWallet {
+		account, err := wallet.FindWallet(tx, order.UserID)
+		if err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch wallet"})
+			return
+		}
+		order.WalletAmount = utils.RoundAmount(min(account.Balance, order.TotalAmount))
+		order.GatewayAmount = utils.RoundAmount(order.TotalAmount - order.WalletAmount)
+		if order.WalletAmount > 0 {
+			if err := wallet.Pay(tx, order.UserID, order.WalletAmount, order.ID); err != nil {
+				tx.Rollback()
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			order.PaymentMethod = "wallet"
+			if order.GatewayAmount > 0 {
+				order.PaymentMethod = "mixed"
+			}
+		}
+	}
+	// 第三方支付部分为模拟支付，直接视为成功
+	if err := tx.Model(&order).Updates(map[string]interface{}{
+		"payment_method": order.PaymentMethod,
+		"wallet_amount":  order.WalletAmount,
+		"gateway_amount": order.GatewayAmount,
+	}).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to pay order"})
+		return
+	}
+
+	// 发放积分
+	if err := points.EarnForOrder(tx, &order); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to award points"})
+		return
+	}
+
+	tx.Commit()
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "Order paid successfully",
+		"payment_method": order.PaymentMethod,
+		"wallet_amount":  order.WalletAmount,
+		"gateway_amount": order.GatewayAmount,
+	})
+}
+
+// CancelOrder 取消订单
+// @Summary      Cancel Order
+// @Description  Cancel an unpaid order, restoring stock and returning the coupon and points
+// @Tags         Order
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Order ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /orders/{id}/cancel [post]
+func CancelOrder(c *gin.Context) {
+	id := c.Param("id")
+	userID, _ := c.Get("userID")
+
+	tx := config.DB.Begin()
+
+	// 只有状态为 0 (待支付) 的订单才能取消，更新为 -1 (已取消)
+	result := tx.Model(&models.Order{}).Where("id = ? AND user_id = ? AND status = ?", id, userID, 0).Update("status", -1)
+	if result.Error != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel order"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Order cannot be cancelled (maybe paid or already cancelled)"})
+		return
+	}
+
+	// 恢复库存
+	var order models.Order
+	if err := tx.Preload("Items").First(&order, id).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load order"})
+		return
+	}
+	if err := stock.Restore(tx, order.Items); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore stock"})
+		return
+	}
+
+	// 退还优惠券
+	if err := couponpkg.Release(tx, order.ID); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to return coupon"})
+		return
+	}
+
+	// 退还抵扣的积分
+	if err := points.Release(tx, &order); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to return points"})
+		return
+	}
+
+	tx.Commit()
+
+	productIDs := make([]uint, 0, len(order.Items))
+	for _, item := range order.Items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	cache.InvalidateProducts(productIDs...)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Order cancelled successfully"})
 }
 
 // ConfirmReceipt 确认收货
@@ -326,7 +606,7 @@ type ReviewOrderInput struct {
 
 // ReviewOrder 评价订单
 // @Summary      Review Order
-// @Description  Submit a review for an order
+// @Description  Submit a review for an order and award loyalty points
 // @Tags         Order
 // @Accept       json
 // @Produce      json
@@ -380,11 +660,26 @@ func ReviewOrder(c *gin.Context) {
 	}
 
 	// 2. 更新订单状态
-	if err := tx.Model(&models.Order{}).Where("id = ? AND user_id = ? AND status = ?", id, userID, 3).Update("status", 4).Error; err != nil {
+	result := tx.Model(&models.Order{}).Where("id = ? AND user_id = ? AND status = ?", id, userID, 3).Update("status", 4)
+	if result.Error != nil {
 		tx.Rollback()
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update order status"})
 		return
 	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Order cannot be reviewed"})
+		return
+	}
+
+	// 3. 评价奖励积分，只奖励有支付记录的订单
+	if order.PaymentMethod != "" {
+		if err := points.EarnForReview(tx, userID.(uint), order.ID); err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to award points"})
+			return
+		}
+	}
 
 	tx.Commit()
 
@@ -414,6 +709,109 @@ func ApplyAfterSales(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "After-sales applied successfully"})
 }
 
+// RefundOrder 管理员为订单退款
+// @Summary      Refund Order
+// @Description  Refund a paid order to the user's wallet, returning used points and reversing earned points (Admin only)
+// @Tags         Order
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Order ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /orders/{id}/refund [post]
+func RefundOrder(c *gin.Context) {
+	// 路由已经过 AdminMiddleware，这里再次校验，防止被挂到普通用户路由下
+	if c.GetString("role") != models.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Admin privileges required"})
+		return
+	}
+	id := c.Param("id")
+
+	tx := config.DB.Begin()
+
+	var order models.Order
+	if err := tx.First(&order, id).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+		return
+	}
+
+	// 只有已支付 (1-5) 的订单才能退款，更新为 6 (已退款)
+	result := tx.Model(&models.Order{}).Where("id = ? AND status BETWEEN ? AND ?", order.ID, 1, 5).Update("status", 6)
+	if result.Error != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refund order"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Order cannot be refunded (maybe unpaid or already refunded)"})
+		return
+	}
+
+	// 退还抵扣的积分，扣回订单获得的积分
+	if err := points.Refund(tx, &order); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reverse points"})
+		return
+	}
+
+	// 恢复库存 (与取消订单一致，退款视为商品退回)
+	var items []models.OrderItem
+	if err := tx.Where("order_id = ?", order.ID).Find(&items).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load order"})
+		return
+	}
+	if err := stock.Restore(tx, items); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore stock"})
+		return
+	}
+
+	// 退还优惠券
+	if err := couponpkg.Release(tx, order.ID); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to return coupon"})
+		return
+	}
+
+	// 实付金额统一退回钱包: 钱包支付部分从订单账户转回，第三方支付部分从清算账户转入
+	// 钱包功能上线前支付的订单记录了支付方式但没有拆分金额，按第三方支付处理；没有支付记录的订单不退款
+	walletAmount, gatewayAmount := order.WalletAmount, order.GatewayAmount
+	if walletAmount == 0 && gatewayAmount == 0 && order.PaymentMethod != "" {
+		gatewayAmount = order.TotalAmount
+	}
+	if walletAmount+gatewayAmount > 0 {
+		if err := wallet.Refund(tx, order.UserID, walletAmount, gatewayAmount, order.ID); err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refund to wallet"})
+			return
+		}
+	}
+
+	tx.Commit()
+
+	productIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	cache.InvalidateProducts(productIDs...)
+
+	notification := models.Notification{
+		UserID:  order.UserID,
+		Title:   "订单已退款",
+		Content: fmt.Sprintf("您的订单 %s 已退款，%.2f 元已退回钱包。", order.OrderNo, order.TotalAmount),
+		IsRead:  false,
+	}
+	config.DB.Create(&notification)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Order refunded successfully"})
+}
+
 // UpdateOrderStatusInput 更新订单状态的输入参数
 type UpdateOrderStatusInput struct {
 	Status int `json:"status" binding:"required"`
@@ -430,10 +828,16 @@ type UpdateOrderStatusInput struct {
 // @Param        input  body      UpdateOrderStatusInput  true  "New Status"
 // @Success      200    {object}  map[string]interface{}
 // @Failure      400    {object}  map[string]interface{}
+// @Failure      403    {object}  map[string]interface{}
 // @Failure      404    {object}  map[string]interface{}
 // @Failure      500    {object}  map[string]interface{}
 // @Router       /orders/{id}/status [put]
 func UpdateOrderStatus(c *gin.Context) {
+	// 路由已经过 AdminMiddleware，这里再次校验，防止被挂到普通用户路由下
+	if c.GetString("role") != models.RoleAdmin {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Admin privileges required"})
+		return
+	}
 	id := c.Param("id")
 	var input UpdateOrderStatusInput
 
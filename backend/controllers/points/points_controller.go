@@ -0,0 +1,136 @@
+package points
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	pointspkg "go-flutter-mall/backend/pkg/points"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPointsAccount 获取当前用户的积分余额
+// @Summary      Get Points Balance
+// @Description  Get the points balance of the authenticated user
+// @Tags         Points
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  models.PointsAccount
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /points [get]
+func GetPointsAccount(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	account, err := pointspkg.FindAccount(config.DB, userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch points account"})
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+// GetPointsTransactions 获取当前用户的积分流水
+// @Summary      Get Points History
+// @Description  Get the points transaction history of the authenticated user
+// @Tags         Points
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page       query     int  false  "Page number" default(1)
+// @Param        page_size  query     int  false  "Page size" default(20)
+// @Success      200        {array}   models.PointsTransaction
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /points/transactions [get]
+func GetPointsTransactions(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	records := []models.PointsTransaction{}
+
+	// 解析分页参数
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	offset := (page - 1) * pageSize
+
+	if err := config.DB.Where("user_id = ?", userID).Order("id desc").Limit(pageSize).Offset(offset).Find(&records).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch points history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, records)
+}
+
+// GetMemberLevel 获取当前用户的会员等级
+// @Summary      Get Member Level
+// @Description  Get the member tier of the authenticated user, progress to the next tier and all tier benefits
+// @Tags         Points
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /points/level [get]
+func GetMemberLevel(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	account, err := pointspkg.FindAccount(config.DB, userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch points account"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"cumulative_spend": account.CumulativeSpend,
+		"level":            pointspkg.LevelFor(account.CumulativeSpend),
+		"next_level":       pointspkg.NextLevel(account.CumulativeSpend),
+		"levels":           pointspkg.Levels,
+	})
+}
+
+// AdjustPointsInput 管理员调整积分的输入参数
+type AdjustPointsInput struct {
+	UserID uint   `json:"user_id" binding:"required"`
+	Change int    `json:"change" binding:"required"` // 正数为增加，负数为扣减
+	Remark string `json:"remark" binding:"required"`
+}
+
+// AdjustPoints 管理员调整用户积分
+// @Summary      Adjust Points
+// @Description  Add or deduct points for a user (Admin only)
+// @Tags         Points
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input  body      AdjustPointsInput  true  "Adjustment Info"
+// @Success      200    {object}  models.PointsTransaction
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /points/admin/adjust [post]
+func AdjustPoints(c *gin.Context) {
+	var input AdjustPointsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var user models.User
+	if err := config.DB.First(&user, input.UserID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	tx := config.DB.Begin()
+	record, err := pointspkg.Adjust(tx, user.ID, input.Change, input.Remark)
+	if err != nil {
+		tx.Rollback()
+		if err == pointspkg.ErrInsufficientPoints {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to adjust points"})
+		return
+	}
+	tx.Commit()
+
+	c.JSON(http.StatusOK, record)
+}
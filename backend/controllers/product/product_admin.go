@@ -0,0 +1,463 @@
+package product
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/attribute"
+	"go-flutter-mall/backend/pkg/cache"
+	"go-flutter-mall/backend/pkg/pricewatch"
+	"go-flutter-mall/backend/pkg/search"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// errSKUNotFound 更新时传入的 SKU ID 不属于该商品
+var errSKUNotFound = errors.New("sku does not belong to product")
+
+// ProductPatchInput 部分更新商品的输入参数，未传的字段保持不变
+type ProductPatchInput struct {
+	Name              *string           `json:"name" binding:"omitempty,min=1"`
+	Description       *string           `json:"description"`
+	Price             *float64          `json:"price" binding:"omitempty,gt=0"`
+	Stock             *int              `json:"stock" binding:"omitempty,min=0"`
+	CoverImage        *string           `json:"cover_image"`
+	Images            []string          `json:"images"` // 传入时整体替换轮播图
+	CategoryID        *uint             `json:"category_id" binding:"omitempty,min=1"`
+	BrandID           *uint             `json:"brand_id"` // 0 表示取消品牌
+	Status            *int              `json:"status" binding:"omitempty,oneof=0 1 2"`
+	Weight            *float64          `json:"weight" binding:"omitempty,min=0"`
+	FreightTemplateID *uint             `json:"freight_template_id"`
+	SKUs              []ProductSKUInput `json:"skus" binding:"dive"` // 传入时按 ID 同步 SKU，规则同 PUT
+	Attributes        map[uint]string   `json:"attributes"`          // 传入时整体替换分类属性值
+
+	PublishAt   *time.Time `json:"publish_at"`   // 定时上架时间，清除请使用 PUT /products/:id/schedule
+	UnpublishAt *time.Time `json:"unpublish_at"` // 定时下架时间
+}
+
+// ProductStatusInput 商品上下架的输入参数
+type ProductStatusInput struct {
+	Status *int `json:"status" binding:"required,oneof=0 1 2"` // 1-上架, 0-下架, 2-草稿
+}
+
+// ProductImagesInput 商品轮播图的输入参数
+type ProductImagesInput struct {
+	Images []string `json:"images" binding:"required,dive,required"`
+}
+
+// reconcileSKUs 按 ID 同步商品 SKU: 已有 ID 更新、ID 为 0 新增、输入中未出现的已有 SKU 软删除
+// 软删除的 SKU 仍可被历史订单和购物车引用，购物车中会显示为不可购买
+func reconcileSKUs(tx *gorm.DB, productID uint, inputs []ProductSKUInput) error {
+	var existing []models.ProductSKU
+	if err := tx.Where("product_id = ?", productID).Find(&existing).Error; err != nil {
+		return err
+	}
+	byID := make(map[uint]models.ProductSKU, len(existing))
+	for _, sku := range existing {
+		byID[sku.ID] = sku
+	}
+
+	kept := make(map[uint]bool)
+	for _, input := range inputs {
+		if input.ID == 0 {
+			sku := models.ProductSKU{
+				ProductID: productID,
+				Name:      input.Name,
+				Specs:     input.Specs,
+				Price:     input.Price,
+				Stock:     input.Stock,
+				Image:     input.Image,
+				Code:      input.Code,
+			}
+			if err := tx.Create(&sku).Error; err != nil {
+				return err
+			}
+			continue
+		}
+
+		sku, ok := byID[input.ID]
+		if !ok || kept[input.ID] {
+			return errSKUNotFound
+		}
+		kept[input.ID] = true
+		sku.Name = input.Name
+		sku.Specs = input.Specs
+		sku.Price = input.Price
+		sku.Stock = input.Stock
+		sku.Image = input.Image
+		// 编码用于批量导入匹配，未传时保留原编码
+		if input.Code != "" {
+			sku.Code = input.Code
+		}
+		if err := tx.Save(&sku).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, sku := range existing {
+		if kept[sku.ID] {
+			continue
+		}
+		if err := tx.Delete(&sku).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateBrand 校验品牌存在 (0 表示无品牌)，不存在时写入 400 响应
+func validateBrand(c *gin.Context, brandID uint) bool {
+	if brandID == 0 {
+		return true
+	}
+	var count int64
+	config.DB.Model(&models.Brand{}).Where("id = ?", brandID).Count(&count)
+	if count == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Brand not found"})
+		return false
+	}
+	return true
+}
+
+// validateAttributes 按商品分类 (含祖先分类) 的属性模板校验属性值，校验失败时写入 400 响应
+func validateAttributes(c *gin.Context, categoryID uint, values map[uint]string) (map[uint]string, bool) {
+	templates, err := attribute.ForCategory(config.DB, categoryID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch category attributes"})
+		return nil, false
+	}
+	result, err := attribute.Validate(templates, values)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return result, true
+}
+
+// sortAttributes 按属性模板排序，并去掉模板已删除的属性值
+func sortAttributes(values []models.ProductAttributeValue) []models.ProductAttributeValue {
+	result := make([]models.ProductAttributeValue, 0, len(values))
+	for _, v := range values {
+		if v.Attribute != nil {
+			result = append(result, v)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i].Attribute, result[j].Attribute
+		if a.SortOrder != b.SortOrder {
+			return a.SortOrder < b.SortOrder
+		}
+		return a.ID < b.ID
+	})
+	return result
+}
+
+// saveProduct 在事务中保存商品并同步 SKU (skus 为 nil 时不修改 SKU) 和记录价格历史，成功后更新搜索索引、检查降价提醒并返回包含 SKU 的商品
+// attrs 为 nil 时沿用已保存的属性值，属性值总是按商品当前分类的模板重新校验
+func saveProduct(c *gin.Context, product *models.Product, skus []ProductSKUInput, attrs map[uint]string) {
+	if attrs == nil {
+		var err error
+		if attrs, err = attribute.Values(config.DB, product.ID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch product attributes"})
+			return
+		}
+	}
+	if !validateBrand(c, product.BrandID) || !validateSchedule(c, product.PublishAt, product.UnpublishAt) {
+		return
+	}
+	attrs, ok := validateAttributes(c, product.CategoryID, attrs)
+	if !ok {
+		return
+	}
+
+	tx := config.DB.Begin()
+
+	// 修改前的价格，用于记录价格历史
+	prices, err := pricewatch.Take(tx, product.ID)
+	if err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update product"})
+		return
+	}
+
+	if err := tx.Save(product).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update product"})
+		return
+	}
+
+	if err := attribute.Save(tx, product.ID, attrs); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save product attributes"})
+		return
+	}
+
+	if skus != nil {
+		if err := reconcileSKUs(tx, product.ID, skus); err != nil {
+			tx.Rollback()
+			if errors.Is(err, errSKUNotFound) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "SKU not found in this product"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update product SKUs"})
+			return
+		}
+	}
+
+	if err := pricewatch.Record(tx, product.ID, prices, models.PriceSourceAdmin); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record price history"})
+		return
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update product"})
+		return
+	}
+
+	// 同步搜索索引和缓存，检查降价提醒
+	search.IndexProduct(product)
+	cache.InvalidateProducts(product.ID)
+	pricewatch.CheckAlerts(config.DB, product.ID)
+
+	config.DB.Preload("SKUs").First(product, product.ID)
+	c.JSON(http.StatusOK, product)
+}
+
+// PatchProduct 部分更新商品
+// @Summary      Patch Product
+// @Description  Update only the provided fields of a product; skus, when provided, are reconciled by ID (Admin only)
+// @Tags         Product
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                true  "Product ID"
+// @Param        input  body      ProductPatchInput  true  "Fields to update"
+// @Success      200    {object}  models.Product
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /products/{id} [patch]
+func PatchProduct(c *gin.Context) {
+	var product models.Product
+	if err := config.DB.First(&product, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+
+	var input ProductPatchInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if input.Name != nil {
+		product.Name = *input.Name
+	}
+	if input.Description != nil {
+		product.Description = *input.Description
+	}
+	if input.Price != nil {
+		product.Price = *input.Price
+	}
+	if input.Stock != nil {
+		product.Stock = *input.Stock
+	}
+	if input.CoverImage != nil {
+		product.CoverImage = *input.CoverImage
+	}
+	if input.Images != nil {
+		product.Images = input.Images
+	}
+	if input.CategoryID != nil {
+		product.CategoryID = *input.CategoryID
+	}
+	if input.BrandID != nil {
+		product.BrandID = *input.BrandID
+	}
+	if input.Status != nil {
+		product.Status = *input.Status
+	}
+	if input.Weight != nil {
+		product.Weight = *input.Weight
+	}
+	if input.FreightTemplateID != nil {
+		product.FreightTemplateID = *input.FreightTemplateID
+	}
+	if input.PublishAt != nil {
+		product.PublishAt = input.PublishAt
+	}
+	if input.UnpublishAt != nil {
+		product.UnpublishAt = input.UnpublishAt
+	}
+
+	saveProduct(c, &product, input.SKUs, input.Attributes)
+}
+
+// UpdateProductStatus 商品上下架
+// 下架和草稿商品不出现在列表和搜索中，购物车中的该商品显示为不可购买；草稿商品的详情也不对外可见
+// @Summary      Update Product Status
+// @Description  Put a product on shelf (1), off shelf (0) or back to draft (2) (Admin only)
+// @Tags         Product
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                 true  "Product ID"
+// @Param        input  body      ProductStatusInput  true  "Status"
+// @Success      200    {object}  models.Product
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /products/{id}/status [put]
+func UpdateProductStatus(c *gin.Context) {
+	var product models.Product
+	if err := config.DB.First(&product, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+
+	var input ProductStatusInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := config.DB.Model(&product).Update("status", *input.Status).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update product status"})
+		return
+	}
+	product.Status = *input.Status
+
+	// 同步搜索索引和缓存
+	search.IndexProduct(&product)
+	cache.InvalidateProducts(product.ID)
+
+	c.JSON(http.StatusOK, product)
+}
+
+// updateImages 保存商品轮播图并同步搜索索引和缓存
+func updateImages(c *gin.Context, product *models.Product, images []string) {
+	if err := config.DB.Model(product).Update("images", pq.StringArray(images)).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update product images"})
+		return
+	}
+	product.Images = images
+
+	search.IndexProduct(product)
+	cache.InvalidateProducts(product.ID)
+
+	c.JSON(http.StatusOK, gin.H{"images": product.Images})
+}
+
+// AddProductImages 追加商品轮播图
+// @Summary      Add Product Images
+// @Description  Append images to the product gallery, skipping ones already present (Admin only)
+// @Tags         Product
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                 true  "Product ID"
+// @Param        input  body      ProductImagesInput  true  "Image URLs"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /products/{id}/images [post]
+func AddProductImages(c *gin.Context) {
+	var product models.Product
+	if err := config.DB.First(&product, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+
+	var input ProductImagesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	images := append([]string{}, product.Images...)
+	seen := make(map[string]bool, len(images))
+	for _, url := range images {
+		seen[url] = true
+	}
+	for _, url := range input.Images {
+		if !seen[url] {
+			seen[url] = true
+			images = append(images, url)
+		}
+	}
+
+	updateImages(c, &product, images)
+}
+
+// ReplaceProductImages 替换或重新排序商品轮播图
+// @Summary      Replace Product Images
+// @Description  Replace the product gallery with the given ordered list (Admin only)
+// @Tags         Product
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                 true  "Product ID"
+// @Param        input  body      ProductImagesInput  true  "Ordered image URLs"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /products/{id}/images [put]
+func ReplaceProductImages(c *gin.Context) {
+	var product models.Product
+	if err := config.DB.First(&product, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+
+	var input ProductImagesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updateImages(c, &product, input.Images)
+}
+
+// DeleteProductImage 删除一张商品轮播图
+// @Summary      Delete Product Image
+// @Description  Remove an image from the product gallery (Admin only)
+// @Tags         Product
+// @Produce      json
+// @Param        id   path      int     true  "Product ID"
+// @Param        url  query     string  true  "Image URL"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /products/{id}/images [delete]
+func DeleteProductImage(c *gin.Context) {
+	var product models.Product
+	if err := config.DB.First(&product, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+
+	url := c.Query("url")
+	if url == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "url is required"})
+		return
+	}
+
+	images := []string{}
+	for _, image := range product.Images {
+		if image != url {
+			images = append(images, image)
+		}
+	}
+	if len(images) == len(product.Images) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Image not found"})
+		return
+	}
+
+	updateImages(c, &product, images)
+}
@@ -1,92 +1,271 @@
 package product
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"go-flutter-mall/backend/config"
 	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/attribute"
+	"go-flutter-mall/backend/pkg/browsing"
+	"go-flutter-mall/backend/pkg/cache"
+	"go-flutter-mall/backend/pkg/search"
+	"go-flutter-mall/backend/pkg/spec"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // GetProducts 获取商品列表
+// 默认只返回上架商品，分类筛选包含子孙分类；关键词搜索由搜索后端按相关度排序并返回高亮片段，并记录到搜索日志
 // @Summary      Get Product List
-// @Description  Get a list of products with pagination and search
+// @Description  Get a paginated list of products with filters, sorting and facet counts
 // @Tags         Product
 // @Produce      json
-// @Param        page       query     int     false  "Page number" default(1)
-// @Param        page_size  query     int     false  "Page size" default(10)
-// @Param        search     query     string  false  "Search keyword"
-// @Success      200        {array}   models.Product
-// @Failure      500        {object}  map[string]interface{}
+// @Param        page         query     int     false  "Page number" default(1)
+// @Param        page_size    query     int     false  "Page size" default(10)
+// @Param        search       query     string  false  "Search keyword"
+// @Param        category_id  query     int     false  "Category ID (includes subcategories)"
+// @Param        brand_id     query     string  false  "Brand IDs, comma separated"
+// @Param        min_price    query     number  false  "Minimum price"
+// @Param        max_price    query     number  false  "Maximum price"
+// @Param        in_stock     query     bool    false  "Only products in stock"
+// @Param        attr         query     string  false  "Attribute filters as attr[<attribute_id>]=v1,v2 (values of one attribute are ORed)"
+// @Param        status       query     string  false  "Status: 1 on shelf (default), 0 off shelf, 2 draft, all (ignored without an admin token)"
+// @Param        sort         query     string  false  "Sort: relevance (default when searching), newest (default), price_asc, price_desc, sales, rating"
+// @Security     BearerAuth
+// @Success      200          {object}  ProductListResponse
+// @Failure      400          {object}  map[string]interface{}
+// @Failure      500          {object}  map[string]interface{}
+// @Failure      503          {object}  map[string]interface{}
 // @Router       /products [get]
 func GetProducts(c *gin.Context) {
-	var products []models.Product
+	started := time.Now()
 
 	// 解析分页参数
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
-	offset := (page - 1) * pageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
 
-	// 查询数据库，预加载 SKU 信息
-	query := config.DB.Preload("SKUs")
+	filter, err := parseProductFilter(c)
+	if errors.Is(err, errSearchUnavailable) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Search service unavailable"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filter parameters"})
+		return
+	}
 
-	// 搜索功能
-	search := c.Query("search")
-	if search != "" {
-		query = query.Where("name ILIKE ? OR description ILIKE ?", "%"+search+"%", "%"+search+"%")
+	sort := c.Query("sort")
+	if sort == "" {
+		sort = "newest"
+		if filter.Ranked {
+			sort = "relevance"
+		}
+	}
+	var orderBy string
+	if sort == "relevance" {
+		orderBy = productSorts["newest"]
+		if filter.Ranked {
+			orderBy = filter.relevanceOrder()
+		}
+	} else if expr, ok := productSorts[sort]; ok {
+		orderBy = expr
+	} else {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sort parameter"})
+		return
 	}
 
-	// Limit 和 Offset 用于实现分页
-	result := query.Limit(pageSize).Offset(offset).Find(&products)
-	if result.Error != nil {
+	// 不带关键词的上架商品列表前几页读取缓存；搜索结果需要记录搜索日志，管理后台查看其他状态时需要实时数据
+	var resp ProductListResponse
+	load := func() (ProductListResponse, error) {
+		return loadProductList(filter, orderBy, page, pageSize)
+	}
+	if filter.Search == "" && filter.Status == "1" && page <= cache.MaxCachedListPage {
+		resp, err = cache.Fetch(cache.ProductListKey(c.Request.URL.Query().Encode()), cache.ProductListTTL, load)
+	} else {
+		resp, err = load()
+	}
+	if errors.Is(err, errProductFacets) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch product facets"})
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch products"})
 		return
 	}
 
-	c.JSON(http.StatusOK, products)
+	if filter.Search != "" {
+		resp.SearchID = search.LogSearch(models.SearchLog{
+			UserID:      c.GetUint("userID"),
+			Query:       filter.Search,
+			Filters:     search.SearchFilters(c.Request.URL.Query(), "search"),
+			Source:      search.SourceProducts,
+			ResultCount: resp.Total,
+			LatencyMs:   time.Since(started).Milliseconds(),
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// errProductFacets 商品列表分面统计失败
+var errProductFacets = errors.New("failed to fetch product facets")
+
+// loadProductList 从数据库查询一页商品 (预加载 SKU) 及总数和分面统计
+func loadProductList(filter productFilter, orderBy string, page, pageSize int) (ProductListResponse, error) {
+	resp := ProductListResponse{Items: []models.Product{}, Page: page, PageSize: pageSize, Highlights: filter.Highlights, Truncated: filter.Truncated}
+
+	if err := filter.apply(config.DB.Model(&models.Product{}), "").Count(&resp.Total).Error; err != nil {
+		return resp, err
+	}
+	resp.Pages = int((resp.Total + int64(pageSize) - 1) / int64(pageSize))
+
+	// 查询数据库，预加载 SKU 信息；Limit 和 Offset 用于实现分页
+	if err := filter.apply(config.DB.Preload("SKUs"), "").
+		Order(orderBy).Order("products.id DESC").
+		Limit(pageSize).Offset((page - 1) * pageSize).Find(&resp.Items).Error; err != nil {
+		return resp, err
+	}
+
+	facets, err := filter.facets()
+	if err != nil {
+		return resp, errProductFacets
+	}
+	resp.Facets = facets
+	return resp, nil
 }
 
 // GetProductDetail 获取商品详情
+// 草稿商品对前台不可见，管理员携带 Token 通过 preview=true 预览
 // @Summary      Get Product Detail
-// @Description  Get detailed information of a product by ID
+// @Description  Get detailed information of a product by ID, with its SKUs, specs and a spec combination to SKU availability map. is_favorited is set and the view is added to the browsing history when a token is sent. Draft products return 404 unless preview=true is sent with an admin token. Served from the Redis cache when available
 // @Tags         Product
 // @Produce      json
-// @Param        id   path      int  true  "Product ID"
-// @Success      200  {object}  models.Product
-// @Failure      404  {object}  map[string]interface{}
+// @Param        id       path      int   true   "Product ID"
+// @Param        preview  query     bool  false  "Show draft products (requires admin token)"
+// @Security     BearerAuth
+// @Success      200      {object}  ProductDetailResponse
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
 // @Router       /products/{id} [get]
 func GetProductDetail(c *gin.Context) {
-	id := c.Param("id")
-	var product models.Product
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
 
-	// 根据 ID 查询商品，并预加载 SKU 信息
-	if err := config.DB.Preload("SKUs").First(&product, id).Error; err != nil {
+	// 商品、品牌和 SKU 映射与用户无关，读取缓存；收藏状态按用户单独查询
+	cached, err := cache.Fetch(cache.ProductKey(uint(id)), cache.ProductTTL, func() (ProductDetailResponse, error) {
+		return loadProductDetail(uint(id))
+	})
+	if errors.Is(err, cache.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch product"})
+		return
+	}
+	// 只有管理员可以预览草稿
+	preview := c.Query("preview") == "true" && c.GetString("role") == models.RoleAdmin
+	if cached.Status == models.ProductStatusDraft && !preview {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
 		return
 	}
 
-	c.JSON(http.StatusOK, product)
+	resp := cached
+	if userID := c.GetUint("userID"); userID != 0 {
+		var count int64
+		config.DB.Model(&models.Favorite{}).Where("user_id = ? AND product_id = ?", userID, resp.ID).Count(&count)
+		resp.IsFavorited = count > 0
+
+		// 管理后台预览不计入浏览历史
+		if !preview {
+			browsing.RecordView(userID, resp.ID)
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// loadProductDetail 从数据库加载商品详情 (不含用户相关字段)，商品不存在时返回 cache.ErrNotFound
+func loadProductDetail(id uint) (ProductDetailResponse, error) {
+	var product models.Product
+
+	// 根据 ID 查询商品，并预加载 SKU、规格和分类属性信息
+	if err := config.DB.Preload("SKUs").
+		Preload("Specs", spec.Ordered).
+		Preload("Specs.Values", spec.Ordered).
+		Preload("Attributes.Attribute").
+		First(&product, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ProductDetailResponse{}, cache.ErrNotFound
+		}
+		return ProductDetailResponse{}, err
+	}
+	product.Attributes = sortAttributes(product.Attributes)
+
+	resp := ProductDetailResponse{
+		Product: product,
+		SKUMap:  spec.AvailabilityMap(product.SKUs),
+	}
+	if product.BrandID != 0 {
+		var brand models.Brand
+		if err := config.DB.First(&brand, product.BrandID).Error; err == nil {
+			resp.Brand = &brand
+		}
+	}
+	return resp, nil
+}
+
+// ProductDetailResponse 商品详情响应，在商品字段之外返回品牌和规格组合到 SKU 的映射
+type ProductDetailResponse struct {
+	models.Product
+	Brand  *models.Brand            `json:"brand,omitempty"`
+	SKUMap map[string]spec.SKUState `json:"sku_map"` // 规格组合键 (规格值 ID 升序以逗号连接) -> SKU 状态，不存在的组合不可选
+
+	IsFavorited bool `json:"is_favorited"` // 当前用户是否已收藏，未登录时为 false
 }
 
 // ProductSKUInput 商品 SKU 输入
 type ProductSKUInput struct {
+	ID    uint    `json:"id"`   // 更新商品时传已有 SKU 的 ID，0 表示新增
+	Code  string  `json:"code"` // 外部 SKU 编码，批量导入时用于匹配；更新已有 SKU 时为空表示不修改
 	Name  string  `json:"name" binding:"required"`
 	Specs string  `json:"specs" binding:"required"` // JSON string
 	Price float64 `json:"price" binding:"required"`
-	Stock int     `json:"stock" binding:"required"`
+	Stock int     `json:"stock" binding:"min=0"`
+	Image string  `json:"image"` // SKU 图片
 }
 
 // CreateProductInput 创建商品输入
 type CreateProductInput struct {
-	Name        string            `json:"name" binding:"required"`
-	Description string            `json:"description"`
-	Price       float64           `json:"price" binding:"required"`
-	Stock       int               `json:"stock" binding:"required"`
-	CoverImage  string            `json:"cover_image"`
-	CategoryID  uint              `json:"category_id" binding:"required"`
-	SKUs        []ProductSKUInput `json:"skus"` // 商品 SKU 列表
+	Name              string            `json:"name" binding:"required"`
+	Description       string            `json:"description"`
+	Price             float64           `json:"price" binding:"required"`
+	Stock             int               `json:"stock" binding:"required"`
+	CoverImage        string            `json:"cover_image"`
+	Images            []string          `json:"images"` // 商品轮播图，更新时不传则保持不变
+	CategoryID        uint              `json:"category_id" binding:"required"`
+	BrandID           uint              `json:"brand_id"`                               // 品牌 ID，0 表示无品牌
+	Status            *int              `json:"status" binding:"omitempty,oneof=0 1 2"` // 1-上架, 0-下架, 2-草稿；创建时默认上架 (设置了未来的定时上架时间则默认草稿)，更新时不传则保持不变
+	Weight            float64           `json:"weight"`                                 // 单件重量 (kg)
+	FreightTemplateID uint              `json:"freight_template_id"`                    // 运费模板 ID，0 表示使用默认模板
+	SKUs              []ProductSKUInput `json:"skus" binding:"dive"`                    // 商品 SKU 列表，更新时不传则保持不变
+	Attributes        map[uint]string   `json:"attributes"`                             // 分类属性值，key 为属性模板 ID；更新时不传则保持不变
+
+	PublishAt   *time.Time `json:"publish_at"`   // 定时上架时间，更新时不传则保持不变
+	UnpublishAt *time.Time `json:"unpublish_at"` // 定时下架时间，更新时不传则保持不变
 }
 
 // CreateProduct 创建商品
@@ -108,13 +287,35 @@ func CreateProduct(c *gin.Context) {
 	}
 
 	product := models.Product{
-		Name:        input.Name,
-		Description: input.Description,
-		Price:       input.Price,
-		Stock:       input.Stock,
-		CoverImage:  input.CoverImage,
-		CategoryID:  input.CategoryID,
-		Status:      1, // 默认上架
+		Name:              input.Name,
+		Description:       input.Description,
+		Price:             input.Price,
+		Stock:             input.Stock,
+		CoverImage:        input.CoverImage,
+		Images:            input.Images,
+		CategoryID:        input.CategoryID,
+		BrandID:           input.BrandID,
+		Weight:            input.Weight,
+		FreightTemplateID: input.FreightTemplateID,
+		Status:            models.ProductStatusOn, // 默认上架
+		PublishAt:         input.PublishAt,
+		UnpublishAt:       input.UnpublishAt,
+	}
+	if input.Status != nil {
+		product.Status = *input.Status
+	} else if input.PublishAt != nil && input.PublishAt.After(time.Now()) {
+		// 定时上架的商品在上架前保持草稿状态
+		product.Status = models.ProductStatusDraft
+	}
+
+	if !validateBrand(c, product.BrandID) || !validateSchedule(c, product.PublishAt, product.UnpublishAt) {
+		return
+	}
+
+	// 按分类属性模板校验属性值
+	attrs, ok := validateAttributes(c, product.CategoryID, input.Attributes)
+	if !ok {
+		return
 	}
 
 	// 开启事务
@@ -126,6 +327,12 @@ func CreateProduct(c *gin.Context) {
 		return
 	}
 
+	if err := attribute.Save(tx, product.ID, attrs); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save product attributes"})
+		return
+	}
+
 	// 创建 SKU
 	if len(input.SKUs) > 0 {
 		for _, skuInput := range input.SKUs {
@@ -135,7 +342,8 @@ func CreateProduct(c *gin.Context) {
 				Specs:     skuInput.Specs,
 				Price:     skuInput.Price,
 				Stock:     skuInput.Stock,
-				// Image: skuInput.Image, // 暂时没有图片输入
+				Image:     skuInput.Image,
+				Code:      skuInput.Code,
 			}
 			if err := tx.Create(&sku).Error; err != nil {
 				tx.Rollback()
@@ -147,6 +355,10 @@ func CreateProduct(c *gin.Context) {
 
 	tx.Commit()
 
+	// 同步搜索索引和缓存 (清除该 ID 可能缓存的空结果)
+	search.IndexProduct(&product)
+	cache.InvalidateProducts(product.ID)
+
 	// 重新查询以包含 SKUs
 	config.DB.Preload("SKUs").First(&product, product.ID)
 
@@ -154,8 +366,9 @@ func CreateProduct(c *gin.Context) {
 }
 
 // UpdateProduct 更新商品
+// 传入 skus 时按 ID 同步 SKU: 已有 ID 更新、ID 为 0 新增、未出现的已有 SKU 软删除
 // @Summary      Update Product
-// @Description  Update an existing product (Admin only)
+// @Description  Update an existing product and reconcile its SKUs (Admin only)
 // @Tags         Product
 // @Accept       json
 // @Produce      json
@@ -188,13 +401,23 @@ func UpdateProduct(c *gin.Context) {
 	product.Stock = input.Stock
 	product.CoverImage = input.CoverImage
 	product.CategoryID = input.CategoryID
-
-	if err := config.DB.Save(&product).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update product"})
-		return
+	product.BrandID = input.BrandID
+	product.Weight = input.Weight
+	product.FreightTemplateID = input.FreightTemplateID
+	if input.Images != nil {
+		product.Images = input.Images
+	}
+	if input.Status != nil {
+		product.Status = *input.Status
+	}
+	if input.PublishAt != nil {
+		product.PublishAt = input.PublishAt
+	}
+	if input.UnpublishAt != nil {
+		product.UnpublishAt = input.UnpublishAt
 	}
 
-	c.JSON(http.StatusOK, product)
+	saveProduct(c, &product, input.SKUs, input.Attributes)
 }
 
 // GetProductReviews 获取商品评价
@@ -254,5 +477,9 @@ func DeleteProduct(c *gin.Context) {
 		return
 	}
 
+	// 同步搜索索引和缓存
+	search.DeleteProduct(product.ID)
+	cache.InvalidateProducts(product.ID)
+
 	c.JSON(http.StatusOK, gin.H{"message": "Product deleted successfully"})
 }
@@ -0,0 +1,124 @@
+package product
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/cache"
+	"go-flutter-mall/backend/pkg/catalog"
+	"go-flutter-mall/backend/pkg/pricewatch"
+	"go-flutter-mall/backend/pkg/search"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 导出文件的 Content-Type
+var exportContentTypes = map[string]string{
+	catalog.FormatCSV:  "text/csv; charset=utf-8",
+	catalog.FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// ImportProducts 批量导入商品
+// 每行一个 SKU，按 sku_code 新增或更新；任一行校验失败时不写入任何数据并返回逐行错误报告
+// @Summary      Import Products
+// @Description  Upload a CSV/XLSX of products and SKUs. Rows are upserted by sku_code; with dry_run=true only the planned changes are returned (Admin only)
+// @Tags         Product
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file     formData  file    true   "CSV or XLSX file"
+// @Param        dry_run  query     bool    false  "Validate and report without saving"
+// @Success      200      {object}  catalog.Report
+// @Failure      400      {object}  map[string]interface{}
+// @Failure      422      {object}  catalog.Report
+// @Failure      500      {object}  map[string]interface{}
+// @Router       /products/import [post]
+func ImportProducts(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is required"})
+		return
+	}
+	format, err := catalog.FormatOf(fileHeader.Filename)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read file"})
+		return
+	}
+	defer file.Close()
+
+	table, err := catalog.ReadTable(format, file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse file: " + err.Error()})
+		return
+	}
+
+	dryRun := c.Query("dry_run") == "true" || c.Query("dry_run") == "1"
+	report, err := catalog.Import(config.DB, table, dryRun)
+	if err != nil {
+		var headerErr *catalog.HeaderError
+		if errors.As(err, &headerErr) || errors.Is(err, catalog.ErrEmptyTable) || errors.Is(err, catalog.ErrTooManyRows) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import products"})
+		return
+	}
+
+	// 同步搜索索引和缓存，检查降价提醒
+	for _, id := range report.ProductIDs {
+		var product models.Product
+		if err := config.DB.First(&product, id).Error; err == nil {
+			search.IndexProduct(&product)
+			pricewatch.CheckAlerts(config.DB, id)
+		}
+	}
+	if len(report.ProductIDs) > 0 {
+		cache.InvalidateProducts(report.ProductIDs...)
+	}
+
+	if !dryRun && report.ErrorRows > 0 {
+		c.JSON(http.StatusUnprocessableEntity, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// ExportProducts 导出商品
+// @Summary      Export Products
+// @Description  Download all products and SKUs as CSV or XLSX, in the same layout the import accepts (Admin only)
+// @Tags         Product
+// @Produce      octet-stream
+// @Param        format  query     string  false  "csv (default) or xlsx"
+// @Success      200     {file}    file
+// @Failure      400     {object}  map[string]interface{}
+// @Failure      500     {object}  map[string]interface{}
+// @Router       /products/export [get]
+func ExportProducts(c *gin.Context) {
+	format := c.DefaultQuery("format", catalog.FormatCSV)
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format, use csv or xlsx"})
+		return
+	}
+
+	rows, err := catalog.Export(config.DB)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export products"})
+		return
+	}
+
+	filename := "products-" + time.Now().Format("20060102") + "." + format
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Header("Content-Type", contentType)
+	c.Status(http.StatusOK)
+	if err := catalog.WriteTable(format, c.Writer, rows); err != nil {
+		c.Error(err)
+	}
+}
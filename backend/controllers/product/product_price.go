@@ -0,0 +1,222 @@
+package product
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/pricewatch"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PriceHistoryItem 价格历史记录，附带 SKU 名称 (商品基础价格为空)
+type PriceHistoryItem struct {
+	models.PriceHistory
+	SKUName string `json:"sku_name"`
+}
+
+// PriceHistoryResponse 价格历史分页响应
+type PriceHistoryResponse struct {
+	Items    []PriceHistoryItem `json:"items"`
+	Total    int64              `json:"total"`
+	Page     int                `json:"page"`
+	PageSize int                `json:"page_size"`
+}
+
+// PriceAlertInput 订阅降价提醒的输入参数
+type PriceAlertInput struct {
+	ProductID   uint    `json:"product_id" binding:"required"`
+	TargetPrice float64 `json:"target_price" binding:"min=0"` // 提醒价格，为 0 时使用当前最低价
+}
+
+// PriceAlertItem 降价提醒及商品当前信息
+type PriceAlertItem struct {
+	models.PriceAlert
+	Product      *models.Product `json:"product"`
+	CurrentPrice float64         `json:"current_price"` // 商品当前最低价
+}
+
+// GetPriceHistory 获取商品价格历史
+// @Summary      Get Product Price History
+// @Description  List price changes of a product and its SKUs, newest first (Admin only). sku_id=0 filters base price changes only
+// @Tags         Product
+// @Produce      json
+// @Param        id         path      int  true   "Product ID"
+// @Param        sku_id     query     int  false  "Only changes of this SKU (0 for the product base price)"
+// @Param        page       query     int  false  "Page number" default(1)
+// @Param        page_size  query     int  false  "Page size" default(20)
+// @Success      200        {object}  PriceHistoryResponse
+// @Failure      400        {object}  map[string]interface{}
+// @Failure      404        {object}  map[string]interface{}
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /products/{id}/price-history [get]
+func GetPriceHistory(c *gin.Context) {
+	var product models.Product
+	if err := config.DB.Unscoped().First(&product, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	query := config.DB.Model(&models.PriceHistory{}).Where("price_histories.product_id = ?", product.ID)
+	if v := c.Query("sku_id"); v != "" {
+		skuID, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sku_id"})
+			return
+		}
+		query = query.Where("price_histories.sku_id = ?", skuID)
+	}
+
+	resp := PriceHistoryResponse{Items: []PriceHistoryItem{}, Page: page, PageSize: pageSize}
+	if err := query.Count(&resp.Total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch price history"})
+		return
+	}
+	// SKU 可能已被软删除，仍显示其名称
+	if err := query.Select("price_histories.*, COALESCE(product_skus.name, '') AS sku_name").
+		Joins("LEFT JOIN product_skus ON product_skus.id = price_histories.sku_id").
+		Order("price_histories.created_at DESC, price_histories.id DESC").
+		Limit(pageSize).Offset((page - 1) * pageSize).
+		Scan(&resp.Items).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch price history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// SubscribePriceAlert 订阅降价提醒
+// 同一商品重复订阅时更新提醒价格；商品最低价低于提醒价格时收到消息通知和 WebSocket 推送
+// @Summary      Subscribe Price Alert
+// @Description  Get notified when the lowest price of a product drops below target_price (defaults to the current lowest price)
+// @Tags         PriceAlert
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input  body      PriceAlertInput  true  "Price Alert"
+// @Success      200    {object}  models.PriceAlert
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /price-alerts [post]
+func SubscribePriceAlert(c *gin.Context) {
+	userID := c.GetUint("userID")
+
+	var input PriceAlertInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	product, price, err := pricewatch.CurrentPrice(config.DB, input.ProductID)
+	if err != nil || product.Status == models.ProductStatusDraft {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+	target := input.TargetPrice
+	if target == 0 {
+		target = price
+	}
+	if target > price {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "target_price must not exceed the current price"})
+		return
+	}
+
+	var alert models.PriceAlert
+	config.DB.Where("user_id = ? AND product_id = ?", userID, product.ID).FirstOrInit(&alert)
+	alert.UserID = userID
+	alert.ProductID = product.ID
+	alert.TargetPrice = target
+	if err := config.DB.Save(&alert).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save price alert"})
+		return
+	}
+
+	c.JSON(http.StatusOK, alert)
+}
+
+// GetPriceAlerts 获取我的降价提醒
+// @Summary      Get My Price Alerts
+// @Description  List the current user's price alerts with product info and current lowest price
+// @Tags         PriceAlert
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   PriceAlertItem
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /price-alerts [get]
+func GetPriceAlerts(c *gin.Context) {
+	userID := c.GetUint("userID")
+
+	var alerts []models.PriceAlert
+	if err := config.DB.Where("user_id = ?", userID).Order("created_at DESC").Find(&alerts).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch price alerts"})
+		return
+	}
+
+	ids := make([]uint, 0, len(alerts))
+	for _, a := range alerts {
+		ids = append(ids, a.ProductID)
+	}
+	var products []models.Product
+	if len(ids) > 0 {
+		if err := config.DB.Preload("SKUs").Where("id IN ?", ids).Find(&products).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch products"})
+			return
+		}
+	}
+	byID := make(map[uint]*models.Product, len(products))
+	for i := range products {
+		byID[products[i].ID] = &products[i]
+	}
+
+	items := make([]PriceAlertItem, 0, len(alerts))
+	for _, a := range alerts {
+		item := PriceAlertItem{PriceAlert: a}
+		// 商品已删除时 product 为 null
+		if p, ok := byID[a.ProductID]; ok {
+			item.Product = p
+			item.CurrentPrice = pricewatch.LowestPrice(p)
+		}
+		items = append(items, item)
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// DeletePriceAlert 取消降价提醒
+// @Summary      Delete Price Alert
+// @Description  Unsubscribe the price alert of a product
+// @Tags         PriceAlert
+// @Produce      json
+// @Security     BearerAuth
+// @Param        productId  path      int  true  "Product ID"
+// @Success      200        {object}  map[string]interface{}
+// @Failure      404        {object}  map[string]interface{}
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /price-alerts/{productId} [delete]
+func DeletePriceAlert(c *gin.Context) {
+	userID := c.GetUint("userID")
+
+	result := config.DB.Where("user_id = ? AND product_id = ?", userID, c.Param("productId")).Delete(&models.PriceAlert{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete price alert"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Price alert not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Price alert deleted successfully"})
+}
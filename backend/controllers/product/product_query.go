@@ -0,0 +1,303 @@
+package product
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/attribute"
+	categorypkg "go-flutter-mall/backend/pkg/category"
+	searchpkg "go-flutter-mall/backend/pkg/search"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxSearchHits 关键词搜索时从搜索后端取回的最大命中数，其余筛选和分面在命中结果内进行
+const maxSearchHits = 500
+
+// errSearchUnavailable 搜索后端查询失败，与筛选参数错误区分 (返回 503)
+var errSearchUnavailable = errors.New("search backend unavailable")
+
+// 商品排序方式对应的 ORDER BY 表达式
+// 销量统计已支付且未退款/取消的订单，评分统计展示中的评价
+var productSorts = map[string]string{
+	"price_asc":  "products.price ASC",
+	"price_desc": "products.price DESC",
+	"sales":      "(SELECT COALESCE(SUM(oi.quantity), 0) FROM order_items oi JOIN orders o ON o.id = oi.order_id WHERE oi.product_id = products.id AND oi.deleted_at IS NULL AND o.status BETWEEN 1 AND 5) DESC",
+	"rating":     "(SELECT COALESCE(AVG(r.rating), 0) FROM reviews r WHERE r.product_id = products.id AND r.deleted_at IS NULL AND r.status = 1) DESC",
+	"newest":     "products.created_at DESC",
+}
+
+// CategoryFacet 分类分面
+type CategoryFacet struct {
+	CategoryID uint   `json:"category_id"`
+	Name       string `json:"name"`
+	Count      int64  `json:"count"`
+}
+
+// BrandFacet 品牌分面
+type BrandFacet struct {
+	BrandID uint   `json:"brand_id"`
+	Name    string `json:"name"`
+	Count   int64  `json:"count"`
+}
+
+// PriceBucket 价格区间分面
+type PriceBucket struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"` // 0 表示不设上限
+	Count int64   `json:"count"`
+}
+
+// AttributeValueCount 属性值分面
+type AttributeValueCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// AttributeFacet 分类属性分面，只统计可筛选的属性
+type AttributeFacet struct {
+	AttributeID uint                  `json:"attribute_id"`
+	Name        string                `json:"name"`
+	Type        string                `json:"type"`
+	Unit        string                `json:"unit,omitempty"`
+	Values      []AttributeValueCount `json:"values"`
+}
+
+// ProductFacets 商品列表分面统计
+type ProductFacets struct {
+	Categories   []CategoryFacet `json:"categories"`
+	Brands       []BrandFacet    `json:"brands"` // 无品牌的商品不计入
+	PriceBuckets []PriceBucket   `json:"price_buckets"`
+
+	// 按分类筛选时返回该分类 (含祖先分类) 可筛选属性的分面
+	Attributes []AttributeFacet `json:"attributes,omitempty"`
+}
+
+// ProductListResponse 商品列表响应
+type ProductListResponse struct {
+	Items    []models.Product `json:"items"`
+	Total    int64            `json:"total"`
+	Page     int              `json:"page"`
+	PageSize int              `json:"page_size"`
+	Pages    int              `json:"pages"`
+	Facets   ProductFacets    `json:"facets"`
+
+	// 关键词搜索时返回命中商品的高亮片段，key 为商品 ID
+	Highlights map[uint]searchpkg.Highlight `json:"highlights,omitempty"`
+	// 关键词搜索时返回搜索日志 ID，上报点击事件时使用
+	SearchID string `json:"search_id,omitempty"`
+	// 搜索后端命中数超过 maxSearchHits 时为 true，此时总数和分面只统计相关度最高的 maxSearchHits 个命中
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// productFilter 商品列表筛选条件
+type productFilter struct {
+	Search      string
+	CategoryID  uint              // 筛选的分类，0 表示不限
+	CategoryIDs []uint            // 分类及其子孙分类，为空表示不限
+	BrandIDs    []uint            // 品牌，为空表示不限
+	Attributes  map[uint][]string // 属性筛选，key 为属性模板 ID，同一属性的多个值为“或”关系
+	MinPrice    *float64
+	MaxPrice    *float64
+	InStock     bool
+	Status      string // "1" 上架 (默认)、"0" 下架、"2" 草稿、"all" 全部 (只有管理员可以指定)
+
+	// 搜索后端的命中结果，按相关度排序；Ranked 为 false 时使用 ILIKE 匹配
+	Ranked     bool
+	HitIDs     []int64
+	Highlights map[uint]searchpkg.Highlight
+	Truncated  bool // 命中数超过 maxSearchHits，只取回了前 maxSearchHits 个
+}
+
+// parseProductFilter 从查询参数解析筛选条件
+func parseProductFilter(c *gin.Context) (productFilter, error) {
+	f := productFilter{
+		Search:  c.Query("search"),
+		InStock: c.Query("in_stock") == "true" || c.Query("in_stock") == "1",
+		Status:  "1",
+	}
+	// 前台只能查看上架商品，管理员携带 Token 时才能按其他状态筛选
+	if c.GetString("role") == models.RoleAdmin {
+		f.Status = c.DefaultQuery("status", "1")
+	}
+
+	if v := c.Query("category_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return f, err
+		}
+		f.CategoryID = uint(id)
+		if f.CategoryIDs, err = categorypkg.WithDescendants(config.DB, uint(id)); err != nil {
+			return f, err
+		}
+	}
+	if v := c.Query("brand_id"); v != "" {
+		for _, part := range strings.Split(v, ",") {
+			id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 64)
+			if err != nil {
+				return f, err
+			}
+			f.BrandIDs = append(f.BrandIDs, uint(id))
+		}
+	}
+	attrs, err := attribute.ParseFilters(c.QueryMap("attr"))
+	if err != nil {
+		return f, err
+	}
+	f.Attributes = attrs
+	if v := c.Query("min_price"); v != "" {
+		price, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return f, err
+		}
+		f.MinPrice = &price
+	}
+	if v := c.Query("max_price"); v != "" {
+		price, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return f, err
+		}
+		f.MaxPrice = &price
+	}
+
+	// 有搜索后端时使用全文检索获取按相关度排序的命中商品
+	// 搜索索引只收录上架商品，管理员按其他状态筛选时使用 ILIKE 匹配
+	if f.Search != "" && f.Status == "1" && searchpkg.Default != nil {
+		result, err := searchpkg.Search(searchpkg.Query{Text: f.Search, Page: 1, PageSize: maxSearchHits})
+		if err != nil {
+			return f, fmt.Errorf("%w: %v", errSearchUnavailable, err)
+		}
+		f.Ranked = true
+		f.Truncated = result.Total > int64(len(result.Hits))
+		f.HitIDs = make([]int64, 0, len(result.Hits))
+		f.Highlights = make(map[uint]searchpkg.Highlight, len(result.Hits))
+		for _, hit := range result.Hits {
+			f.HitIDs = append(f.HitIDs, int64(hit.ProductID))
+			f.Highlights[hit.ProductID] = hit.Highlight
+		}
+	}
+	return f, nil
+}
+
+// relevanceOrder 按搜索后端返回的命中顺序排序
+// 命中 ID 均为整数，直接拼接到 ORDER BY 表达式中
+func (f productFilter) relevanceOrder() string {
+	if len(f.HitIDs) == 0 {
+		return productSorts["newest"]
+	}
+	ids := make([]string, len(f.HitIDs))
+	for i, id := range f.HitIDs {
+		ids[i] = strconv.FormatInt(id, 10)
+	}
+	return "array_position(ARRAY[" + strings.Join(ids, ",") + "]::bigint[], products.id::bigint)"
+}
+
+// apply 将筛选条件应用到查询
+// skip 用于计算分面时忽略对应维度 ("category"、"brand"、"price" 或 attributeSkip 返回的属性维度)，使分面反映切换该维度后的结果数
+func (f productFilter) apply(query *gorm.DB, skip string) *gorm.DB {
+	if f.Ranked {
+		if len(f.HitIDs) == 0 {
+			query = query.Where("1 = 0")
+		} else {
+			query = query.Where("products.id IN ?", f.HitIDs)
+		}
+	} else if f.Search != "" {
+		query = query.Where("(products.name ILIKE ? OR products.description ILIKE ?)", "%"+f.Search+"%", "%"+f.Search+"%")
+	}
+	if len(f.CategoryIDs) > 0 && skip != "category" {
+		query = query.Where("products.category_id IN ?", f.CategoryIDs)
+	}
+	if len(f.BrandIDs) > 0 && skip != "brand" {
+		query = query.Where("products.brand_id IN ?", f.BrandIDs)
+	}
+	if skip != "price" {
+		if f.MinPrice != nil {
+			query = query.Where("products.price >= ?", *f.MinPrice)
+		}
+		if f.MaxPrice != nil {
+			query = query.Where("products.price <= ?", *f.MaxPrice)
+		}
+	}
+	for id, values := range f.Attributes {
+		if skip == attributeSkip(id) {
+			continue
+		}
+		query = query.Where("products.id IN (SELECT product_id FROM product_attribute_values WHERE attribute_id = ? AND value IN ?)", id, values)
+	}
+	if f.InStock {
+		query = query.Where("products.stock > 0")
+	}
+	if f.Status != "all" {
+		query = query.Where("products.status = ?", f.Status)
+	}
+	return query
+}
+
+// facets 计算分类、品牌、价格区间和分类属性分面
+func (f productFilter) facets() (ProductFacets, error) {
+	facets := ProductFacets{Categories: []CategoryFacet{}, Brands: []BrandFacet{}, PriceBuckets: []PriceBucket{}}
+
+	if err := f.apply(config.DB.Model(&models.Product{}), "category").
+		Select("products.category_id, categories.name, COUNT(*) AS count").
+		Joins("LEFT JOIN categories ON categories.id = products.category_id").
+		Group("products.category_id, categories.name").
+		Order("count DESC").
+		Scan(&facets.Categories).Error; err != nil {
+		return facets, err
+	}
+
+	if err := f.apply(config.DB.Model(&models.Product{}), "brand").
+		Select("products.brand_id, brands.name, COUNT(*) AS count").
+		Joins("JOIN brands ON brands.id = products.brand_id AND brands.deleted_at IS NULL").
+		Group("products.brand_id, brands.name").
+		Order("count DESC").
+		Scan(&facets.Brands).Error; err != nil {
+		return facets, err
+	}
+
+	for _, r := range searchpkg.PriceRanges {
+		bucket := PriceBucket{Min: r.Min, Max: r.Max}
+		query := f.apply(config.DB.Model(&models.Product{}), "price").Where("products.price >= ?", bucket.Min)
+		if bucket.Max > 0 {
+			query = query.Where("products.price < ?", bucket.Max)
+		}
+		if err := query.Count(&bucket.Count).Error; err != nil {
+			return facets, err
+		}
+		facets.PriceBuckets = append(facets.PriceBuckets, bucket)
+	}
+
+	if f.CategoryID == 0 {
+		return facets, nil
+	}
+	templates, err := attribute.ForCategory(config.DB, f.CategoryID)
+	if err != nil {
+		return facets, err
+	}
+	for _, t := range templates {
+		if !t.Filterable {
+			continue
+		}
+		facet := AttributeFacet{AttributeID: t.ID, Name: t.Name, Type: t.Type, Unit: t.Unit, Values: []AttributeValueCount{}}
+		if err := f.apply(config.DB.Model(&models.Product{}), attributeSkip(t.ID)).
+			Select("pav.value, COUNT(*) AS count").
+			Joins("JOIN product_attribute_values pav ON pav.product_id = products.id AND pav.attribute_id = ?", t.ID).
+			Group("pav.value").
+			Order("count DESC, pav.value ASC").
+			Scan(&facet.Values).Error; err != nil {
+			return facets, err
+		}
+		facets.Attributes = append(facets.Attributes, facet)
+	}
+	return facets, nil
+}
+
+// attributeSkip 计算某个属性的分面时传给 apply 的 skip 值
+func attributeSkip(id uint) string {
+	return "attr:" + strconv.FormatUint(uint64(id), 10)
+}
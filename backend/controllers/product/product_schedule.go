@@ -0,0 +1,176 @@
+package product
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/cache"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 日历视图最多查询的天数
+const maxCalendarDays = 92
+
+// 定时计划类型
+const (
+	ScheduleActionPublish   = "publish"
+	ScheduleActionUnpublish = "unpublish"
+)
+
+// ProductScheduleInput 设置定时上下架的输入参数，传 null 表示取消对应计划
+type ProductScheduleInput struct {
+	PublishAt   *time.Time `json:"publish_at"`   // 定时上架时间
+	UnpublishAt *time.Time `json:"unpublish_at"` // 定时下架时间
+}
+
+// ScheduleEvent 日历中的一次定时上下架
+type ScheduleEvent struct {
+	ProductID  uint      `json:"product_id"`
+	Name       string    `json:"name"`
+	CoverImage string    `json:"cover_image"`
+	Status     int       `json:"status"` // 商品当前状态
+	Action     string    `json:"action"` // publish 或 unpublish
+	At         time.Time `json:"at"`
+}
+
+// CalendarDay 日历中的一天
+type CalendarDay struct {
+	Date   string          `json:"date"` // YYYY-MM-DD
+	Events []ScheduleEvent `json:"events"`
+}
+
+// ScheduleCalendarResponse 定时上下架日历响应，只包含有计划的日期
+type ScheduleCalendarResponse struct {
+	From string        `json:"from"`
+	To   string        `json:"to"`
+	Days []CalendarDay `json:"days"`
+}
+
+// validateSchedule 定时下架时间必须晚于定时上架时间，校验失败时写入 400 响应
+func validateSchedule(c *gin.Context, publishAt, unpublishAt *time.Time) bool {
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unpublish_at must be after publish_at"})
+		return false
+	}
+	return true
+}
+
+// UpdateProductSchedule 设置商品定时上下架
+// 调度器每分钟检查一次，到点后修改商品状态并清空对应时间；定时上架对草稿和下架商品均生效
+// @Summary      Update Product Schedule
+// @Description  Set or clear (null) the scheduled publish and unpublish times of a product (Admin only)
+// @Tags         Product
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                   true  "Product ID"
+// @Param        input  body      ProductScheduleInput  true  "Schedule"
+// @Success      200    {object}  models.Product
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /products/{id}/schedule [put]
+func UpdateProductSchedule(c *gin.Context) {
+	var product models.Product
+	if err := config.DB.First(&product, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+
+	var input ProductScheduleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !validateSchedule(c, input.PublishAt, input.UnpublishAt) {
+		return
+	}
+
+	if err := config.DB.Model(&product).Select("publish_at", "unpublish_at").Updates(models.Product{
+		PublishAt:   input.PublishAt,
+		UnpublishAt: input.UnpublishAt,
+	}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update product schedule"})
+		return
+	}
+	product.PublishAt = input.PublishAt
+	product.UnpublishAt = input.UnpublishAt
+	cache.InvalidateProducts(product.ID)
+
+	c.JSON(http.StatusOK, product)
+}
+
+// GetProductCalendar 定时上下架日历
+// @Summary      Get Product Schedule Calendar
+// @Description  List upcoming scheduled publish/unpublish changes grouped by day (Admin only). Defaults to the next 30 days, at most 92 days
+// @Tags         Product
+// @Produce      json
+// @Param        from  query     string  false  "Start date (YYYY-MM-DD), default today"
+// @Param        to    query     string  false  "End date (YYYY-MM-DD, inclusive), default from + 30 days"
+// @Success      200   {object}  ScheduleCalendarResponse
+// @Failure      400   {object}  map[string]interface{}
+// @Failure      500   {object}  map[string]interface{}
+// @Router       /products/admin/calendar [get]
+func GetProductCalendar(c *gin.Context) {
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if v := c.Query("from"); v != "" {
+		t, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date"})
+			return
+		}
+		from = t
+	}
+	to := from.AddDate(0, 0, 30)
+	if v := c.Query("to"); v != "" {
+		t, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date"})
+			return
+		}
+		to = t
+	}
+	if to.Before(from) || to.Sub(from) > maxCalendarDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date range"})
+		return
+	}
+	end := to.AddDate(0, 0, 1)
+
+	var products []models.Product
+	if err := config.DB.Where("(publish_at >= ? AND publish_at < ?) OR (unpublish_at >= ? AND unpublish_at < ?)", from, end, from, end).
+		Find(&products).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch scheduled products"})
+		return
+	}
+
+	var events []ScheduleEvent
+	inRange := func(t *time.Time) bool { return t != nil && !t.Before(from) && t.Before(end) }
+	for _, p := range products {
+		event := ScheduleEvent{ProductID: p.ID, Name: p.Name, CoverImage: p.CoverImage, Status: p.Status}
+		if inRange(p.PublishAt) {
+			event.Action, event.At = ScheduleActionPublish, *p.PublishAt
+			events = append(events, event)
+		}
+		if inRange(p.UnpublishAt) {
+			event.Action, event.At = ScheduleActionUnpublish, *p.UnpublishAt
+			events = append(events, event)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].At.Before(events[j].At) })
+
+	resp := ScheduleCalendarResponse{From: from.Format("2006-01-02"), To: to.Format("2006-01-02"), Days: []CalendarDay{}}
+	for _, e := range events {
+		date := e.At.In(time.Local).Format("2006-01-02")
+		if n := len(resp.Days); n == 0 || resp.Days[n-1].Date != date {
+			resp.Days = append(resp.Days, CalendarDay{Date: date})
+		}
+		last := &resp.Days[len(resp.Days)-1]
+		last.Events = append(last.Events, e)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
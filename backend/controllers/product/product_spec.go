@@ -0,0 +1,314 @@
+package product
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/cache"
+	"go-flutter-mall/backend/pkg/spec"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SpecValueInput 规格值输入
+type SpecValueInput struct {
+	Value string `json:"value" binding:"required"`
+	Image string `json:"image"`
+}
+
+// SpecInput 规格属性输入，规格和规格值的顺序即展示顺序
+type SpecInput struct {
+	Name   string           `json:"name" binding:"required"`
+	Values []SpecValueInput `json:"values" binding:"required,min=1,dive"`
+}
+
+// ProductSpecsInput 设置商品规格的输入参数
+type ProductSpecsInput struct {
+	Specs []SpecInput `json:"specs" binding:"dive"`
+}
+
+// GenerateSKUsInput 生成 SKU 矩阵的输入参数
+type GenerateSKUsInput struct {
+	Price       *float64 `json:"price" binding:"omitempty,gt=0"` // 新 SKU 的价格，默认为商品价格
+	Stock       int      `json:"stock" binding:"min=0"`          // 新 SKU 的库存
+	RemoveStale bool     `json:"remove_stale"`                   // 是否删除不属于任何规格组合的 SKU
+}
+
+// GenerateSKUsResponse 生成 SKU 矩阵的结果
+type GenerateSKUsResponse struct {
+	Created int                 `json:"created"` // 新增的 SKU 数
+	Removed int                 `json:"removed"` // 删除的 SKU 数
+	SKUs    []models.ProductSKU `json:"skus"`    // 生成后的全部 SKU
+}
+
+// validateSpecs 规格名称在商品内唯一，规格值在规格内唯一
+func validateSpecs(inputs []SpecInput) error {
+	names := make(map[string]bool)
+	for i := range inputs {
+		inputs[i].Name = strings.TrimSpace(inputs[i].Name)
+		if inputs[i].Name == "" || names[inputs[i].Name] {
+			return errors.New("Spec names must be unique and non-empty")
+		}
+		names[inputs[i].Name] = true
+
+		values := make(map[string]bool)
+		for j := range inputs[i].Values {
+			v := strings.TrimSpace(inputs[i].Values[j].Value)
+			if v == "" || values[v] {
+				return errors.New("Spec values must be unique and non-empty within a spec")
+			}
+			values[v] = true
+			inputs[i].Values[j].Value = v
+		}
+	}
+	return nil
+}
+
+// saveSpecs 按名称同步规格属性和规格值: 同名的保留 ID 并更新顺序和图片，新的创建，未出现的软删除
+// 保留 ID 使已生成的 SKU 规格组合键继续有效
+func saveSpecs(tx *gorm.DB, productID uint, inputs []SpecInput) error {
+	existing, err := spec.Load(tx, productID)
+	if err != nil {
+		return err
+	}
+	specsByName := make(map[string]models.ProductSpec, len(existing))
+	for _, s := range existing {
+		specsByName[s.Name] = s
+	}
+
+	keptSpecs := make(map[uint]bool)
+	for i, input := range inputs {
+		s, ok := specsByName[input.Name]
+		if !ok {
+			s = models.ProductSpec{ProductID: productID, Name: input.Name}
+		}
+		s.SortOrder = i
+		current := s.Values
+		s.Values = nil
+		if err := tx.Save(&s).Error; err != nil {
+			return err
+		}
+		keptSpecs[s.ID] = true
+
+		valuesByName := make(map[string]models.ProductSpecValue, len(current))
+		for _, v := range current {
+			valuesByName[v.Value] = v
+		}
+		keptValues := make(map[uint]bool)
+		for j, vi := range input.Values {
+			v, ok := valuesByName[vi.Value]
+			if !ok {
+				v = models.ProductSpecValue{SpecID: s.ID, Value: vi.Value}
+			}
+			v.Image = vi.Image
+			v.SortOrder = j
+			if err := tx.Save(&v).Error; err != nil {
+				return err
+			}
+			keptValues[v.ID] = true
+		}
+		for _, v := range current {
+			if !keptValues[v.ID] {
+				if err := tx.Delete(&v).Error; err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, s := range existing {
+		if keptSpecs[s.ID] {
+			continue
+		}
+		if err := tx.Where("spec_id = ?", s.ID).Delete(&models.ProductSpecValue{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&s).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetProductSpecs 获取商品规格
+// @Summary      Get Product Specs
+// @Description  List spec attributes and their values of a product, in display order
+// @Tags         Product
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Success      200  {array}   models.ProductSpec
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /products/{id}/specs [get]
+func GetProductSpecs(c *gin.Context) {
+	var product models.Product
+	if err := config.DB.First(&product, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+
+	specs, err := spec.Load(config.DB, product.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch specs"})
+		return
+	}
+
+	c.JSON(http.StatusOK, specs)
+}
+
+// SaveProductSpecs 设置商品规格
+// @Summary      Save Product Specs
+// @Description  Replace the spec attributes and values of a product. Specs and values are matched by name so existing SKU combinations stay valid (Admin only)
+// @Tags         Product
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                true  "Product ID"
+// @Param        input  body      ProductSpecsInput  true  "Specs"
+// @Success      200    {array}   models.ProductSpec
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /products/{id}/specs [put]
+func SaveProductSpecs(c *gin.Context) {
+	var product models.Product
+	if err := config.DB.First(&product, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+
+	var input ProductSpecsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validateSpecs(input.Specs); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tx := config.DB.Begin()
+	if err := saveSpecs(tx, product.ID, input.Specs); err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save specs"})
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save specs"})
+		return
+	}
+	cache.InvalidateProducts(product.ID)
+
+	specs, _ := spec.Load(config.DB, product.ID)
+	c.JSON(http.StatusOK, specs)
+}
+
+// GenerateProductSKUs 按规格生成 SKU 矩阵
+// 已存在的组合保留价格和库存，只刷新名称和规格 JSON；缺失的组合按默认价格和库存创建
+// @Summary      Generate SKU Matrix
+// @Description  Create one SKU per spec combination; optionally remove SKUs that match no combination (Admin only)
+// @Tags         Product
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int                true  "Product ID"
+// @Param        input  body      GenerateSKUsInput  true  "Defaults for new SKUs"
+// @Success      200    {object}  GenerateSKUsResponse
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /products/{id}/skus/generate [post]
+func GenerateProductSKUs(c *gin.Context) {
+	var product models.Product
+	if err := config.DB.First(&product, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+
+	var input GenerateSKUsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	price := product.Price
+	if input.Price != nil {
+		price = *input.Price
+	}
+
+	specs, err := spec.Load(config.DB, product.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch specs"})
+		return
+	}
+	combos, err := spec.Matrix(specs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tx := config.DB.Begin()
+
+	var skus []models.ProductSKU
+	if err := tx.Where("product_id = ?", product.ID).Find(&skus).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch SKUs"})
+		return
+	}
+	byKey := make(map[string]models.ProductSKU, len(skus))
+	for _, sku := range skus {
+		if sku.SpecKey != "" {
+			byKey[sku.SpecKey] = sku
+		}
+	}
+
+	resp := GenerateSKUsResponse{}
+	inMatrix := make(map[string]bool, len(combos))
+	for _, combo := range combos {
+		inMatrix[combo.Key] = true
+		sku, ok := byKey[combo.Key]
+		if !ok {
+			sku = models.ProductSKU{ProductID: product.ID, SpecKey: combo.Key, Price: price, Stock: input.Stock}
+			resp.Created++
+		}
+		sku.Name = combo.Name
+		sku.Specs = combo.Specs
+		if sku.Image == "" {
+			for _, v := range combo.Values {
+				if v.Image != "" {
+					sku.Image = v.Image
+					break
+				}
+			}
+		}
+		if err := tx.Save(&sku).Error; err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save SKU"})
+			return
+		}
+	}
+
+	if input.RemoveStale {
+		for _, sku := range skus {
+			if inMatrix[sku.SpecKey] {
+				continue
+			}
+			if err := tx.Delete(&sku).Error; err != nil {
+				tx.Rollback()
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove SKU"})
+				return
+			}
+			resp.Removed++
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate SKUs"})
+		return
+	}
+	cache.InvalidateProducts(product.ID)
+
+	config.DB.Where("product_id = ?", product.ID).Order("id asc").Find(&resp.SKUs)
+	c.JSON(http.StatusOK, resp)
+}
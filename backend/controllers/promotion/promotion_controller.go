@@ -0,0 +1,212 @@
+package promotion
+
+import (
+	"net/http"
+	"time"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	promotionpkg "go-flutter-mall/backend/pkg/promotion"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PromotionInput 创建/更新促销活动的输入参数
+type PromotionInput struct {
+	Name      string    `json:"name" binding:"required"`
+	Type      string    `json:"type" binding:"required,oneof=buy_x_get_y tiered category_discount bundle"`
+	ScopeType string    `json:"scope_type" binding:"omitempty,oneof=all category product"`
+	ScopeIDs  []int64   `json:"scope_ids"`
+	Rules     string    `json:"rules" binding:"required"` // JSON string，结构见 promotion.Rule
+	Priority  int       `json:"priority"`
+	StartAt   time.Time `json:"start_at" binding:"required"`
+	EndAt     time.Time `json:"end_at" binding:"required"`
+	Status    *int      `json:"status"`
+}
+
+// uniqueIDs 按原顺序去重
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// bindPromotion 绑定并校验输入，写入促销活动
+func bindPromotion(c *gin.Context, p *models.Promotion) bool {
+	var input PromotionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+
+	if !input.EndAt.After(input.StartAt) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end_at must be after start_at"})
+		return false
+	}
+	if _, err := promotionpkg.ParseRule(input.Type, input.Rules); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	if input.ScopeType == "" {
+		input.ScopeType = models.ScopeAll
+	}
+	// 去除重复的 ID，否则 bundle 套装会把同一商品计为多件
+	input.ScopeIDs = uniqueIDs(input.ScopeIDs)
+	if input.ScopeType != models.ScopeAll && len(input.ScopeIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "scope_ids is required for scoped promotions"})
+		return false
+	}
+	if input.Type == models.PromotionTypeBundle && input.ScopeType != models.ScopeProduct {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bundle promotions must use product scope"})
+		return false
+	}
+
+	p.Name = input.Name
+	p.Type = input.Type
+	p.ScopeType = input.ScopeType
+	p.ScopeIDs = input.ScopeIDs
+	p.Rules = input.Rules
+	p.Priority = input.Priority
+	p.StartAt = input.StartAt
+	p.EndAt = input.EndAt
+	if input.Status != nil {
+		p.Status = *input.Status
+	}
+	return true
+}
+
+// GetPromotions 获取进行中的促销活动
+// @Summary      Get Active Promotions
+// @Description  Get a list of promotions that are currently active
+// @Tags         Promotion
+// @Produce      json
+// @Success      200  {array}   models.Promotion
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /promotions [get]
+func GetPromotions(c *gin.Context) {
+	promotions, err := promotionpkg.LoadActive(config.DB)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch promotions"})
+		return
+	}
+	if promotions == nil {
+		promotions = []models.Promotion{}
+	}
+
+	c.JSON(http.StatusOK, promotions)
+}
+
+// GetAllPromotions 管理员获取所有促销活动
+// @Summary      Get All Promotions
+// @Description  Get all promotions including inactive ones (Admin only)
+// @Tags         Promotion
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   models.Promotion
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /promotions/admin/all [get]
+func GetAllPromotions(c *gin.Context) {
+	promotions := []models.Promotion{}
+
+	if err := config.DB.Order("priority desc, created_at desc").Find(&promotions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch promotions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, promotions)
+}
+
+// CreatePromotion 管理员创建促销活动
+// @Summary      Create Promotion
+// @Description  Create a new promotion (Admin only)
+// @Tags         Promotion
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input  body      PromotionInput  true  "Promotion Info"
+// @Success      201    {object}  models.Promotion
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /promotions/admin [post]
+func CreatePromotion(c *gin.Context) {
+	p := models.Promotion{Status: 1}
+	if !bindPromotion(c, &p) {
+		return
+	}
+
+	if err := config.DB.Create(&p).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create promotion"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, p)
+}
+
+// UpdatePromotion 管理员更新促销活动
+// @Summary      Update Promotion
+// @Description  Update an existing promotion (Admin only)
+// @Tags         Promotion
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      int             true  "Promotion ID"
+// @Param        input  body      PromotionInput  true  "Promotion Info"
+// @Success      200    {object}  models.Promotion
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /promotions/admin/{id} [put]
+func UpdatePromotion(c *gin.Context) {
+	id := c.Param("id")
+	var p models.Promotion
+
+	if err := config.DB.First(&p, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Promotion not found"})
+		return
+	}
+
+	if !bindPromotion(c, &p) {
+		return
+	}
+
+	if err := config.DB.Save(&p).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update promotion"})
+		return
+	}
+
+	c.JSON(http.StatusOK, p)
+}
+
+// DeletePromotion 管理员删除促销活动
+// @Summary      Delete Promotion
+// @Description  Delete a promotion (Admin only)
+// @Tags         Promotion
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Promotion ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /promotions/admin/{id} [delete]
+func DeletePromotion(c *gin.Context) {
+	id := c.Param("id")
+	var p models.Promotion
+
+	if err := config.DB.First(&p, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Promotion not found"})
+		return
+	}
+
+	if err := config.DB.Delete(&p).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete promotion"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Promotion deleted successfully"})
+}
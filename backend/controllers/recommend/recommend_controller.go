@@ -0,0 +1,119 @@
+package recommend
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	recommendpkg "go-flutter-mall/backend/pkg/recommend"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecommendedProduct 推荐商品及推荐理由
+type RecommendedProduct struct {
+	models.Product
+	Reason string  `json:"reason"` // 推荐理由: also_bought, viewed, search, popular
+	Score  float64 `json:"score"`  // 推荐得分，热门补充的商品为 0
+}
+
+// RecommendationResponse 推荐结果
+type RecommendationResponse struct {
+	Items        []RecommendedProduct `json:"items"`
+	Personalized bool                 `json:"personalized"` // 是否基于用户的购买、浏览或搜索记录；为 false 时全部为热门商品
+}
+
+// parseLimit 解析推荐数量，默认 10，最多 50
+func parseLimit(c *gin.Context) int {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+	return limit
+}
+
+// loadProducts 按推荐顺序加载商品及 SKU
+func loadProducts(items []recommendpkg.Item) ([]RecommendedProduct, error) {
+	result := []RecommendedProduct{}
+	if len(items) == 0 {
+		return result, nil
+	}
+	ids := make([]uint, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ProductID)
+	}
+	var products []models.Product
+	if err := config.DB.Preload("SKUs").Where("id IN ?", ids).Find(&products).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.Product, len(products))
+	for _, p := range products {
+		byID[p.ID] = p
+	}
+	for _, item := range items {
+		if p, ok := byID[item.ProductID]; ok {
+			result = append(result, RecommendedProduct{Product: p, Reason: item.Reason, Score: item.Score})
+		}
+	}
+	return result, nil
+}
+
+// GetRelatedProducts 看了又看 / 买了还买
+// 优先返回经常与该商品一起购买的商品，不足时用同分类和全站热门商品补充
+// @Summary      Get Related Products
+// @Description  Products frequently bought together with this product (co-purchase similarity, refreshed periodically), filled with popular products of the same category and then the whole store
+// @Tags         Recommendation
+// @Produce      json
+// @Param        id     path      int  true   "Product ID"
+// @Param        limit  query     int  false  "Number of products" default(10)
+// @Success      200    {array}   RecommendedProduct
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /products/{id}/related [get]
+func GetRelatedProducts(c *gin.Context) {
+	var product models.Product
+	if err := config.DB.First(&product, c.Param("id")).Error; err != nil || product.Status == models.ProductStatusDraft {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+
+	items, err := recommendpkg.Related(config.DB, &product, parseLimit(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch related products"})
+		return
+	}
+	products, err := loadProducts(items)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch related products"})
+		return
+	}
+
+	c.JSON(http.StatusOK, products)
+}
+
+// GetRecommendations 猜你喜欢
+// 登录用户根据购买、浏览和搜索记录个性化推荐，未登录或没有记录时返回热门商品
+// @Summary      Get Recommendations
+// @Description  Personalized product feed blending co-purchase similarity of bought and viewed products, recently viewed products and recent searches. Guests and cold-start users get popular products
+// @Tags         Recommendation
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit  query     int  false  "Number of products" default(10)
+// @Success      200    {object}  RecommendationResponse
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /recommendations [get]
+func GetRecommendations(c *gin.Context) {
+	items, personalized, err := recommendpkg.ForUser(config.DB, c.GetUint("userID"), parseLimit(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch recommendations"})
+		return
+	}
+	products, err := loadProducts(items)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch recommendations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, RecommendationResponse{Items: products, Personalized: personalized})
+}
@@ -0,0 +1,179 @@
+package search
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	searchpkg "go-flutter-mall/backend/pkg/search"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchClickInput 搜索结果点击事件的输入参数
+type SearchClickInput struct {
+	SearchID  string `json:"search_id" binding:"required"` // 搜索接口返回的 search_id
+	ProductID uint   `json:"product_id" binding:"required"`
+	Position  int    `json:"position"` // 商品在结果中的位置，从 1 开始
+}
+
+// parseReportRange 解析统计时间范围，from/to 格式为 2006-01-02 (包含 to 当天)，默认最近 7 天
+func parseReportRange(c *gin.Context) (from, to time.Time, limit int, err error) {
+	now := time.Now()
+	to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local).Add(24 * time.Hour)
+	from = to.AddDate(0, 0, -7)
+
+	if v := c.Query("to"); v != "" {
+		day, e := time.ParseInLocation("2006-01-02", v, time.Local)
+		if e != nil {
+			return from, to, 0, e
+		}
+		to = day.Add(24 * time.Hour)
+	}
+	if v := c.Query("from"); v != "" {
+		day, e := time.ParseInLocation("2006-01-02", v, time.Local)
+		if e != nil {
+			return from, to, 0, e
+		}
+		from = day
+	}
+	if !from.Before(to) {
+		return from, to, 0, errors.New("from must not be after to")
+	}
+
+	limit, _ = strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	return from, to, limit, nil
+}
+
+// RecordSearchClick 记录搜索结果点击
+// @Summary      Record Search Click
+// @Description  Record that a product was opened from a search result, linked by search_id
+// @Tags         Search
+// @Accept       json
+// @Produce      json
+// @Param        input  body      SearchClickInput  true  "Click Event"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Failure      503    {object}  map[string]interface{}
+// @Router       /search/click [post]
+func RecordSearchClick(c *gin.Context) {
+	var input SearchClickInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := searchpkg.RecordClick(input.SearchID, c.GetUint("userID"), input.ProductID, input.Position)
+	switch {
+	case errors.Is(err, searchpkg.ErrAnalyticsDisabled):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Search analytics is not available"})
+		return
+	case errors.Is(err, searchpkg.ErrSearchNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Search not found"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record click"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Click recorded"})
+}
+
+// respondReport 输出统计结果，统一处理 MongoDB 不可用的情况
+func respondReport(c *gin.Context, stats []searchpkg.QueryStat, err error) {
+	if errors.Is(err, searchpkg.ErrAnalyticsDisabled) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Search analytics is not available"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate search report"})
+		return
+	}
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetTopQueries 管理员查看热门查询
+// @Summary      Top Search Queries
+// @Description  Most frequent search queries in a time range, with result counts and click-through rate (Admin only)
+// @Tags         Search
+// @Produce      json
+// @Security     BearerAuth
+// @Param        from   query     string  false  "Start date (2006-01-02), default 7 days ago"
+// @Param        to     query     string  false  "End date inclusive (2006-01-02), default today"
+// @Param        limit  query     int     false  "Max queries" default(20)
+// @Success      200    {array}   searchpkg.QueryStat
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Failure      503    {object}  map[string]interface{}
+// @Router       /search/admin/analytics/top [get]
+func GetTopQueries(c *gin.Context) {
+	from, to, limit, err := parseReportRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date range"})
+		return
+	}
+
+	stats, err := searchpkg.TopQueries(from, to, limit)
+	respondReport(c, stats, err)
+}
+
+// GetZeroResultQueries 管理员查看无结果查询
+// @Summary      Zero-Result Search Queries
+// @Description  Queries that returned no products in a time range, ordered by how often they failed (Admin only)
+// @Tags         Search
+// @Produce      json
+// @Security     BearerAuth
+// @Param        from   query     string  false  "Start date (2006-01-02), default 7 days ago"
+// @Param        to     query     string  false  "End date inclusive (2006-01-02), default today"
+// @Param        limit  query     int     false  "Max queries" default(20)
+// @Success      200    {array}   searchpkg.QueryStat
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Failure      503    {object}  map[string]interface{}
+// @Router       /search/admin/analytics/zero-results [get]
+func GetZeroResultQueries(c *gin.Context) {
+	from, to, limit, err := parseReportRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date range"})
+		return
+	}
+
+	stats, err := searchpkg.ZeroResultQueries(from, to, limit)
+	respondReport(c, stats, err)
+}
+
+// GetSearchCTR 管理员查看查询点击率
+// @Summary      Search Click-Through Rate
+// @Description  Click-through rate per query in a time range, lowest first (Admin only)
+// @Tags         Search
+// @Produce      json
+// @Security     BearerAuth
+// @Param        from          query     string  false  "Start date (2006-01-02), default 7 days ago"
+// @Param        to            query     string  false  "End date inclusive (2006-01-02), default today"
+// @Param        min_searches  query     int     false  "Only queries searched at least this many times" default(5)
+// @Param        limit         query     int     false  "Max queries" default(20)
+// @Success      200           {array}   searchpkg.QueryStat
+// @Failure      400           {object}  map[string]interface{}
+// @Failure      500           {object}  map[string]interface{}
+// @Failure      503           {object}  map[string]interface{}
+// @Router       /search/admin/analytics/ctr [get]
+func GetSearchCTR(c *gin.Context) {
+	from, to, limit, err := parseReportRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date range"})
+		return
+	}
+	minSearches, _ := strconv.ParseInt(c.DefaultQuery("min_searches", "5"), 10, 64)
+	if minSearches < 1 {
+		minSearches = 1
+	}
+
+	stats, err := searchpkg.ClickThroughRates(from, to, minSearches, limit)
+	respondReport(c, stats, err)
+}
@@ -0,0 +1,246 @@
+package search
+
+import (
+	"net/http"
+	"strings"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	searchpkg "go-flutter-mall/backend/pkg/search"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SynonymInput 创建/更新同义词组的输入参数
+type SynonymInput struct {
+	Terms []string `json:"terms" binding:"required,min=2"` // 组内互为同义的词，如 ["iphone", "苹果手机"]
+}
+
+// StopwordInput 添加停用词的输入参数
+type StopwordInput struct {
+	Word string `json:"word" binding:"required"`
+}
+
+// normalizeTerms 同义词统一小写、去除空白和重复
+func normalizeTerms(terms []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, t := range terms {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		result = append(result, t)
+	}
+	return result
+}
+
+// GetSynonyms 管理员获取同义词组列表
+// @Summary      Get Synonyms
+// @Description  List search synonym groups (Admin only)
+// @Tags         Search
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   models.SearchSynonym
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /search/admin/synonyms [get]
+func GetSynonyms(c *gin.Context) {
+	synonyms := []models.SearchSynonym{}
+	if err := config.DB.Order("id ASC").Find(&synonyms).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch synonyms"})
+		return
+	}
+
+	c.JSON(http.StatusOK, synonyms)
+}
+
+// CreateSynonym 管理员创建同义词组
+// @Summary      Create Synonym
+// @Description  Create a synonym group; a query containing any term also searches the others (Admin only)
+// @Tags         Search
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input  body      SynonymInput  true  "Synonym Group"
+// @Success      201    {object}  models.SearchSynonym
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /search/admin/synonyms [post]
+func CreateSynonym(c *gin.Context) {
+	var input SynonymInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	terms := normalizeTerms(input.Terms)
+	if len(terms) < 2 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A synonym group needs at least two distinct terms"})
+		return
+	}
+
+	synonym := models.SearchSynonym{Terms: terms}
+	if err := config.DB.Create(&synonym).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create synonym"})
+		return
+	}
+	searchpkg.ReloadDictionary()
+
+	c.JSON(http.StatusCreated, synonym)
+}
+
+// UpdateSynonym 管理员更新同义词组
+// @Summary      Update Synonym
+// @Description  Replace the terms of a synonym group (Admin only)
+// @Tags         Search
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      int           true  "Synonym ID"
+// @Param        input  body      SynonymInput  true  "Synonym Group"
+// @Success      200    {object}  models.SearchSynonym
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /search/admin/synonyms/{id} [put]
+func UpdateSynonym(c *gin.Context) {
+	var synonym models.SearchSynonym
+	if err := config.DB.First(&synonym, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Synonym not found"})
+		return
+	}
+
+	var input SynonymInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	terms := normalizeTerms(input.Terms)
+	if len(terms) < 2 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A synonym group needs at least two distinct terms"})
+		return
+	}
+
+	synonym.Terms = terms
+	if err := config.DB.Save(&synonym).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update synonym"})
+		return
+	}
+	searchpkg.ReloadDictionary()
+
+	c.JSON(http.StatusOK, synonym)
+}
+
+// DeleteSynonym 管理员删除同义词组
+// @Summary      Delete Synonym
+// @Description  Delete a synonym group (Admin only)
+// @Tags         Search
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Synonym ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /search/admin/synonyms/{id} [delete]
+func DeleteSynonym(c *gin.Context) {
+	var synonym models.SearchSynonym
+	if err := config.DB.First(&synonym, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Synonym not found"})
+		return
+	}
+
+	if err := config.DB.Delete(&synonym).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete synonym"})
+		return
+	}
+	searchpkg.ReloadDictionary()
+
+	c.JSON(http.StatusOK, gin.H{"message": "Synonym deleted successfully"})
+}
+
+// GetStopwords 管理员获取停用词列表
+// @Summary      Get Stopwords
+// @Description  List search stopwords (Admin only)
+// @Tags         Search
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   models.SearchStopword
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /search/admin/stopwords [get]
+func GetStopwords(c *gin.Context) {
+	stopwords := []models.SearchStopword{}
+	if err := config.DB.Order("word ASC").Find(&stopwords).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch stopwords"})
+		return
+	}
+
+	c.JSON(http.StatusOK, stopwords)
+}
+
+// CreateStopword 管理员添加停用词
+// @Summary      Create Stopword
+// @Description  Add a word that is removed from queries before searching (Admin only)
+// @Tags         Search
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input  body      StopwordInput  true  "Stopword"
+// @Success      201    {object}  models.SearchStopword
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /search/admin/stopwords [post]
+func CreateStopword(c *gin.Context) {
+	var input StopwordInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	word := strings.ToLower(strings.TrimSpace(input.Word))
+	if word == "" || strings.ContainsAny(word, " \t") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Stopword must be a single non-empty word"})
+		return
+	}
+
+	var count int64
+	config.DB.Model(&models.SearchStopword{}).Where("word = ?", word).Count(&count)
+	if count > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Stopword already exists"})
+		return
+	}
+
+	stopword := models.SearchStopword{Word: word}
+	if err := config.DB.Create(&stopword).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create stopword"})
+		return
+	}
+	searchpkg.ReloadDictionary()
+
+	c.JSON(http.StatusCreated, stopword)
+}
+
+// DeleteStopword 管理员删除停用词
+// @Summary      Delete Stopword
+// @Description  Remove a stopword (Admin only)
+// @Tags         Search
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Stopword ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /search/admin/stopwords/{id} [delete]
+func DeleteStopword(c *gin.Context) {
+	var stopword models.SearchStopword
+	if err := config.DB.First(&stopword, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Stopword not found"})
+		return
+	}
+
+	if err := config.DB.Delete(&stopword).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete stopword"})
+		return
+	}
+	searchpkg.ReloadDictionary()
+
+	c.JSON(http.StatusOK, gin.H{"message": "Stopword deleted successfully"})
+}
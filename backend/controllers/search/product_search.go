@@ -0,0 +1,146 @@
+package search
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	categorypkg "go-flutter-mall/backend/pkg/category"
+	searchpkg "go-flutter-mall/backend/pkg/search"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProductSearchResponse 商品搜索响应
+type ProductSearchResponse struct {
+	Items    []models.Product `json:"items"` // 按相关度排序
+	Hits     []searchpkg.Hit  `json:"hits"`  // 与 Items 一一对应的得分和高亮
+	Total    int64            `json:"total"`
+	Page     int              `json:"page"`
+	PageSize int              `json:"page_size"`
+	Pages    int              `json:"pages"`
+	Facets   searchpkg.Facets `json:"facets"`
+	SearchID string           `json:"search_id,omitempty"` // 搜索日志 ID，上报点击事件时使用
+}
+
+// SearchProducts 商品全文搜索
+// 直接使用搜索后端分页，名称命中权重高于描述；每次搜索记录到搜索日志
+// @Summary      Search Products
+// @Description  Full-text search on on-shelf products with relevance ranking, highlights and facets
+// @Tags         Search
+// @Produce      json
+// @Param        q            query     string  true   "Keyword"
+// @Param        category_id  query     int     false  "Category ID (includes descendants)"
+// @Param        page         query     int     false  "Page number" default(1)
+// @Param        page_size    query     int     false  "Page size" default(10)
+// @Success      200          {object}  ProductSearchResponse
+// @Failure      400          {object}  map[string]interface{}
+// @Failure      500          {object}  map[string]interface{}
+// @Failure      503          {object}  map[string]interface{}
+// @Router       /search/products [get]
+func SearchProducts(c *gin.Context) {
+	started := time.Now()
+	text := c.Query("q")
+	if text == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Keyword is required"})
+		return
+	}
+	if searchpkg.Default == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Search engine is not available"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	query := searchpkg.Query{Text: text, Page: page, PageSize: pageSize}
+	if v := c.Query("category_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category_id"})
+			return
+		}
+		if query.CategoryIDs, err = categorypkg.WithDescendants(config.DB, uint(id)); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch categories"})
+			return
+		}
+	}
+
+	result, err := searchpkg.Search(query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search products"})
+		return
+	}
+
+	// 按命中顺序加载商品，索引与数据库不一致时跳过已不存在的商品
+	ids := make([]uint, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		ids = append(ids, hit.ProductID)
+	}
+	var products []models.Product
+	if len(ids) > 0 {
+		if err := config.DB.Preload("SKUs").Where("id IN ?", ids).Find(&products).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch products"})
+			return
+		}
+	}
+	byID := make(map[uint]models.Product, len(products))
+	for _, p := range products {
+		byID[p.ID] = p
+	}
+
+	resp := ProductSearchResponse{
+		Items:    []models.Product{},
+		Hits:     []searchpkg.Hit{},
+		Total:    result.Total,
+		Page:     page,
+		PageSize: pageSize,
+		Pages:    int((result.Total + int64(pageSize) - 1) / int64(pageSize)),
+		Facets:   result.Facets,
+		SearchID: searchpkg.LogSearch(models.SearchLog{
+			UserID:      c.GetUint("userID"),
+			Query:       text,
+			Filters:     searchpkg.SearchFilters(c.Request.URL.Query(), "q"),
+			Source:      searchpkg.SourceSearch,
+			ResultCount: result.Total,
+			LatencyMs:   time.Since(started).Milliseconds(),
+		}),
+	}
+	for _, hit := range result.Hits {
+		if p, ok := byID[hit.ProductID]; ok {
+			resp.Items = append(resp.Items, p)
+			resp.Hits = append(resp.Hits, hit)
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RebuildSearchIndex 管理员在服务进程内重建搜索索引
+// @Summary      Rebuild Search Index
+// @Description  Re-import all products from the database into the current search backend in the background (Admin only). Works while the server is running, unlike the rebuild script which cannot open a bleve index held by the server
+// @Tags         Search
+// @Produce      json
+// @Security     BearerAuth
+// @Success      202  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Failure      503  {object}  map[string]interface{}
+// @Router       /search/admin/rebuild [post]
+func RebuildSearchIndex(c *gin.Context) {
+	switch err := searchpkg.StartRebuild(config.DB); err {
+	case nil:
+		c.JSON(http.StatusAccepted, gin.H{"message": "Search index rebuild started"})
+	case searchpkg.ErrRebuildRunning:
+		c.JSON(http.StatusConflict, gin.H{"error": "Search index rebuild is already running"})
+	default:
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Search engine is not available"})
+	}
+}
@@ -2,11 +2,15 @@ package search
 
 import (
 	"context"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"go-flutter-mall/backend/config"
 	"go-flutter-mall/backend/models"
+	searchpkg "go-flutter-mall/backend/pkg/search"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
@@ -18,6 +22,7 @@ type AddSearchHistoryInput struct {
 }
 
 // AddSearchHistory 添加搜索记录
+// 同一关键词只保留一条并刷新时间，超出每用户上限的旧记录会被删除
 // @Summary      Add Search History
 // @Description  Add a keyword to the user's search history
 // @Tags         Search
@@ -40,12 +45,18 @@ func AddSearchHistory(c *gin.Context) {
 		return
 	}
 
-	collection := config.MongoDB.Collection("search_history")
+	keyword := strings.TrimSpace(input.Keyword)
+	if keyword == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Keyword is required"})
+		return
+	}
+
+	collection := config.MongoDB.Collection(searchpkg.HistoryCollection)
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	// 检查是否已存在相同的关键词，如果存在则更新时间
-	filter := bson.M{"user_id": userID, "keyword": input.Keyword}
+	filter := bson.M{"user_id": userID, "keyword": keyword}
 	update := bson.M{
 		"$set": bson.M{
 			"created_at": time.Now(),
@@ -59,6 +70,11 @@ func AddSearchHistory(c *gin.Context) {
 		return
 	}
 
+	// 裁剪失败不影响本次记录
+	if err := searchpkg.TrimHistory(ctx, userID); err != nil {
+		log.Printf("Failed to trim search history of user %v: %v", userID, err)
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "Search history saved"})
 }
 
@@ -68,18 +84,24 @@ func AddSearchHistory(c *gin.Context) {
 // @Tags         Search
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {array}   models.SearchHistory
-// @Failure      500  {object}  map[string]interface{}
+// @Param        limit  query     int  false  "Max entries" default(10)
+// @Success      200    {array}   models.SearchHistory
+// @Failure      500    {object}  map[string]interface{}
 // @Router       /search/history [get]
 func GetSearchHistory(c *gin.Context) {
 	userID, _ := c.Get("userID")
 
-	collection := config.MongoDB.Collection("search_history")
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	collection := config.MongoDB.Collection(searchpkg.HistoryCollection)
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// 按时间倒序查询前 10 条
-	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(10)
+	// 按时间倒序查询前 limit 条
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(int64(limit))
 	cursor, err := collection.Find(ctx, bson.M{"user_id": userID}, opts)
 	if err != nil {
 		// Log the actual error for debugging
@@ -104,21 +126,38 @@ func GetSearchHistory(c *gin.Context) {
 }
 
 // ClearSearchHistory 清空搜索历史
+// 指定 keyword 时只删除该关键词
 // @Summary      Clear Search History
-// @Description  Clear all search history for the user
+// @Description  Clear all search history for the user, or only the given keyword
 // @Tags         Search
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {object}  map[string]interface{}
-// @Failure      500  {object}  map[string]interface{}
+// @Param        keyword  query     string  false  "Delete only this keyword"
+// @Success      200      {object}  map[string]interface{}
+// @Failure      404      {object}  map[string]interface{}
+// @Failure      500      {object}  map[string]interface{}
 // @Router       /search/history [delete]
 func ClearSearchHistory(c *gin.Context) {
 	userID, _ := c.Get("userID")
 
-	collection := config.MongoDB.Collection("search_history")
+	collection := config.MongoDB.Collection(searchpkg.HistoryCollection)
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	if keyword, ok := c.GetQuery("keyword"); ok {
+		result, err := collection.DeleteOne(ctx, bson.M{"user_id": userID, "keyword": strings.TrimSpace(keyword)})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete search history"})
+			return
+		}
+		if result.DeletedCount == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Keyword not found in search history"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"message": "Search history keyword deleted"})
+		return
+	}
+
 	_, err := collection.DeleteMany(ctx, bson.M{"user_id": userID})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clear search history"})
@@ -0,0 +1,190 @@
+package search
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	searchpkg "go-flutter-mall/backend/pkg/search"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Suggestion 搜索建议项
+type Suggestion struct {
+	Text      string `json:"text"`
+	Type      string `json:"type"`                 // product-商品名称, keyword-热门关键词
+	ProductID uint   `json:"product_id,omitempty"` // Type 为 product 时的商品 ID
+}
+
+// SearchTermInput 置顶/屏蔽热搜词的输入参数
+type SearchTermInput struct {
+	Keyword   string `json:"keyword" binding:"required"`
+	Action    string `json:"action" binding:"required,oneof=pin block"` // pin-置顶, block-屏蔽
+	SortOrder int    `json:"sort_order"`
+}
+
+// escapeLike 转义 LIKE 模式中的通配符
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// GetSuggestions 搜索建议 (自动补全)
+// 先返回名称以输入开头的上架商品，再补充以输入开头的热门关键词
+// @Summary      Search Suggestions
+// @Description  Autocomplete from product name prefixes and popular historical keywords
+// @Tags         Search
+// @Produce      json
+// @Param        q      query     string  true   "Prefix"
+// @Param        limit  query     int     false  "Max suggestions" default(10)
+// @Success      200    {array}   Suggestion
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /search/suggest [get]
+func GetSuggestions(c *gin.Context) {
+	q := strings.TrimSpace(c.Query("q"))
+	if q == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Keyword is required"})
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit < 1 || limit > 20 {
+		limit = 10
+	}
+
+	var products []models.Product
+	if err := config.DB.Select("id", "name").
+		Where("status = ? AND name ILIKE ?", 1, escapeLike(q)+"%").
+		Order("name ASC").Limit(limit).Find(&products).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch suggestions"})
+		return
+	}
+
+	suggestions := []Suggestion{}
+	seen := make(map[string]bool)
+	for _, p := range products {
+		suggestions = append(suggestions, Suggestion{Text: p.Name, Type: "product", ProductID: p.ID})
+		seen[strings.ToLower(p.Name)] = true
+	}
+	for _, keyword := range searchpkg.SuggestKeywords(q, limit) {
+		if len(suggestions) >= limit {
+			break
+		}
+		if seen[keyword] {
+			continue
+		}
+		suggestions = append(suggestions, Suggestion{Text: keyword, Type: "keyword"})
+	}
+
+	c.JSON(http.StatusOK, suggestions)
+}
+
+// GetHotKeywords 热搜榜
+// 定时从搜索历史统计并缓存在 Redis，管理员置顶词在前，屏蔽词不出现
+// @Summary      Hot Keywords
+// @Description  Get popular search keywords of the last 7 days, with pinned terms first and blocked terms removed
+// @Tags         Search
+// @Produce      json
+// @Param        limit  query     int  false  "Max keywords" default(10)
+// @Success      200    {array}   searchpkg.HotKeyword
+// @Router       /search/hot [get]
+func GetHotKeywords(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	c.JSON(http.StatusOK, searchpkg.HotKeywords(limit))
+}
+
+// GetSearchTerms 管理员获取置顶/屏蔽词列表
+// @Summary      Get Search Terms
+// @Description  List pinned and blocked hot keywords (Admin only)
+// @Tags         Search
+// @Produce      json
+// @Security     BearerAuth
+// @Param        action  query     string  false  "Filter by action (pin/block)"
+// @Success      200     {array}   models.SearchTerm
+// @Failure      500     {object}  map[string]interface{}
+// @Router       /search/admin/terms [get]
+func GetSearchTerms(c *gin.Context) {
+	query := config.DB.Order("action ASC, sort_order ASC, id ASC")
+	if action := c.Query("action"); action != "" {
+		query = query.Where("action = ?", action)
+	}
+
+	terms := []models.SearchTerm{}
+	if err := query.Find(&terms).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch search terms"})
+		return
+	}
+
+	c.JSON(http.StatusOK, terms)
+}
+
+// SaveSearchTerm 管理员置顶或屏蔽热搜词
+// 同一关键词只保留一条规则，重复提交时覆盖干预方式和排序
+// @Summary      Save Search Term
+// @Description  Pin or block a hot keyword; an existing rule for the keyword is replaced (Admin only)
+// @Tags         Search
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input  body      SearchTermInput  true  "Search Term"
+// @Success      200    {object}  models.SearchTerm
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /search/admin/terms [post]
+func SaveSearchTerm(c *gin.Context) {
+	var input SearchTermInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	keyword := strings.ToLower(strings.TrimSpace(input.Keyword))
+	if keyword == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Keyword is required"})
+		return
+	}
+
+	var term models.SearchTerm
+	config.DB.Where("keyword = ?", keyword).First(&term)
+	term.Keyword = keyword
+	term.Action = input.Action
+	term.SortOrder = input.SortOrder
+	if err := config.DB.Save(&term).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save search term"})
+		return
+	}
+
+	c.JSON(http.StatusOK, term)
+}
+
+// DeleteSearchTerm 管理员取消置顶/屏蔽
+// @Summary      Delete Search Term
+// @Description  Remove a pin or block rule (Admin only)
+// @Tags         Search
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Search Term ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /search/admin/terms/{id} [delete]
+func DeleteSearchTerm(c *gin.Context) {
+	var term models.SearchTerm
+	if err := config.DB.First(&term, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Search term not found"})
+		return
+	}
+
+	// 硬删除，便于同一关键词重新配置
+	if err := config.DB.Unscoped().Delete(&term).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete search term"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Search term deleted successfully"})
+}
@@ -0,0 +1,233 @@
+package shipping
+
+import (
+	"net/http"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// FreightRuleInput 运费规则输入参数
+type FreightRuleInput struct {
+	Regions        []string `json:"regions"` // 为空表示默认规则
+	FirstUnit      float64  `json:"first_unit" binding:"gt=0"`
+	FirstFee       float64  `json:"first_fee" binding:"gte=0"`
+	AdditionalUnit float64  `json:"additional_unit" binding:"gte=0"`
+	AdditionalFee  float64  `json:"additional_fee" binding:"gte=0"`
+	FreeThreshold  float64  `json:"free_threshold" binding:"gte=0"`
+}
+
+// FreightTemplateInput 创建/更新运费模板的输入参数
+type FreightTemplateInput struct {
+	Name       string             `json:"name" binding:"required"`
+	ChargeType string             `json:"charge_type" binding:"required,oneof=item weight"`
+	IsDefault  bool               `json:"is_default"`
+	Rules      []FreightRuleInput `json:"rules" binding:"required,min=1,dive"`
+}
+
+// toRules 将输入转换为运费规则
+func (input *FreightTemplateInput) toRules(templateID uint) []models.FreightRule {
+	rules := make([]models.FreightRule, len(input.Rules))
+	for i, r := range input.Rules {
+		rules[i] = models.FreightRule{
+			TemplateID:     templateID,
+			Regions:        r.Regions,
+			FirstUnit:      r.FirstUnit,
+			FirstFee:       r.FirstFee,
+			AdditionalUnit: r.AdditionalUnit,
+			AdditionalFee:  r.AdditionalFee,
+			FreeThreshold:  r.FreeThreshold,
+		}
+	}
+	return rules
+}
+
+// clearDefault 取消其他模板的默认标记，保证只有一个默认模板
+func clearDefault(tx *gorm.DB, exceptID uint) error {
+	return tx.Model(&models.FreightTemplate{}).Where("is_default = ? AND id <> ?", true, exceptID).Update("is_default", false).Error
+}
+
+// GetFreightTemplates 管理员获取运费模板列表
+// @Summary      Get Freight Templates
+// @Description  Get all freight templates with their rules (Admin only)
+// @Tags         Shipping
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   models.FreightTemplate
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /shipping/admin/templates [get]
+func GetFreightTemplates(c *gin.Context) {
+	templates := []models.FreightTemplate{}
+
+	if err := config.DB.Preload("Rules").Order("is_default desc, created_at desc").Find(&templates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch freight templates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+// CreateFreightTemplate 管理员创建运费模板
+// @Summary      Create Freight Template
+// @Description  Create a new freight template (Admin only)
+// @Tags         Shipping
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input  body      FreightTemplateInput  true  "Freight Template Info"
+// @Success      201    {object}  models.FreightTemplate
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /shipping/admin/templates [post]
+func CreateFreightTemplate(c *gin.Context) {
+	var input FreightTemplateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tpl := models.FreightTemplate{
+		Name:       input.Name,
+		ChargeType: input.ChargeType,
+		IsDefault:  input.IsDefault,
+		Rules:      input.toRules(0),
+	}
+
+	tx := config.DB.Begin()
+
+	if err := tx.Create(&tpl).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create freight template"})
+		return
+	}
+
+	if tpl.IsDefault {
+		if err := clearDefault(tx, tpl.ID); err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update default template"})
+			return
+		}
+	}
+
+	tx.Commit()
+
+	c.JSON(http.StatusCreated, tpl)
+}
+
+// UpdateFreightTemplate 管理员更新运费模板
+// 规则整体替换
+// @Summary      Update Freight Template
+// @Description  Update a freight template and replace its rules (Admin only)
+// @Tags         Shipping
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      int                   true  "Freight Template ID"
+// @Param        input  body      FreightTemplateInput  true  "Freight Template Info"
+// @Success      200    {object}  models.FreightTemplate
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      404    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /shipping/admin/templates/{id} [put]
+func UpdateFreightTemplate(c *gin.Context) {
+	id := c.Param("id")
+	var tpl models.FreightTemplate
+
+	if err := config.DB.First(&tpl, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Freight template not found"})
+		return
+	}
+
+	var input FreightTemplateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tx := config.DB.Begin()
+
+	tpl.Name = input.Name
+	tpl.ChargeType = input.ChargeType
+	tpl.IsDefault = input.IsDefault
+	if err := tx.Save(&tpl).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update freight template"})
+		return
+	}
+
+	// 替换规则
+	if err := tx.Unscoped().Where("template_id = ?", tpl.ID).Delete(&models.FreightRule{}).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update freight rules"})
+		return
+	}
+	tpl.Rules = input.toRules(tpl.ID)
+	if err := tx.Create(&tpl.Rules).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update freight rules"})
+		return
+	}
+
+	if tpl.IsDefault {
+		if err := clearDefault(tx, tpl.ID); err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update default template"})
+			return
+		}
+	}
+
+	tx.Commit()
+
+	c.JSON(http.StatusOK, tpl)
+}
+
+// DeleteFreightTemplate 管理员删除运费模板
+// 仍被商品引用的模板不允许删除
+// @Summary      Delete Freight Template
+// @Description  Delete a freight template that is not referenced by any product (Admin only)
+// @Tags         Shipping
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Freight Template ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /shipping/admin/templates/{id} [delete]
+func DeleteFreightTemplate(c *gin.Context) {
+	id := c.Param("id")
+	var tpl models.FreightTemplate
+
+	if err := config.DB.First(&tpl, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Freight template not found"})
+		return
+	}
+
+	var count int64
+	if err := config.DB.Model(&models.Product{}).Where("freight_template_id = ?", tpl.ID).Count(&count).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check template usage"})
+		return
+	}
+	if count > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Freight template is still used by products"})
+		return
+	}
+
+	tx := config.DB.Begin()
+	if err := tx.Where("template_id = ?", tpl.ID).Delete(&models.FreightRule{}).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete freight rules"})
+		return
+	}
+	if err := tx.Delete(&tpl).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete freight template"})
+		return
+	}
+	tx.Commit()
+
+	c.JSON(http.StatusOK, gin.H{"message": "Freight template deleted successfully"})
+}
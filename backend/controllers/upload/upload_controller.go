@@ -0,0 +1,176 @@
+package upload
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 单次最多上传的图片数 (商品轮播图、评价晒图)
+const maxFilesPerRequest = 9
+
+// uploadError 将存储错误转换为 HTTP 响应
+func uploadError(c *gin.Context, filename string, err error) {
+	status := http.StatusInternalServerError
+	message := "Failed to save image"
+	switch {
+	case errors.Is(err, storage.ErrTooLarge):
+		status, message = http.StatusRequestEntityTooLarge, err.Error()
+	case errors.Is(err, storage.ErrUnsupportedType):
+		status, message = http.StatusUnsupportedMediaType, err.Error()
+	case errors.Is(err, storage.ErrInvalidImage):
+		status, message = http.StatusBadRequest, err.Error()
+	case errors.Is(err, storage.ErrUnavailable):
+		status, message = http.StatusServiceUnavailable, err.Error()
+	}
+	c.JSON(status, gin.H{"error": message, "file": filename})
+}
+
+// saveFile 打开上传的文件并保存为指定用途的图片
+func saveFile(c *gin.Context, kind string, fh *multipart.FileHeader) (*storage.Image, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return storage.SaveImage(c.Request.Context(), kind, f)
+}
+
+// saveFiles 保存表单中 files (或单个 file) 字段的全部图片，任一失败时直接返回错误响应
+func saveFiles(c *gin.Context, kind string) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Multipart form is required"})
+		return
+	}
+	files := append(form.File["files"], form.File["file"]...)
+	if len(files) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one file is required"})
+		return
+	}
+	if len(files) > maxFilesPerRequest {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Too many files"})
+		return
+	}
+
+	images := make([]*storage.Image, 0, len(files))
+	for _, fh := range files {
+		img, err := saveFile(c, kind, fh)
+		if err != nil {
+			uploadError(c, fh.Filename, err)
+			return
+		}
+		images = append(images, img)
+	}
+	c.JSON(http.StatusCreated, images)
+}
+
+// UploadProductImages 上传商品图片
+// 返回的 url 可用于商品封面、轮播图、SKU 图片和规格值图片
+// @Summary      Upload Product Images
+// @Description  Upload up to 9 product images (jpeg/png/gif/webp). Returns the original URL plus thumb/medium variants and their WebP versions (Admin only)
+// @Tags         Upload
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        files  formData  file  true  "Image files (repeat the field for multiple files)"
+// @Success      201    {array}   storage.Image
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      403    {object}  map[string]interface{}
+// @Failure      413    {object}  map[string]interface{}
+// @Failure      415    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /uploads/products [post]
+func UploadProductImages(c *gin.Context) {
+	saveFiles(c, storage.KindProduct)
+}
+
+// UploadReviewImages 上传评价晒图
+// @Summary      Upload Review Images
+// @Description  Upload up to 9 review images (jpeg/png/gif/webp) before submitting a review
+// @Tags         Upload
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        files  formData  file  true  "Image files (repeat the field for multiple files)"
+// @Success      201    {array}   storage.Image
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      413    {object}  map[string]interface{}
+// @Failure      415    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /uploads/reviews [post]
+func UploadReviewImages(c *gin.Context) {
+	saveFiles(c, storage.KindReview)
+}
+
+// UploadChatImage 上传聊天图片
+// 返回的 url 作为 type=image 消息的内容发送
+// @Summary      Upload Chat Image
+// @Description  Upload a chat image (jpeg/png/gif/webp)
+// @Tags         Upload
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file  formData  file  true  "Image file"
+// @Success      201   {object}  storage.Image
+// @Failure      400   {object}  map[string]interface{}
+// @Failure      413   {object}  map[string]interface{}
+// @Failure      415   {object}  map[string]interface{}
+// @Failure      500   {object}  map[string]interface{}
+// @Router       /uploads/chat [post]
+func UploadChatImage(c *gin.Context) {
+	fh, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is required"})
+		return
+	}
+	img, err := saveFile(c, storage.KindChat, fh)
+	if err != nil {
+		uploadError(c, fh.Filename, err)
+		return
+	}
+	c.JSON(http.StatusCreated, img)
+}
+
+// UploadAvatar 上传并设置当前用户头像
+// 头像不超过 2MB，保存后使用缩略图作为用户头像
+// @Summary      Upload Avatar
+// @Description  Upload an avatar image (max 2MB) and set it as the current user's avatar
+// @Tags         Upload
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file  formData  file  true  "Image file"
+// @Success      201   {object}  storage.Image
+// @Failure      400   {object}  map[string]interface{}
+// @Failure      413   {object}  map[string]interface{}
+// @Failure      415   {object}  map[string]interface{}
+// @Failure      500   {object}  map[string]interface{}
+// @Router       /uploads/avatar [post]
+func UploadAvatar(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is required"})
+		return
+	}
+	img, err := saveFile(c, storage.KindAvatar, fh)
+	if err != nil {
+		uploadError(c, fh.Filename, err)
+		return
+	}
+
+	avatar := img.Variants["thumb"]
+	if err := config.DB.Model(&models.User{}).Where("id = ?", userID).Update("avatar", avatar).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update avatar"})
+		return
+	}
+	c.JSON(http.StatusCreated, img)
+}
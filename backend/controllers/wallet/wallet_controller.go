@@ -0,0 +1,217 @@
+package wallet
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	walletpkg "go-flutter-mall/backend/pkg/wallet"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetWallet 获取当前用户的钱包余额
+// @Summary      Get Wallet
+// @Description  Get the stored-value wallet of the authenticated user
+// @Tags         Wallet
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  models.Wallet
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /wallet [get]
+func GetWallet(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	w, err := walletpkg.FindWallet(config.DB, userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch wallet"})
+		return
+	}
+
+	c.JSON(http.StatusOK, w)
+}
+
+// GetWalletTransactions 获取当前用户的钱包流水
+// 返回钱包账户的账本分录，贷记 (credit) 为入账，借记 (debit) 为出账
+// @Summary      Get Wallet Transactions
+// @Description  Get the ledger entries of the authenticated user's wallet
+// @Tags         Wallet
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page       query     int  false  "Page number" default(1)
+// @Param        page_size  query     int  false  "Page size" default(20)
+// @Success      200        {array}   models.LedgerEntry
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /wallet/transactions [get]
+func GetWalletTransactions(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	entries := []models.LedgerEntry{}
+
+	// 解析分页参数
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	offset := (page - 1) * pageSize
+
+	if err := config.DB.Where("account = ?", walletpkg.WalletAccount(userID.(uint))).Order("id desc").Limit(pageSize).Offset(offset).Find(&entries).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch wallet transactions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// RedeemGiftCardInput 兑换礼品卡的输入参数
+type RedeemGiftCardInput struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// RedeemGiftCard 兑换礼品卡到钱包
+// @Summary      Redeem Gift Card
+// @Description  Redeem a gift card code into the authenticated user's wallet
+// @Tags         Wallet
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input  body      RedeemGiftCardInput  true  "Gift Card Code"
+// @Success      200    {object}  map[string]interface{}
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /wallet/redeem [post]
+func RedeemGiftCard(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	var input RedeemGiftCardInput
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tx := config.DB.Begin()
+	card, err := walletpkg.Redeem(tx, userID.(uint), input.Code)
+	if err != nil {
+		tx.Rollback()
+		switch err {
+		case walletpkg.ErrGiftCardNotFound, walletpkg.ErrGiftCardRedeemed, walletpkg.ErrGiftCardDisabled, walletpkg.ErrGiftCardExpired, walletpkg.ErrInvalidAmount:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to redeem gift card"})
+		}
+		return
+	}
+	tx.Commit()
+
+	w, _ := walletpkg.FindWallet(config.DB, userID.(uint))
+	c.JSON(http.StatusOK, gin.H{"message": "Gift card redeemed successfully", "amount": card.FaceValue, "balance": w.Balance})
+}
+
+// IssueGiftCardsInput 发行礼品卡的输入参数
+type IssueGiftCardsInput struct {
+	BatchNo   string    `json:"batch_no"` // 批次号，为空时自动生成
+	Count     int       `json:"count" binding:"required,min=1,max=1000"`
+	FaceValue float64   `json:"face_value" binding:"required,gt=0"`
+	ExpireAt  time.Time `json:"expire_at" binding:"required"`
+}
+
+// IssueGiftCards 管理员批量发行礼品卡
+// @Summary      Issue Gift Cards
+// @Description  Issue a batch of gift card codes, e.g. for a corporate purchase (Admin only)
+// @Tags         Wallet
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input  body      IssueGiftCardsInput  true  "Issue Info"
+// @Success      201    {array}   models.GiftCard
+// @Failure      400    {object}  map[string]interface{}
+// @Failure      500    {object}  map[string]interface{}
+// @Router       /gift-cards/admin [post]
+func IssueGiftCards(c *gin.Context) {
+	var input IssueGiftCardsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !input.ExpireAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expire_at must be in the future"})
+		return
+	}
+	if input.BatchNo == "" {
+		input.BatchNo = fmt.Sprintf("GC%d", time.Now().UnixNano())
+	}
+
+	tx := config.DB.Begin()
+	cards, err := walletpkg.Issue(tx, input.BatchNo, input.Count, input.FaceValue, input.ExpireAt)
+	if err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue gift cards"})
+		return
+	}
+	tx.Commit()
+
+	c.JSON(http.StatusCreated, cards)
+}
+
+// GetGiftCards 管理员查询礼品卡
+// @Summary      Get Gift Cards
+// @Description  Get gift cards, optionally filtered by batch and status (Admin only)
+// @Tags         Wallet
+// @Produce      json
+// @Security     BearerAuth
+// @Param        batch_no   query     string  false  "Batch number"
+// @Param        status     query     int     false  "Status (0 active, 1 redeemed, 2 disabled)"
+// @Param        page       query     int     false  "Page number" default(1)
+// @Param        page_size  query     int     false  "Page size" default(20)
+// @Success      200        {array}   models.GiftCard
+// @Failure      500        {object}  map[string]interface{}
+// @Router       /gift-cards/admin [get]
+func GetGiftCards(c *gin.Context) {
+	cards := []models.GiftCard{}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	offset := (page - 1) * pageSize
+
+	query := config.DB.Order("created_at desc")
+	if batchNo := c.Query("batch_no"); batchNo != "" {
+		query = query.Where("batch_no = ?", batchNo)
+	}
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Limit(pageSize).Offset(offset).Find(&cards).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch gift cards"})
+		return
+	}
+
+	c.JSON(http.StatusOK, cards)
+}
+
+// DisableGiftCard 管理员作废未兑换的礼品卡
+// @Summary      Disable Gift Card
+// @Description  Disable an unredeemed gift card (Admin only)
+// @Tags         Wallet
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Gift Card ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      500  {object}  map[string]interface{}
+// @Router       /gift-cards/admin/{id}/disable [put]
+func DisableGiftCard(c *gin.Context) {
+	id := c.Param("id")
+
+	result := config.DB.Model(&models.GiftCard{}).Where("id = ? AND status = ?", id, models.GiftCardStatusActive).Update("status", models.GiftCardStatusDisabled)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to disable gift card"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Gift card not found or already redeemed"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Gift card disabled successfully"})
+}
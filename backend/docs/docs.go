@@ -349,28 +349,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/cart": {
+        "/brands": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get list of items in the user's shopping cart",
+                "description": "List all brands ordered by sort_order, with the number of on-shelf products",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Cart"
+                    "Brand"
                 ],
-                "summary": "Get Cart",
+                "summary": "Get Brands",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.CartItem"
+                                "$ref": "#/definitions/brand.BrandWithCount"
                             }
                         }
                     },
@@ -382,14 +377,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/brands/admin": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add a product to the shopping cart or update quantity if exists",
+                "description": "Create a new brand (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -397,31 +394,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cart"
+                    "Brand"
                 ],
-                "summary": "Add to Cart",
+                "summary": "Create Brand",
                 "parameters": [
                     {
-                        "description": "Cart Item Info",
+                        "description": "Brand Info",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/cart.AddToCartInput"
+                            "$ref": "#/definitions/brand.BrandInput"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.CartItem"
-                        }
-                    },
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.CartItem"
+                            "$ref": "#/definitions/models.Brand"
                         }
                     },
                     "400": {
@@ -441,14 +432,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/cart/{id}": {
+        "/brands/admin/{id}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update quantity or selected status of a cart item",
+                "description": "Update a brand (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -456,24 +447,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Cart"
+                    "Brand"
                 ],
-                "summary": "Update Cart Item",
+                "summary": "Update Brand",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Cart Item ID",
+                        "description": "Brand ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated Info",
+                        "description": "Brand Info",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CartItem"
+                            "$ref": "#/definitions/brand.BrandInput"
                         }
                     }
                 ],
@@ -481,7 +472,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.CartItem"
+                            "$ref": "#/definitions/models.Brand"
                         }
                     },
                     "400": {
@@ -497,30 +488,35 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
-            }
-        },
-        "/chat/messages/{userId}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get chat history with a specific user",
+                "description": "Delete a brand that no product references (Admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Chat"
+                    "Brand"
                 ],
-                "summary": "Get Messages",
+                "summary": "Delete Brand",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "userId",
+                        "description": "Brand ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -529,10 +525,22 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.ChatMessage"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
@@ -545,41 +553,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/notification": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Send a system notification to a user",
-                "consumes": [
-                    "application/json"
-                ],
+        "/brands/{id}": {
+            "get": {
+                "description": "Get a brand with a paginated list of its on-shelf products",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Chat"
+                    "Brand"
                 ],
-                "summary": "Send System Notification",
+                "summary": "Get Brand Landing",
                 "parameters": [
                     {
-                        "description": "Notification Info",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/chat.SendNotificationInput"
-                        }
+                        "type": "integer",
+                        "description": "Brand ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort: newest (default), price_asc, price_desc",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/brand.BrandLandingResponse"
                         }
                     },
                     "400": {
@@ -589,6 +606,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -599,69 +623,80 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/read": {
-            "put": {
+        "/browsing-history": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mark all messages from admin as read for the current user",
+                "description": "Get a paginated list of products the current user viewed, one entry per product per day, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Chat"
+                    "BrowsingHistory"
+                ],
+                "summary": "Get Browsing History",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Mark Messages as Read",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/browsing.HistoryListResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/chat/users": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of users who have chatted with the admin",
+                "description": "Clear all browsing history of the current user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Chat"
+                    "BrowsingHistory"
                 ],
-                "summary": "Get Chat Users",
+                "summary": "Clear Browsing History",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.User"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
@@ -670,68 +705,131 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             }
         },
-        "/notifications": {
+        "/browsing-history/admin/views": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of notifications for the authenticated user",
+                "description": "Products ranked by views in a time range, with distinct visitors (Admin only). Only views of logged-in users within the retention period are counted",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
+                    "BrowsingHistory"
+                ],
+                "summary": "Top Viewed Products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (2006-01-02), default 7 days ago",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date inclusive (2006-01-02), default today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Max products",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get Notifications",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Notification"
+                                "$ref": "#/definitions/browsing.ProductViews"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             }
         },
-        "/notifications/admin/all": {
-            "get": {
+        "/browsing-history/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of all system notifications (Admin only)",
+                "description": "Delete one entry from the current user's browsing history",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
+                    "BrowsingHistory"
+                ],
+                "summary": "Delete Browsing History Entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Browsing history entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get All Notifications",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Notification"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
@@ -740,41 +838,39 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
             }
         },
-        "/notifications/admin/user/{userId}": {
+        "/cart": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get notifications for a specific user (Admin only)",
+                "description": "Get list of items in the user's shopping cart",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
-                ],
-                "summary": "Get User Notifications",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "userId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Cart"
                 ],
+                "summary": "Get Cart",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Notification"
+                                "$ref": "#/definitions/models.CartItem"
                             }
                         }
                     },
@@ -786,32 +882,60 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/notifications/unread-count": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the count of unread notifications and chat messages",
+                "description": "Add a product to the shopping cart or update quantity if exists",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
+                    "Cart"
+                ],
+                "summary": "Add to Cart",
+                "parameters": [
+                    {
+                        "description": "Cart Item Info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/cart.AddToCartInput"
+                        }
+                    }
                 ],
-                "summary": "Get Unread Count",
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CartItem"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.CartItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "integer",
-                                "format": "int64"
-                            }
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
@@ -824,33 +948,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/{id}/read": {
-            "put": {
+        "/cart/summary": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mark a specific notification as read",
+                "description": "Get the price breakdown (promotions and optional coupon) of selected cart items",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
+                    "Cart"
                 ],
-                "summary": "Mark Notification as Read",
+                "summary": "Get Cart Summary",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Notification ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "User Coupon ID",
+                        "name": "coupon_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pricing.Quote"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -866,36 +995,83 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders": {
-            "get": {
+        "/cart/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of orders for the authenticated user",
+                "description": "Update quantity or selected status of a cart item",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Cart"
                 ],
-                "summary": "Get Order List",
+                "summary": "Update Cart Item",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Order Status",
-                        "name": "status",
-                        "in": "query"
+                        "description": "Cart Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated Info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CartItem"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CartItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "description": "Get all categories as a tree ordered by sort_order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
                 ],
+                "summary": "Get Category Tree",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Order"
+                                "$ref": "#/definitions/models.Category"
                             }
                         }
                     },
@@ -907,14 +1083,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/categories/admin": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new order from selected cart items",
+                "description": "Create a new category, up to three levels deep (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -922,17 +1100,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Category"
                 ],
-                "summary": "Create Order",
+                "summary": "Create Category",
                 "parameters": [
                     {
-                        "description": "Order Info",
+                        "description": "Category Info",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/order.CreateOrderInput"
+                            "$ref": "#/definitions/category.CategoryInput"
                         }
                     }
                 ],
@@ -940,7 +1118,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Order"
+                            "$ref": "#/definitions/models.Category"
                         }
                     },
                     "400": {
@@ -950,13 +1128,6 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -967,28 +1138,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/admin/all": {
+        "/categories/admin/all": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of all orders (Admin only)",
+                "description": "Get all categories as a flat list (Admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Category"
                 ],
-                "summary": "Get All Orders",
+                "summary": "Get All Categories",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Order"
+                                "$ref": "#/definitions/models.Category"
                             }
                         }
                     },
@@ -1002,29 +1173,56 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/counts": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+        "/categories/admin/attributes/{id}": {
+            "put": {
+                "description": "Update an attribute template (Admin only)",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Get the count of orders for each status",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Category"
+                ],
+                "summary": "Update Category Attribute",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Attribute ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Attribute Info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/category.AttributeInput"
+                        }
+                    }
                 ],
-                "summary": "Get Order Counts",
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CategoryAttribute"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "integer"
-                            }
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
@@ -1035,27 +1233,20 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/orders/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get detailed information of a specific order",
+            },
+            "delete": {
+                "description": "Delete an attribute template and the values products have for it (Admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Category"
                 ],
-                "summary": "Get Order Detail",
+                "summary": "Delete Category Attribute",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Order ID",
+                        "description": "Attribute ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1065,7 +1256,8 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Order"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "404": {
@@ -1074,30 +1266,47 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/categories/admin/sort": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete an order (Admin only)",
+                "description": "Update sort_order of multiple categories at once (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Category"
                 ],
-                "summary": "Delete Order",
+                "summary": "Sort Categories",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "New Sort Orders",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/category.SortCategoryInput"
+                            }
+                        }
                     }
                 ],
                 "responses": {
@@ -1108,8 +1317,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1125,33 +1334,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}/after-sales": {
-            "post": {
+        "/categories/admin/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Apply for after-sales service for an order",
-                "produces": [
+                "description": "Update a category, optionally moving it under another parent (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Category"
                 ],
-                "summary": "Apply After-Sales",
+                "summary": "Update Category",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Order ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Category Info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/category.CategoryInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Category"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1165,27 +1399,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/orders/{id}/pay": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Simulate payment for an order",
+                "description": "Delete a category that has no products and no subcategories (Admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Category"
                 ],
-                "summary": "Pay Order",
+                "summary": "Delete Category",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Order ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1206,43 +1438,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                }
-            }
-        },
-        "/orders/{id}/receipt": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Confirm receipt of an order",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Order"
-                ],
-                "summary": "Confirm Receipt",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1258,14 +1455,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}/review": {
+        "/categories/admin/{id}/attributes": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Submit a review for an order",
+                "description": "Add an attribute template to a category; it applies to products of the category and its subcategories (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1273,33 +1465,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Category"
                 ],
-                "summary": "Review Order",
+                "summary": "Create Category Attribute",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Order ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Review Content",
+                        "description": "Attribute Info",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/order.ReviewOrderInput"
+                            "$ref": "#/definitions/category.AttributeInput"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.CategoryAttribute"
                         }
                     },
                     "400": {
@@ -1309,6 +1500,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1319,55 +1517,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}/status": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update the status of an order (Admin only)",
-                "consumes": [
-                    "application/json"
-                ],
+        "/categories/{id}/attributes": {
+            "get": {
+                "description": "List attribute templates that apply to products of a category, including ones inherited from parent categories",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Category"
                 ],
-                "summary": "Update Order Status",
+                "summary": "Get Category Attributes",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Order ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "New Status",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/order.UpdateOrderStatusInput"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CategoryAttribute"
+                            }
                         }
                     },
                     "404": {
@@ -1387,17 +1563,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/products": {
+        "/categories/{id}/products": {
             "get": {
-                "description": "Get a list of products with pagination and search",
+                "description": "Get on-shelf products of a category and all its descendants",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Category"
                 ],
-                "summary": "Get Product List",
+                "summary": "Get Category Products",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -1411,12 +1594,6 @@ const docTemplate = `{
                         "description": "Page size",
                         "name": "page_size",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search keyword",
-                        "name": "search",
-                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1429,47 +1606,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Create a new product (Admin only)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Product"
-                ],
-                "summary": "Create Product",
-                "parameters": [
-                    {
-                        "description": "Product Info",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/product.CreateProductInput"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/models.Product"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1485,21 +1623,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}": {
+        "/chat/messages/{userId}": {
             "get": {
-                "description": "Get detailed information of a product by ID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get chat history with a specific user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Chat"
                 ],
-                "summary": "Get Product Detail",
+                "summary": "Get Messages",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
+                        "description": "User ID",
+                        "name": "userId",
                         "in": "path",
                         "required": true
                     }
@@ -1508,20 +1651,30 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ChatMessage"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing product (Admin only)",
+            }
+        },
+        "/chat/notification": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send a system notification to a user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1529,43 +1682,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Chat"
                 ],
-                "summary": "Update Product",
+                "summary": "Send System Notification",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Product Info",
+                        "description": "Notification Info",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/product.CreateProductInput"
+                            "$ref": "#/definitions/chat.SendNotificationInput"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.Product"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1579,25 +1719,23 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a product by ID (Admin only)",
+            }
+        },
+        "/chat/read": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark all messages from admin as read for the current user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
-                ],
-                "summary": "Delete Product",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Chat"
                 ],
+                "summary": "Mark Messages as Read",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1606,8 +1744,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1623,32 +1761,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}/reviews": {
+        "/chat/users": {
             "get": {
-                "description": "Get a list of reviews for a specific product",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of users who have chatted with the admin",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Chat"
                 ],
-                "summary": "Get Product Reviews",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                "summary": "Get Chat Users",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
+                }
+            }
+        },
+        "/coupons": {
+            "get": {
+                "description": "Get a list of coupon templates that are currently claimable",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupon"
                 ],
+                "summary": "Get Claimable Coupons",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Review"
+                                "$ref": "#/definitions/models.CouponTemplate"
                             }
                         }
                     },
@@ -1662,28 +1826,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/search/history": {
+        "/coupons/admin/templates": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the user's recent search history",
+                "description": "Get all coupon templates (Admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Search"
+                    "Coupon"
                 ],
-                "summary": "Get Search History",
+                "summary": "Get Coupon Templates",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.SearchHistory"
+                                "$ref": "#/definitions/models.CouponTemplate"
                             }
                         }
                     },
@@ -1702,7 +1866,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add a keyword to the user's search history",
+                "description": "Create a new coupon template (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1710,27 +1874,86 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Search"
+                    "Coupon"
                 ],
-                "summary": "Add Search History",
+                "summary": "Create Coupon Template",
                 "parameters": [
                     {
-                        "description": "Search Keyword",
+                        "description": "Coupon Template Info",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/search.AddSearchHistoryInput"
+                            "$ref": "#/definitions/coupon.CouponTemplateInput"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.CouponTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
+                    }
+                }
+            }
+        },
+        "/coupons/admin/templates/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing coupon template (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupon"
+                ],
+                "summary": "Update Coupon Template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Coupon Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Coupon Template Info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/coupon.CouponTemplateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CouponTemplate"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1739,6 +1962,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1754,14 +1984,23 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Clear all search history for the user",
+                "description": "Delete a coupon template (Admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Search"
+                    "Coupon"
+                ],
+                "summary": "Delete Coupon Template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Coupon Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Clear Search History",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1770,6 +2009,13 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1779,148 +2025,7389 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "admin.AdminLoginInput": {
-            "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
-            "properties": {
-                "password": {
-                    "type": "string"
-                },
-                "username": {
-                    "type": "string"
-                }
-            }
-        },
-        "admin.DashboardStats": {
-            "type": "object",
-            "properties": {
-                "total_orders": {
-                    "type": "integer"
-                },
-                "total_products": {
-                    "type": "integer"
-                },
-                "total_sales": {
-                    "type": "number"
-                },
-                "total_users": {
-                    "type": "integer"
-                }
-            }
         },
-        "cart.AddToCartInput": {
-            "type": "object",
-            "required": [
-                "product_id",
-                "quantity"
-            ],
-            "properties": {
-                "product_id": {
-                    "description": "商品 ID",
-                    "type": "integer"
-                },
-                "quantity": {
-                    "description": "数量，至少为 1",
-                    "type": "integer",
-                    "minimum": 1
-                },
-                "sku_id": {
-                    "description": "SKU ID (可选)",
-                    "type": "integer"
+        "/coupons/mine": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get coupons in the user's wallet, filtered by status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupon"
+                ],
+                "summary": "Get My Coupons",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "unused",
+                        "description": "unused, used or expired",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.UserCoupon"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
                 }
             }
         },
-        "chat.SendNotificationInput": {
-            "type": "object",
-            "properties": {
-                "content": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
-                },
+        "/coupons/{id}/claim": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Claim a coupon from a template into the user's wallet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupon"
+                ],
+                "summary": "Claim Coupon",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Coupon Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserCoupon"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/favorites": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of the current user's favorite products, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Favorite"
+                ],
+                "summary": "Get Favorites",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/favorite.FavoriteListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a product to the current user's favorites (idempotent)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Favorite"
+                ],
+                "summary": "Add Favorite",
+                "parameters": [
+                    {
+                        "description": "Product",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/favorite.FavoriteInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/favorites/admin/top": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rank products by favorites (Admin only). With from/to, only favorites added in that range are counted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Favorite"
+                ],
+                "summary": "Get Most Favorited Products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD, inclusive)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of products",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/favorite.FavoriteRankItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/favorites/{productId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a product from the current user's favorites",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Favorite"
+                ],
+                "summary": "Remove Favorite",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/gift-cards/admin": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get gift cards, optionally filtered by batch and status (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Get Gift Cards",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Batch number",
+                        "name": "batch_no",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Status (0 active, 1 redeemed, 2 disabled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GiftCard"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue a batch of gift card codes, e.g. for a corporate purchase (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Issue Gift Cards",
+                "parameters": [
+                    {
+                        "description": "Issue Info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/wallet.IssueGiftCardsInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GiftCard"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/gift-cards/admin/{id}/disable": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Disable an unredeemed gift card (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Disable Gift Card",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gift Card ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of notifications for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "Get Notifications",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Notification"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/admin/all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of all system notifications (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "Get All Notifications",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Notification"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/admin/user/{userId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get notifications for a specific user (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "Get User Notifications",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Notification"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/unread-count": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the count of unread notifications and chat messages",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "Get Unread Count",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer",
+                                "format": "int64"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/read": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a specific notification as read",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notification"
+                ],
+                "summary": "Mark Notification as Read",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of orders for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Get Order List",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order Status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Order"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new order from selected cart items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Create Order",
+                "parameters": [
+                    {
+                        "description": "Order Info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/order.CreateOrderInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/admin/all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of all orders (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Get All Orders",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Order"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/counts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the count of orders for each status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Get Order Counts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/preview": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Calculate the price breakdown of selected cart items without creating an order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Preview Order",
+                "parameters": [
+                    {
+                        "description": "Preview Info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/order.PreviewOrderInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/pricing.Quote"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detailed information of a specific order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Get Order Detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete an order (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Delete Order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/after-sales": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply for after-sales service for an order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Apply After-Sales",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel an unpaid order, restoring stock and returning the coupon and points",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Cancel Order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/pay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pay for an order with wallet balance, the gateway (simulated), or both; awards loyalty points",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Pay Order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment Options",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/order.PayOrderInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/receipt": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Confirm receipt of an order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Confirm Receipt",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/refund": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Refund a paid order to the user's wallet, returning used points and reversing earned points (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Refund Order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/review": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Submit a review for an order and award loyalty points",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Review Order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review Content",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/order.ReviewOrderInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the status of an order (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Update Order Status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New Status",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/order.UpdateOrderStatusInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/points": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the points balance of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Points"
+                ],
+                "summary": "Get Points Balance",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PointsAccount"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/points/admin/adjust": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add or deduct points for a user (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Points"
+                ],
+                "summary": "Adjust Points",
+                "parameters": [
+                    {
+                        "description": "Adjustment Info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/points.AdjustPointsInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PointsTransaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/points/level": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the member tier of the authenticated user, progress to the next tier and all tier benefits",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Points"
+                ],
+                "summary": "Get Member Level",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/points/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the points transaction history of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Points"
+                ],
+                "summary": "Get Points History",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PointsTransaction"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/price-alerts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the current user's price alerts with product info and current lowest price",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PriceAlert"
+                ],
+                "summary": "Get My Price Alerts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/product.PriceAlertItem"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get notified when the lowest price of a product drops below target_price (defaults to the current lowest price)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PriceAlert"
+                ],
+                "summary": "Subscribe Price Alert",
+                "parameters": [
+                    {
+                        "description": "Price Alert",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product.PriceAlertInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PriceAlert"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/price-alerts/{productId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unsubscribe the price alert of a product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PriceAlert"
+                ],
+                "summary": "Delete Price Alert",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of products with filters, sorting and facet counts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Get Product List",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search keyword",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID (includes subcategories)",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Brand IDs, comma separated",
+                        "name": "brand_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only products in stock",
+                        "name": "in_stock",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attribute filters as attr[\u003cattribute_id\u003e]=v1,v2 (values of one attribute are ORed)",
+                        "name": "attr",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status: 1 on shelf (default), 0 off shelf, 2 draft, all (ignored without an admin token)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort: relevance (default when searching), newest (default), price_asc, price_desc, sales, rating",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product.ProductListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new product (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Create Product",
+                "parameters": [
+                    {
+                        "description": "Product Info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product.CreateProductInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/admin/calendar": {
+            "get": {
+                "description": "List upcoming scheduled publish/unpublish changes grouped by day (Admin only). Defaults to the next 30 days, at most 92 days",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Get Product Schedule Calendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD), default today",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD, inclusive), default from + 30 days",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product.ScheduleCalendarResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/export": {
+            "get": {
+                "description": "Download all products and SKUs as CSV or XLSX, in the same layout the import accepts (Admin only)",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Export Products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv (default) or xlsx",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/import": {
+            "post": {
+                "description": "Upload a CSV/XLSX of products and SKUs. Rows are upserted by sku_code; with dry_run=true only the planned changes are returned (Admin only)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Import Products",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV or XLSX file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and report without saving",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/catalog.Report"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/catalog.Report"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detailed information of a product by ID, with its SKUs, specs and a spec combination to SKU availability map. is_favorited is set and the view is added to the browsing history when a token is sent. Draft products return 404 unless preview=true is sent with an admin token. Served from the Redis cache when available",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Get Product Detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Show draft products (requires admin token)",
+                        "name": "preview",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product.ProductDetailResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing product and reconcile its SKUs (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Update Product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product Info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product.CreateProductInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a product by ID (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Delete Product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update only the provided fields of a product; skus, when provided, are reconciled by ID (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Patch Product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product.ProductPatchInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/images": {
+            "put": {
+                "description": "Replace the product gallery with the given ordered list (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Replace Product Images",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ordered image URLs",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product.ProductImagesInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Append images to the product gallery, skipping ones already present (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Add Product Images",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Image URLs",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product.ProductImagesInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove an image from the product gallery (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Delete Product Image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image URL",
+                        "name": "url",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/price-history": {
+            "get": {
+                "description": "List price changes of a product and its SKUs, newest first (Admin only). sku_id=0 filters base price changes only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Get Product Price History",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only changes of this SKU (0 for the product base price)",
+                        "name": "sku_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product.PriceHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/related": {
+            "get": {
+                "description": "Products frequently bought together with this product (co-purchase similarity, refreshed periodically), filled with popular products of the same category and then the whole store",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recommendation"
+                ],
+                "summary": "Get Related Products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of products",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/recommend.RecommendedProduct"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/reviews": {
+            "get": {
+                "description": "Get a list of reviews for a specific product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Get Product Reviews",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Review"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/schedule": {
+            "put": {
+                "description": "Set or clear (null) the scheduled publish and unpublish times of a product (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Update Product Schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product.ProductScheduleInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/skus/generate": {
+            "post": {
+                "description": "Create one SKU per spec combination; optionally remove SKUs that match no combination (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Generate SKU Matrix",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Defaults for new SKUs",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product.GenerateSKUsInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/product.GenerateSKUsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/specs": {
+            "get": {
+                "description": "List spec attributes and their values of a product, in display order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Get Product Specs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProductSpec"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the spec attributes and values of a product. Specs and values are matched by name so existing SKU combinations stay valid (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Save Product Specs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Specs",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product.ProductSpecsInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProductSpec"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/status": {
+            "put": {
+                "description": "Put a product on shelf (1), off shelf (0) or back to draft (2) (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Update Product Status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/product.ProductStatusInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions": {
+            "get": {
+                "description": "Get a list of promotions that are currently active",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotion"
+                ],
+                "summary": "Get Active Promotions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Promotion"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions/admin": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new promotion (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotion"
+                ],
+                "summary": "Create Promotion",
+                "parameters": [
+                    {
+                        "description": "Promotion Info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/promotion.PromotionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Promotion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions/admin/all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all promotions including inactive ones (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotion"
+                ],
+                "summary": "Get All Promotions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Promotion"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions/admin/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing promotion (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotion"
+                ],
+                "summary": "Update Promotion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promotion Info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/promotion.PromotionInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Promotion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a promotion (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotion"
+                ],
+                "summary": "Delete Promotion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/recommendations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Personalized product feed blending co-purchase similarity of bought and viewed products, recently viewed products and recent searches. Guests and cold-start users get popular products",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recommendation"
+                ],
+                "summary": "Get Recommendations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of products",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/recommend.RecommendationResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/search/admin/analytics/ctr": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Click-through rate per query in a time range, lowest first (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Search Click-Through Rate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (2006-01-02), default 7 days ago",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date inclusive (2006-01-02), default today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Only queries searched at least this many times",
+                        "name": "min_searches",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Max queries",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/search.QueryStat"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/search/admin/analytics/top": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Most frequent search queries in a time range, with result counts and click-through rate (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Top Search Queries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (2006-01-02), default 7 days ago",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date inclusive (2006-01-02), default today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Max queries",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/search.QueryStat"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/search/admin/analytics/zero-results": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queries that returned no products in a time range, ordered by how often they failed (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Zero-Result Search Queries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (2006-01-02), default 7 days ago",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date inclusive (2006-01-02), default today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Max queries",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/search.QueryStat"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/search/admin/rebuild": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Re-import all products from the database into the current search backend in the background (Admin only). Works while the server is running, unlike the rebuild script which cannot open a bleve index held by the server",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Rebuild Search Index",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/search/admin/stopwords": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List search stopwords (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Get Stopwords",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SearchStopword"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a word that is removed from queries before searching (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Create Stopword",
+                "parameters": [
+                    {
+                        "description": "Stopword",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/search.StopwordInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchStopword"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/search/admin/stopwords/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a stopword (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Delete Stopword",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stopword ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/search/admin/synonyms": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List search synonym groups (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Get Synonyms",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SearchSynonym"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a synonym group; a query containing any term also searches the others (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Create Synonym",
+                "parameters": [
+                    {
+                        "description": "Synonym Group",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/search.SynonymInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchSynonym"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/search/admin/synonyms/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the terms of a synonym group (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Update Synonym",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Synonym ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Synonym Group",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/search.SynonymInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchSynonym"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a synonym group (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Delete Synonym",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Synonym ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/search/admin/terms": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List pinned and blocked hot keywords (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Get Search Terms",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by action (pin/block)",
+                        "name": "action",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SearchTerm"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pin or block a hot keyword; an existing rule for the keyword is replaced (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Save Search Term",
+                "parameters": [
+                    {
+                        "description": "Search Term",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/search.SearchTermInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchTerm"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/search/admin/terms/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a pin or block rule (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Delete Search Term",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Search Term ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/search/click": {
+            "post": {
+                "description": "Record that a product was opened from a search result, linked by search_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Record Search Click",
+                "parameters": [
+                    {
+                        "description": "Click Event",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/search.SearchClickInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/search/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the user's recent search history",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Get Search History",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Max entries",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SearchHistory"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a keyword to the user's search history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Add Search History",
+                "parameters": [
+                    {
+                        "description": "Search Keyword",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/search.AddSearchHistoryInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Clear all search history for the user, or only the given keyword",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Clear Search History",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delete only this keyword",
+                        "name": "keyword",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/search/hot": {
+            "get": {
+                "description": "Get popular search keywords of the last 7 days, with pinned terms first and blocked terms removed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Hot Keywords",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Max keywords",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/search.HotKeyword"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/search/products": {
+            "get": {
+                "description": "Full-text search on on-shelf products with relevance ranking, highlights and facets",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Search Products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Keyword",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID (includes descendants)",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/search.ProductSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/search/suggest": {
+            "get": {
+                "description": "Autocomplete from product name prefixes and popular historical keywords",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Search Suggestions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Prefix",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Max suggestions",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/search.Suggestion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/admin/templates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all freight templates with their rules (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "Get Freight Templates",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.FreightTemplate"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new freight template (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "Create Freight Template",
+                "parameters": [
+                    {
+                        "description": "Freight Template Info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/shipping.FreightTemplateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.FreightTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/admin/templates/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a freight template and replace its rules (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "Update Freight Template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Freight Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Freight Template Info",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/shipping.FreightTemplateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FreightTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a freight template that is not referenced by any product (Admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "Delete Freight Template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Freight Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/avatar": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload an avatar image (max 2MB) and set it as the current user's avatar",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Upload"
+                ],
+                "summary": "Upload Avatar",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/storage.Image"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/chat": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a chat image (jpeg/png/gif/webp)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Upload"
+                ],
+                "summary": "Upload Chat Image",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/storage.Image"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/products": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload up to 9 product images (jpeg/png/gif/webp). Returns the original URL plus thumb/medium variants and their WebP versions (Admin only)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Upload"
+                ],
+                "summary": "Upload Product Images",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Image files (repeat the field for multiple files)",
+                        "name": "files",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.Image"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/reviews": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload up to 9 review images (jpeg/png/gif/webp) before submitting a review",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Upload"
+                ],
+                "summary": "Upload Review Images",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Image files (repeat the field for multiple files)",
+                        "name": "files",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/storage.Image"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the stored-value wallet of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Get Wallet",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Wallet"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/redeem": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Redeem a gift card code into the authenticated user's wallet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Redeem Gift Card",
+                "parameters": [
+                    {
+                        "description": "Gift Card Code",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/wallet.RedeemGiftCardInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the ledger entries of the authenticated user's wallet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Get Wallet Transactions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.LedgerEntry"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "admin.AdminLoginInput": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "admin.DashboardStats": {
+            "type": "object",
+            "properties": {
+                "top_viewed_products": {
+                    "description": "最近 7 天浏览量前 5 的商品，MongoDB 不可用时为空",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/browsing.ProductViews"
+                    }
+                },
+                "total_orders": {
+                    "type": "integer"
+                },
+                "total_products": {
+                    "type": "integer"
+                },
+                "total_sales": {
+                    "type": "number"
+                },
+                "total_users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "brand.BrandInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "logo": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "brand.BrandLandingResponse": {
+            "type": "object",
+            "properties": {
+                "brand": {
+                    "$ref": "#/definitions/models.Brand"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Product"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "pages": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "brand.BrandWithCount": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "description": {
+                    "description": "品牌介绍",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "logo": {
+                    "description": "品牌 Logo URL",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "品牌名称",
+                    "type": "string"
+                },
+                "product_count": {
+                    "type": "integer"
+                },
+                "sort_order": {
+                    "description": "排序权重，越小越靠前",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "browsing.HistoryItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "day": {
+                    "description": "浏览日期 (2006-01-02，服务器本地时区)",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "product": {
+                    "description": "商品已删除时为 null",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    ]
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "viewed_at": {
+                    "description": "当天最后一次浏览时间",
+                    "type": "string"
+                },
+                "views": {
+                    "description": "当天浏览次数",
+                    "type": "integer"
+                }
+            }
+        },
+        "browsing.HistoryListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/browsing.HistoryItem"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "browsing.ProductViews": {
+            "type": "object",
+            "properties": {
+                "cover_image": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "商品信息，商品已删除时为空",
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "views": {
+                    "description": "浏览次数",
+                    "type": "integer"
+                },
+                "visitors": {
+                    "description": "浏览过的用户数 (去重)",
+                    "type": "integer"
+                }
+            }
+        },
+        "cart.AddToCartInput": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "description": "商品 ID",
+                    "type": "integer"
+                },
+                "quantity": {
+                    "description": "数量，至少为 1",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "sku_id": {
+                    "description": "SKU ID (可选)",
+                    "type": "integer"
+                }
+            }
+        },
+        "catalog.Report": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "description": "是否已写入数据库，存在错误时不写入任何数据",
+                    "type": "boolean"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "error_rows": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/catalog.RowError"
+                    }
+                },
+                "products_created": {
+                    "type": "integer"
+                },
+                "products_updated": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/catalog.RowResult"
+                    }
+                },
+                "skus_created": {
+                    "type": "integer"
+                },
+                "skus_updated": {
+                    "type": "integer"
+                },
+                "total_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "catalog.RowError": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "sku_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "catalog.RowResult": {
+            "type": "object",
+            "properties": {
+                "product_action": {
+                    "description": "create / update",
+                    "type": "string"
+                },
+                "product_id": {
+                    "description": "已有商品的 ID；提交后新商品也会填充",
+                    "type": "integer"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "sku_action": {
+                    "description": "create / update",
+                    "type": "string"
+                },
+                "sku_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "category.AttributeInput": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "filterable": {
+                    "description": "是否可在商品列表中筛选",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "description": "enum 类型的可选值",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "sort_order": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "number",
+                        "enum",
+                        "bool"
+                    ]
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "category.CategoryInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "icon": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "父分类 ID，0 表示一级分类",
+                    "type": "integer"
+                },
+                "sort_order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "category.SortCategoryInput": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "sort_order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "chat.SendNotificationInput": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.CreateAddressInput": {
+            "type": "object",
+            "required": [
+                "city",
+                "detail_address",
+                "district",
+                "phone",
+                "province",
+                "receiver_name"
+            ],
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "detail_address": {
+                    "type": "string"
+                },
+                "district": {
+                    "type": "string"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "province": {
+                    "type": "string"
+                },
+                "receiver_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.LoginInput": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "description": "邮箱 (与 Username 二选一)",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "密码必填",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "用户名 (与 Email 二选一)",
+                    "type": "string"
+                }
+            }
+        },
+        "coupon.CouponTemplateInput": {
+            "type": "object",
+            "required": [
+                "end_at",
+                "name",
+                "start_at",
+                "type"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "end_at": {
+                    "type": "string"
+                },
+                "max_discount": {
+                    "type": "number"
+                },
+                "min_amount": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "per_user_limit": {
+                    "type": "integer"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "scope_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "scope_type": {
+                    "type": "string",
+                    "enum": [
+                        "all",
+                        "category",
+                        "product"
+                    ]
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "fixed",
+                        "percent",
+                        "threshold",
+                        "free_shipping"
+                    ]
+                },
+                "valid_days": {
+                    "type": "integer"
+                }
+            }
+        },
+        "favorite.FavoriteInput": {
+            "type": "object",
+            "required": [
+                "product_id"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "favorite.FavoriteListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Favorite"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "pages": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "favorite.FavoriteRankItem": {
+            "type": "object",
+            "properties": {
+                "cover_image": {
+                    "type": "string"
+                },
+                "favorites": {
+                    "description": "统计时间范围内新增的收藏数 (未指定范围时为当前收藏总数)",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "gorm.DeletedAt": {
+            "type": "object",
+            "properties": {
+                "time": {
+                    "type": "string"
+                },
+                "valid": {
+                    "description": "Valid is true if Time is not NULL",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.Address": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "description": "城市",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "detail_address": {
+                    "description": "详细地址",
+                    "type": "string"
+                },
+                "district": {
+                    "description": "区/县",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_default": {
+                    "description": "是否为默认地址",
+                    "type": "boolean"
+                },
+                "phone": {
+                    "description": "联系电话",
+                    "type": "string"
+                },
+                "province": {
+                    "description": "省份",
+                    "type": "string"
+                },
+                "receiver_name": {
+                    "description": "收货人姓名",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "关联的用户 ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Brand": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "description": {
+                    "description": "品牌介绍",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "logo": {
+                    "description": "品牌 Logo URL",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "品牌名称",
+                    "type": "string"
+                },
+                "sort_order": {
+                    "description": "排序权重，越小越靠前",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CartItem": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "description": "以下字段不入库，查询购物车时填充",
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "product": {
+                    "description": "预加载的商品信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    ]
+                },
+                "product_id": {
+                    "description": "关联的商品 ID",
+                    "type": "integer"
+                },
+                "quantity": {
+                    "description": "购买数量",
+                    "type": "integer"
+                },
+                "selected": {
+                    "description": "是否选中",
+                    "type": "boolean"
+                },
+                "sku_id": {
+                    "description": "关联的 SKU ID (如果商品有规格)",
+                    "type": "integer"
+                },
+                "unavailable_reason": {
+                    "description": "不可购买原因: product_removed, off_shelf, sku_removed",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "关联的用户 ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Category": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "description": "子分类 (分类树接口填充)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Category"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "icon": {
+                    "description": "分类图标",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "level": {
+                    "description": "层级: 1, 2, 3",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "分类名称",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "父分类 ID",
+                    "type": "integer"
+                },
+                "sort_order": {
+                    "description": "排序权重",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CategoryAttribute": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "description": "所属分类 ID",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "filterable": {
+                    "description": "是否可在商品列表中筛选并返回分面",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "属性名称",
+                    "type": "string"
+                },
+                "options": {
+                    "description": "枚举可选值 (enum 类型)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "required": {
+                    "description": "商品是否必须填写",
+                    "type": "boolean"
+                },
+                "sort_order": {
+                    "description": "排序权重，越小越靠前",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "属性类型: text, number, enum, bool",
+                    "type": "string"
+                },
+                "unit": {
+                    "description": "单位 (如 GB、英寸、天)",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ChatMessage": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_read": {
+                    "type": "boolean"
+                },
+                "receiver_id": {
+                    "description": "接收者 ID",
+                    "type": "integer"
+                },
+                "sender_id": {
+                    "description": "发送者 ID (如果是客服，则是 AdminUser ID；如果是用户，则是 User ID)",
+                    "type": "integer"
+                },
+                "sender_type": {
+                    "description": "user, admin",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "text, image",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CouponTemplate": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "优惠金额 (fixed/threshold)",
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "description": {
+                    "description": "使用说明",
+                    "type": "string"
+                },
+                "end_at": {
+                    "description": "领取/使用结束时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issued_count": {
+                    "description": "已领取数量",
+                    "type": "integer"
+                },
+                "max_discount": {
+                    "description": "最高优惠金额 (percent)，0 表示不限",
+                    "type": "number"
+                },
+                "min_amount": {
+                    "description": "使用门槛，适用商品金额满 MinAmount 才可使用",
+                    "type": "number"
+                },
+                "name": {
+                    "description": "优惠券名称",
+                    "type": "string"
+                },
+                "per_user_limit": {
+                    "description": "每人限领张数",
+                    "type": "integer"
+                },
+                "rate": {
+                    "description": "折扣率 (percent)，如 0.85 表示 85 折",
+                    "type": "number"
+                },
+                "scope_ids": {
+                    "description": "适用的分类 ID 或商品 ID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "scope_type": {
+                    "description": "适用范围: all, category, product",
+                    "type": "string"
+                },
+                "start_at": {
+                    "description": "领取/使用开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态: 1-启用, 0-停用 (停用后不可领取，已领取的券不受影响)",
+                    "type": "integer"
+                },
+                "total_count": {
+                    "description": "发行总量，0 表示不限",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "类型: fixed, percent, threshold, free_shipping",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "valid_days": {
+                    "description": "领取后有效天数，0 表示以 EndAt 为准",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Favorite": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "收藏时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "product": {
+                    "description": "预加载的商品信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    ]
+                },
+                "product_id": {
+                    "description": "商品 ID",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "description": "用户 ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.FreightRule": {
+            "type": "object",
+            "properties": {
+                "additional_fee": {
+                    "description": "每续件/续重运费",
+                    "type": "number"
+                },
+                "additional_unit": {
+                    "description": "续件数/续重 (kg)",
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "first_fee": {
+                    "description": "首件/首重运费",
+                    "type": "number"
+                },
+                "first_unit": {
+                    "description": "首件数/首重 (kg)",
+                    "type": "number"
+                },
+                "free_threshold": {
+                    "description": "满额包邮，0 表示不包邮",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "regions": {
+                    "description": "适用地区，如 [\"北京市\", \"广东省/深圳市\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "template_id": {
+                    "description": "关联的运费模板 ID",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FreightTemplate": {
+            "type": "object",
+            "properties": {
+                "charge_type": {
+                    "description": "计费方式: item, weight",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_default": {
+                    "description": "是否为默认模板",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "模板名称",
+                    "type": "string"
+                },
+                "rules": {
+                    "description": "按地区的运费规则",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FreightRule"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GiftCard": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "剩余金额，兑换后为 0",
+                    "type": "number"
+                },
+                "batch_no": {
+                    "description": "发行批次号",
+                    "type": "string"
+                },
+                "code": {
+                    "description": "卡密",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "expire_at": {
+                    "description": "过期时间",
+                    "type": "string"
+                },
+                "face_value": {
+                    "description": "面值",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "redeemed_at": {
+                    "description": "兑换时间",
+                    "type": "string"
+                },
+                "redeemed_by": {
+                    "description": "兑换用户 ID",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态: 0-未兑换, 1-已兑换, 2-已作废",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LedgerEntry": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "description": "记账账户",
+                    "type": "string"
+                },
+                "amount": {
+                    "description": "金额，始终为正数",
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "direction": {
+                    "description": "方向: debit, credit",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "description": "关联的订单 ID",
+                    "type": "integer"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "txn_no": {
+                    "description": "业务流水号，同一笔业务的借贷分录相同",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "业务类型: gift_card_redeem, order_pay, order_refund",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Notification": {
+            "type": "object",
+            "properties": {
+                "User": {
+                    "description": "添加关联关系",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    ]
+                },
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_read": {
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Order": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "收货地址快照 (简化处理，实际应复制地址信息)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Address"
+                        }
+                    ]
+                },
+                "address_id": {
+                    "description": "收货地址 ID",
+                    "type": "integer"
+                },
+                "coupon_discount": {
+                    "description": "优惠券抵扣金额",
+                    "type": "number"
+                },
+                "coupon_id": {
+                    "description": "使用的用户优惠券 ID (UserCoupon.ID)",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "Override for custom formatting if needed, but here just for key name",
+                    "type": "string"
+                },
+                "gateway_amount": {
+                    "description": "第三方支付金额",
+                    "type": "number"
+                },
+                "id": {
+                    "description": "Override ID to ensure lowercase JSON",
+                    "type": "integer"
+                },
+                "items": {
+                    "description": "订单包含的商品项",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderItem"
+                    }
+                },
+                "member_discount": {
+                    "description": "会员等级折扣金额",
+                    "type": "number"
+                },
+                "order_no": {
+                    "description": "订单编号，唯一",
+                    "type": "string"
+                },
+                "payment_method": {
+                    "description": "支付方式: gateway, wallet, mixed (钱包 + 第三方支付)",
+                    "type": "string"
+                },
+                "points_discount": {
+                    "description": "积分抵扣金额",
+                    "type": "number"
+                },
+                "points_used": {
+                    "description": "抵扣使用的积分",
+                    "type": "integer"
+                },
+                "product_amount": {
+                    "description": "商品总金额 (优惠前)",
+                    "type": "number"
+                },
+                "promotion_discount": {
+                    "description": "自动促销优惠金额",
+                    "type": "number"
+                },
+                "shipping_fee": {
+                    "description": "运费",
+                    "type": "number"
+                },
+                "status": {
+                    "description": "订单状态: 0-待支付, 1-待发货, 2-待收货, 3-待评价, 4-已完成, 5-售后中, 6-已退款, -1-已取消",
+                    "type": "integer"
+                },
+                "total_amount": {
+                    "description": "订单实付金额",
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "关联的用户 ID",
+                    "type": "integer"
+                },
+                "wallet_amount": {
+                    "description": "钱包支付金额",
+                    "type": "number"
+                }
+            }
+        },
+        "models.OrderItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "discount_amount": {
+                    "description": "分摊到该商品项的促销优惠",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "description": "关联的订单 ID",
+                    "type": "integer"
+                },
+                "price": {
+                    "description": "购买时的单价",
+                    "type": "number"
+                },
+                "product_id": {
+                    "description": "商品 ID",
+                    "type": "integer"
+                },
+                "product_image": {
+                    "description": "商品图片 (快照)",
+                    "type": "string"
+                },
+                "product_name": {
+                    "description": "商品名称 (快照)",
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "购买数量",
+                    "type": "integer"
+                },
+                "sku_id": {
+                    "description": "SKU ID",
+                    "type": "integer"
+                },
+                "sku_name": {
+                    "description": "SKU 名称 (快照)",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PointsAccount": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "可用积分",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "cumulative_spend": {
+                    "description": "累计消费金额，用于计算会员等级",
+                    "type": "number"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "total_earned": {
+                    "description": "累计获得积分",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "关联的用户 ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PointsTransaction": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "变动后余额",
+                    "type": "integer"
+                },
+                "change": {
+                    "description": "变动积分，正数为增加，负数为扣减",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "description": "关联的订单 ID",
+                    "type": "integer"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "流水类型: earn_order, earn_review, spend_order, return_order, reverse_order, admin_adjust",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "关联的用户 ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PriceAlert": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "notified_at": {
+                    "description": "最近一次提醒时间",
+                    "type": "string"
+                },
+                "product_id": {
+                    "description": "商品 ID",
+                    "type": "integer"
+                },
+                "target_price": {
+                    "description": "提醒价格，默认为订阅时的最低价",
+                    "type": "number"
+                },
+                "user_id": {
+                    "description": "用户 ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Product": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "description": "分类属性值",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductAttributeValue"
+                    }
+                },
+                "brand_id": {
+                    "description": "品牌 ID，0 表示无品牌",
+                    "type": "integer"
+                },
+                "category_id": {
+                    "description": "分类 ID",
+                    "type": "integer"
+                },
+                "cover_image": {
+                    "description": "封面图片 URL",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "description": {
+                    "description": "商品描述",
+                    "type": "string"
+                },
+                "favorite_count": {
+                    "description": "收藏数，GORM 只读 (保存商品时不会覆盖)，由收藏接口以原子 SQL 增减",
+                    "type": "integer"
+                },
+                "freight_template_id": {
+                    "description": "运费模板 ID，0 表示使用默认模板",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "images": {
+                    "description": "商品轮播图列表 (PostgreSQL 数组类型)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "商品名称",
+                    "type": "string"
+                },
+                "price": {
+                    "description": "商品基础价格",
+                    "type": "number"
+                },
+                "publish_at": {
+                    "description": "定时上下架，由调度器到点执行后清空",
+                    "type": "string"
+                },
+                "reviews": {
+                    "description": "关联的评价列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Review"
+                    }
+                },
+                "skus": {
+                    "description": "关联的 SKU 列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductSKU"
+                    }
+                },
+                "specs": {
+                    "description": "以下关联由商品详情接口预加载",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductSpec"
+                    }
+                },
+                "status": {
+                    "description": "商品状态: 1-上架, 0-下架, 2-草稿",
+                    "type": "integer"
+                },
+                "stock": {
+                    "description": "总库存",
+                    "type": "integer"
+                },
+                "unpublish_at": {
+                    "description": "定时下架时间",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "单件重量 (kg)，按重量计费的运费模板使用",
+                    "type": "number"
+                }
+            }
+        },
+        "models.ProductAttributeValue": {
+            "type": "object",
+            "properties": {
+                "attribute": {
+                    "description": "预加载的属性模板",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CategoryAttribute"
+                        }
+                    ]
+                },
+                "attribute_id": {
+                    "description": "关联的属性模板 ID",
+                    "type": "integer"
+                },
+                "product_id": {
+                    "description": "关联的商品 ID",
+                    "type": "integer"
+                },
+                "value": {
+                    "description": "属性值",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProductSKU": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "外部 SKU 编码，批量导入时按编码新增或更新，未删除的 SKU 之间唯一",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image": {
+                    "description": "SKU 图片",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "SKU 名称 (如 \"红色 XL\")",
+                    "type": "string"
+                },
+                "price": {
+                    "description": "SKU 价格",
+                    "type": "number"
+                },
+                "product_id": {
+                    "description": "关联的商品 ID",
+                    "type": "integer"
+                },
+                "spec_key": {
+                    "description": "规格组合键: 规格值 ID 升序以逗号连接 (如 \"3,7\")，由规格矩阵生成；手动创建的 SKU 为空",
+                    "type": "string"
+                },
+                "specs": {
+                    "description": "规格详情 JSON 字符串",
+                    "type": "string"
+                },
+                "stock": {
+                    "description": "SKU 库存",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProductSpec": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "规格名称",
+                    "type": "string"
+                },
+                "product_id": {
+                    "description": "关联的商品 ID",
+                    "type": "integer"
+                },
+                "sort_order": {
+                    "description": "排序权重，越小越靠前",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "values": {
+                    "description": "规格值列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductSpecValue"
+                    }
+                }
+            }
+        },
+        "models.ProductSpecValue": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image": {
+                    "description": "规格值图片 (如颜色对应的商品图)",
+                    "type": "string"
+                },
+                "sort_order": {
+                    "description": "排序权重，越小越靠前",
+                    "type": "integer"
+                },
+                "spec_id": {
+                    "description": "关联的规格属性 ID",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "value": {
+                    "description": "规格值",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Promotion": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "end_at": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "活动名称",
+                    "type": "string"
+                },
+                "priority": {
+                    "description": "优先级，数值越大越先计算",
+                    "type": "integer"
+                },
+                "rules": {
+                    "description": "规则详情 JSON 字符串，结构见 promotion.Rule",
+                    "type": "string"
+                },
+                "scope_ids": {
+                    "description": "适用的分类 ID 或商品 ID 列表；bundle 类型为套装内的商品 ID",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "scope_type": {
+                    "description": "适用范围: all, category, product (与优惠券一致)",
+                    "type": "string"
+                },
+                "start_at": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态: 1-启用, 0-停用",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "类型: buy_x_get_y, tiered, category_discount, bundle",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Review": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "评价内容",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "images": {
+                    "description": "评价图片(JSON array string)",
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "description": "评分 1-5",
+                    "type": "integer"
+                },
+                "reply": {
+                    "description": "商家回复",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "0:隐藏 1:显示 (默认1)",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user": {
+                    "description": "关联用户信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    ]
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SearchHistory": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "keyword": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SearchStopword": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "word": {
+                    "description": "停用词 (小写)",
+                    "type": "string"
+                }
+            }
+        },
+        "models.SearchSynonym": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "terms": {
+                    "description": "同义词 (小写)，如 [\"iphone\", \"苹果手机\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SearchTerm": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "干预方式: pin-置顶, block-屏蔽",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "keyword": {
+                    "description": "关键词 (小写)",
+                    "type": "string"
+                },
+                "sort_order": {
+                    "description": "置顶词排序，数值越小越靠前",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.User": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "description": "用户头像 URL",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "email": {
+                    "description": "邮箱，必须唯一且不能为空",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "description": "用户角色 (user/admin)，默认为 user",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "username": {
+                    "description": "用户名，必须唯一且不能为空",
+                    "type": "string"
+                }
+            }
+        },
+        "models.UserCoupon": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "expire_at": {
+                    "description": "过期时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "description": "使用该券的订单 ID",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态: 0-未使用, 1-已使用",
+                    "type": "integer"
+                },
+                "template": {
+                    "description": "预加载的模板信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.CouponTemplate"
+                        }
+                    ]
+                },
+                "template_id": {
+                    "description": "关联的模板 ID",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "used_at": {
+                    "description": "使用时间",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "关联的用户 ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Wallet": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "可用余额",
+                    "type": "number"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "关联的用户 ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "order.CreateOrderInput": {
+            "type": "object",
+            "required": [
+                "address_id"
+            ],
+            "properties": {
+                "address_id": {
+                    "description": "收货地址 ID",
+                    "type": "integer"
+                },
+                "coupon_id": {
+                    "description": "使用的用户优惠券 ID (可选)",
+                    "type": "integer"
+                },
+                "points": {
+                    "description": "使用的积分 (可选)",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "order.PayOrderInput": {
+            "type": "object",
+            "properties": {
+                "use_wallet": {
+                    "description": "是否使用钱包余额，余额不足部分走第三方支付",
+                    "type": "boolean"
+                }
+            }
+        },
+        "order.PreviewOrderInput": {
+            "type": "object",
+            "properties": {
+                "address_id": {
+                    "description": "收货地址 ID (可选，默认使用默认地址)",
+                    "type": "integer"
+                },
+                "coupon_id": {
+                    "description": "使用的用户优惠券 ID (可选)",
+                    "type": "integer"
+                },
+                "points": {
+                    "description": "使用的积分 (可选)",
+                    "type": "integer"
+                }
+            }
+        },
+        "order.ReviewOrderInput": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer"
+                }
+            }
+        },
+        "order.UpdateOrderStatusInput": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "points.AdjustPointsInput": {
+            "type": "object",
+            "required": [
+                "change",
+                "remark",
+                "user_id"
+            ],
+            "properties": {
+                "change": {
+                    "description": "正数为增加，负数为扣减",
+                    "type": "integer"
+                },
+                "remark": {
+                    "type": "string"
+                },
                 "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "controllers.CreateAddressInput": {
+        "points.Level": {
+            "type": "object",
+            "properties": {
+                "discount_rate": {
+                    "description": "会员折扣，1 表示无折扣",
+                    "type": "number"
+                },
+                "free_shipping": {
+                    "description": "是否包邮",
+                    "type": "boolean"
+                },
+                "level": {
+                    "description": "等级",
+                    "type": "integer"
+                },
+                "min_spend": {
+                    "description": "达到该等级所需的累计消费",
+                    "type": "number"
+                },
+                "name": {
+                    "description": "等级名称",
+                    "type": "string"
+                }
+            }
+        },
+        "pricing.Line": {
+            "type": "object",
+            "properties": {
+                "cart_item_id": {
+                    "type": "integer"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "discount": {
+                    "description": "分摊到该行的促销优惠",
+                    "type": "number"
+                },
+                "final_amount": {
+                    "description": "优惠后金额",
+                    "type": "number"
+                },
+                "original_amount": {
+                    "description": "原价金额",
+                    "type": "number"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "product_image": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "promotion_ids": {
+                    "description": "命中的促销 ID",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "sku_id": {
+                    "type": "integer"
+                },
+                "sku_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "pricing.Quote": {
+            "type": "object",
+            "properties": {
+                "coupon_discount": {
+                    "description": "优惠券抵扣",
+                    "type": "number"
+                },
+                "coupon_id": {
+                    "description": "使用的用户优惠券 ID",
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pricing.Line"
+                    }
+                },
+                "member_discount": {
+                    "description": "会员折扣优惠",
+                    "type": "number"
+                },
+                "member_level": {
+                    "description": "当前会员等级",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/points.Level"
+                        }
+                    ]
+                },
+                "points_discount": {
+                    "description": "积分抵扣",
+                    "type": "number"
+                },
+                "points_used": {
+                    "description": "使用的积分",
+                    "type": "integer"
+                },
+                "product_amount": {
+                    "description": "商品原价总额",
+                    "type": "number"
+                },
+                "promotion_discount": {
+                    "description": "促销优惠",
+                    "type": "number"
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/promotion.Applied"
+                    }
+                },
+                "shipping_fee": {
+                    "description": "运费",
+                    "type": "number"
+                },
+                "total_amount": {
+                    "description": "应付金额",
+                    "type": "number"
+                }
+            }
+        },
+        "product.AttributeFacet": {
+            "type": "object",
+            "properties": {
+                "attribute_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product.AttributeValueCount"
+                    }
+                }
+            }
+        },
+        "product.AttributeValueCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "product.BrandFacet": {
+            "type": "object",
+            "properties": {
+                "brand_id": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "product.CalendarDay": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product.ScheduleEvent"
+                    }
+                }
+            }
+        },
+        "product.CategoryFacet": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "product.CreateProductInput": {
             "type": "object",
             "required": [
-                "city",
-                "detail_address",
-                "district",
-                "phone",
-                "province",
-                "receiver_name"
+                "category_id",
+                "name",
+                "price",
+                "stock"
             ],
             "properties": {
-                "city": {
+                "attributes": {
+                    "description": "分类属性值，key 为属性模板 ID；更新时不传则保持不变",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "brand_id": {
+                    "description": "品牌 ID，0 表示无品牌",
+                    "type": "integer"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "cover_image": {
                     "type": "string"
                 },
-                "detail_address": {
+                "description": {
                     "type": "string"
                 },
-                "district": {
+                "freight_template_id": {
+                    "description": "运费模板 ID，0 表示使用默认模板",
+                    "type": "integer"
+                },
+                "images": {
+                    "description": "商品轮播图，更新时不传则保持不变",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
                     "type": "string"
                 },
-                "is_default": {
+                "price": {
+                    "type": "number"
+                },
+                "publish_at": {
+                    "description": "定时上架时间，更新时不传则保持不变",
+                    "type": "string"
+                },
+                "skus": {
+                    "description": "商品 SKU 列表，更新时不传则保持不变",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product.ProductSKUInput"
+                    }
+                },
+                "status": {
+                    "description": "1-上架, 0-下架, 2-草稿；创建时默认上架 (设置了未来的定时上架时间则默认草稿)，更新时不传则保持不变",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1,
+                        2
+                    ]
+                },
+                "stock": {
+                    "type": "integer"
+                },
+                "unpublish_at": {
+                    "description": "定时下架时间，更新时不传则保持不变",
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "单件重量 (kg)",
+                    "type": "number"
+                }
+            }
+        },
+        "product.GenerateSKUsInput": {
+            "type": "object",
+            "properties": {
+                "price": {
+                    "description": "新 SKU 的价格，默认为商品价格",
+                    "type": "number"
+                },
+                "remove_stale": {
+                    "description": "是否删除不属于任何规格组合的 SKU",
                     "type": "boolean"
                 },
-                "phone": {
-                    "type": "string"
+                "stock": {
+                    "description": "新 SKU 的库存",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "product.GenerateSKUsResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "新增的 SKU 数",
+                    "type": "integer"
+                },
+                "removed": {
+                    "description": "删除的 SKU 数",
+                    "type": "integer"
+                },
+                "skus": {
+                    "description": "生成后的全部 SKU",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductSKU"
+                    }
+                }
+            }
+        },
+        "product.PriceAlertInput": {
+            "type": "object",
+            "required": [
+                "product_id"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "target_price": {
+                    "description": "提醒价格，为 0 时使用当前最低价",
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "product.PriceAlertItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current_price": {
+                    "description": "商品当前最低价",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "notified_at": {
+                    "description": "最近一次提醒时间",
+                    "type": "string"
+                },
+                "product": {
+                    "$ref": "#/definitions/models.Product"
+                },
+                "product_id": {
+                    "description": "商品 ID",
+                    "type": "integer"
+                },
+                "target_price": {
+                    "description": "提醒价格，默认为订阅时的最低价",
+                    "type": "number"
+                },
+                "user_id": {
+                    "description": "用户 ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "product.PriceBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
                 },
-                "province": {
-                    "type": "string"
+                "max": {
+                    "description": "0 表示不设上限",
+                    "type": "number"
                 },
-                "receiver_name": {
-                    "type": "string"
+                "min": {
+                    "type": "number"
                 }
             }
         },
-        "controllers.LoginInput": {
+        "product.PriceHistoryItem": {
             "type": "object",
-            "required": [
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "description": "邮箱 (与 Username 二选一)",
+                "created_at": {
                     "type": "string"
                 },
-                "password": {
-                    "description": "密码必填",
+                "id": {
+                    "type": "integer"
+                },
+                "new_price": {
+                    "description": "变动后价格",
+                    "type": "number"
+                },
+                "old_price": {
+                    "description": "变动前价格",
+                    "type": "number"
+                },
+                "product_id": {
+                    "description": "商品 ID",
+                    "type": "integer"
+                },
+                "sku_id": {
+                    "description": "SKU ID，0 表示商品基础价格",
+                    "type": "integer"
+                },
+                "sku_name": {
                     "type": "string"
                 },
-                "username": {
-                    "description": "用户名 (与 Email 二选一)",
+                "source": {
+                    "description": "变动来源: admin, import",
                     "type": "string"
                 }
             }
         },
-        "gorm.DeletedAt": {
+        "product.PriceHistoryResponse": {
             "type": "object",
             "properties": {
-                "time": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product.PriceHistoryItem"
+                    }
                 },
-                "valid": {
-                    "description": "Valid is true if Time is not NULL",
-                    "type": "boolean"
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Address": {
+        "product.ProductDetailResponse": {
             "type": "object",
             "properties": {
-                "city": {
-                    "description": "城市",
+                "attributes": {
+                    "description": "分类属性值",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductAttributeValue"
+                    }
+                },
+                "brand": {
+                    "$ref": "#/definitions/models.Brand"
+                },
+                "brand_id": {
+                    "description": "品牌 ID，0 表示无品牌",
+                    "type": "integer"
+                },
+                "category_id": {
+                    "description": "分类 ID",
+                    "type": "integer"
+                },
+                "cover_image": {
+                    "description": "封面图片 URL",
                     "type": "string"
                 },
                 "createdAt": {
@@ -1929,257 +9416,505 @@ const docTemplate = `{
                 "deletedAt": {
                     "$ref": "#/definitions/gorm.DeletedAt"
                 },
-                "detail_address": {
-                    "description": "详细地址",
+                "description": {
+                    "description": "商品描述",
                     "type": "string"
                 },
-                "district": {
-                    "description": "区/县",
-                    "type": "string"
+                "favorite_count": {
+                    "description": "收藏数，GORM 只读 (保存商品时不会覆盖)，由收藏接口以原子 SQL 增减",
+                    "type": "integer"
+                },
+                "freight_template_id": {
+                    "description": "运费模板 ID，0 表示使用默认模板",
+                    "type": "integer"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "is_default": {
-                    "description": "是否为默认地址",
+                "images": {
+                    "description": "商品轮播图列表 (PostgreSQL 数组类型)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "is_favorited": {
+                    "description": "当前用户是否已收藏，未登录时为 false",
                     "type": "boolean"
                 },
-                "phone": {
-                    "description": "联系电话",
+                "name": {
+                    "description": "商品名称",
                     "type": "string"
                 },
-                "province": {
-                    "description": "省份",
+                "price": {
+                    "description": "商品基础价格",
+                    "type": "number"
+                },
+                "publish_at": {
+                    "description": "定时上下架，由调度器到点执行后清空",
                     "type": "string"
                 },
-                "receiver_name": {
-                    "description": "收货人姓名",
+                "reviews": {
+                    "description": "关联的评价列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Review"
+                    }
+                },
+                "sku_map": {
+                    "description": "规格组合键 (规格值 ID 升序以逗号连接) -\u003e SKU 状态，不存在的组合不可选",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/spec.SKUState"
+                    }
+                },
+                "skus": {
+                    "description": "关联的 SKU 列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductSKU"
+                    }
+                },
+                "specs": {
+                    "description": "以下关联由商品详情接口预加载",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductSpec"
+                    }
+                },
+                "status": {
+                    "description": "商品状态: 1-上架, 0-下架, 2-草稿",
+                    "type": "integer"
+                },
+                "stock": {
+                    "description": "总库存",
+                    "type": "integer"
+                },
+                "unpublish_at": {
+                    "description": "定时下架时间",
                     "type": "string"
                 },
                 "updatedAt": {
                     "type": "string"
                 },
-                "user_id": {
-                    "description": "关联的用户 ID",
-                    "type": "integer"
+                "weight": {
+                    "description": "单件重量 (kg)，按重量计费的运费模板使用",
+                    "type": "number"
                 }
             }
         },
-        "models.CartItem": {
+        "product.ProductFacets": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "type": "string"
+                "attributes": {
+                    "description": "按分类筛选时返回该分类 (含祖先分类) 可筛选属性的分面",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product.AttributeFacet"
+                    }
                 },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
+                "brands": {
+                    "description": "无品牌的商品不计入",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product.BrandFacet"
+                    }
                 },
-                "id": {
-                    "type": "integer"
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product.CategoryFacet"
+                    }
                 },
-                "product": {
-                    "description": "预加载的商品信息",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.Product"
-                        }
-                    ]
+                "price_buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product.PriceBucket"
+                    }
+                }
+            }
+        },
+        "product.ProductImagesInput": {
+            "type": "object",
+            "required": [
+                "images"
+            ],
+            "properties": {
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "product.ProductListResponse": {
+            "type": "object",
+            "properties": {
+                "facets": {
+                    "$ref": "#/definitions/product.ProductFacets"
                 },
-                "product_id": {
-                    "description": "关联的商品 ID",
-                    "type": "integer"
+                "highlights": {
+                    "description": "关键词搜索时返回命中商品的高亮片段，key 为商品 ID",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/search.Highlight"
+                    }
                 },
-                "quantity": {
-                    "description": "购买数量",
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Product"
+                    }
+                },
+                "page": {
                     "type": "integer"
                 },
-                "selected": {
-                    "description": "是否选中",
-                    "type": "boolean"
+                "page_size": {
+                    "type": "integer"
                 },
-                "sku_id": {
-                    "description": "关联的 SKU ID (如果商品有规格)",
+                "pages": {
                     "type": "integer"
                 },
-                "updatedAt": {
+                "search_id": {
+                    "description": "关键词搜索时返回搜索日志 ID，上报点击事件时使用",
                     "type": "string"
                 },
-                "user_id": {
-                    "description": "关联的用户 ID",
+                "total": {
                     "type": "integer"
+                },
+                "truncated": {
+                    "description": "搜索后端命中数超过 maxSearchHits 时为 true，此时总数和分面只统计相关度最高的 maxSearchHits 个命中",
+                    "type": "boolean"
                 }
             }
         },
-        "models.ChatMessage": {
+        "product.ProductPatchInput": {
             "type": "object",
             "properties": {
-                "content": {
+                "attributes": {
+                    "description": "传入时整体替换分类属性值",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "brand_id": {
+                    "description": "0 表示取消品牌",
+                    "type": "integer"
+                },
+                "category_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "cover_image": {
                     "type": "string"
                 },
-                "createdAt": {
+                "description": {
+                    "type": "string"
+                },
+                "freight_template_id": {
+                    "type": "integer"
+                },
+                "images": {
+                    "description": "传入时整体替换轮播图",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "price": {
+                    "type": "number"
+                },
+                "publish_at": {
+                    "description": "定时上架时间，清除请使用 PUT /products/:id/schedule",
+                    "type": "string"
+                },
+                "skus": {
+                    "description": "传入时按 ID 同步 SKU，规则同 PUT",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product.ProductSKUInput"
+                    }
+                },
+                "status": {
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1,
+                        2
+                    ]
+                },
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "unpublish_at": {
+                    "description": "定时下架时间",
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "product.ProductSKUInput": {
+            "type": "object",
+            "required": [
+                "name",
+                "price",
+                "specs"
+            ],
+            "properties": {
+                "code": {
+                    "description": "外部 SKU 编码，批量导入时用于匹配；更新已有 SKU 时为空表示不修改",
                     "type": "string"
                 },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
-                },
                 "id": {
+                    "description": "更新商品时传已有 SKU 的 ID，0 表示新增",
                     "type": "integer"
                 },
-                "is_read": {
-                    "type": "boolean"
+                "image": {
+                    "description": "SKU 图片",
+                    "type": "string"
                 },
-                "receiver_id": {
-                    "description": "接收者 ID",
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "sender_id": {
-                    "description": "发送者 ID (如果是客服，则是 AdminUser ID；如果是用户，则是 User ID)",
-                    "type": "integer"
+                "price": {
+                    "type": "number"
                 },
-                "sender_type": {
-                    "description": "user, admin",
+                "specs": {
+                    "description": "JSON string",
                     "type": "string"
                 },
-                "type": {
-                    "description": "text, image",
+                "stock": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "product.ProductScheduleInput": {
+            "type": "object",
+            "properties": {
+                "publish_at": {
+                    "description": "定时上架时间",
                     "type": "string"
                 },
-                "updatedAt": {
+                "unpublish_at": {
+                    "description": "定时下架时间",
                     "type": "string"
                 }
             }
         },
-        "models.Notification": {
+        "product.ProductSpecsInput": {
             "type": "object",
             "properties": {
-                "User": {
-                    "description": "添加关联关系",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.User"
-                        }
+                "specs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product.SpecInput"
+                    }
+                }
+            }
+        },
+        "product.ProductStatusInput": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "description": "1-上架, 0-下架, 2-草稿",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1,
+                        2
                     ]
+                }
+            }
+        },
+        "product.ScheduleCalendarResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/product.CalendarDay"
+                    }
                 },
-                "content": {
+                "from": {
                     "type": "string"
                 },
-                "created_at": {
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "product.ScheduleEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "publish 或 unpublish",
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "at": {
+                    "type": "string"
                 },
-                "is_read": {
-                    "type": "boolean"
+                "cover_image": {
+                    "type": "string"
                 },
-                "title": {
+                "name": {
                     "type": "string"
                 },
-                "user_id": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "商品当前状态",
                     "type": "integer"
                 }
             }
         },
-        "models.Order": {
+        "product.SpecInput": {
             "type": "object",
+            "required": [
+                "name",
+                "values"
+            ],
             "properties": {
-                "address": {
-                    "description": "收货地址快照 (简化处理，实际应复制地址信息)",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.Address"
-                        }
-                    ]
-                },
-                "address_id": {
-                    "description": "收货地址 ID",
-                    "type": "integer"
-                },
-                "created_at": {
-                    "description": "Override for custom formatting if needed, but here just for key name",
+                "name": {
                     "type": "string"
                 },
-                "id": {
-                    "description": "Override ID to ensure lowercase JSON",
-                    "type": "integer"
-                },
-                "items": {
-                    "description": "订单包含的商品项",
+                "values": {
                     "type": "array",
+                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/models.OrderItem"
+                        "$ref": "#/definitions/product.SpecValueInput"
                     }
-                },
-                "order_no": {
-                    "description": "订单编号，唯一",
+                }
+            }
+        },
+        "product.SpecValueInput": {
+            "type": "object",
+            "required": [
+                "value"
+            ],
+            "properties": {
+                "image": {
                     "type": "string"
                 },
-                "status": {
-                    "description": "订单状态: 0-待支付, 1-待发货, 2-待收货, 3-待评价(已完成), 4-已取消, 5-售后中",
-                    "type": "integer"
-                },
-                "total_amount": {
-                    "description": "订单总金额",
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "promotion.Applied": {
+            "type": "object",
+            "properties": {
+                "discount": {
                     "type": "number"
                 },
-                "updated_at": {
+                "name": {
                     "type": "string"
                 },
-                "user_id": {
-                    "description": "关联的用户 ID",
+                "promotion_id": {
                     "type": "integer"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "models.OrderItem": {
+        "promotion.PromotionInput": {
             "type": "object",
+            "required": [
+                "end_at",
+                "name",
+                "rules",
+                "start_at",
+                "type"
+            ],
             "properties": {
-                "created_at": {
+                "end_at": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "order_id": {
-                    "description": "关联的订单 ID",
+                "priority": {
                     "type": "integer"
                 },
-                "price": {
-                    "description": "购买时的单价",
-                    "type": "number"
+                "rules": {
+                    "description": "JSON string，结构见 promotion.Rule",
+                    "type": "string"
                 },
-                "product_id": {
-                    "description": "商品 ID",
-                    "type": "integer"
+                "scope_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "product_image": {
-                    "description": "商品图片 (快照)",
-                    "type": "string"
+                "scope_type": {
+                    "type": "string",
+                    "enum": [
+                        "all",
+                        "category",
+                        "product"
+                    ]
                 },
-                "product_name": {
-                    "description": "商品名称 (快照)",
+                "start_at": {
                     "type": "string"
                 },
-                "quantity": {
-                    "description": "购买数量",
-                    "type": "integer"
-                },
-                "sku_id": {
-                    "description": "SKU ID",
+                "status": {
                     "type": "integer"
                 },
-                "sku_name": {
-                    "description": "SKU 名称 (快照)",
-                    "type": "string"
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "buy_x_get_y",
+                        "tiered",
+                        "category_discount",
+                        "bundle"
+                    ]
+                }
+            }
+        },
+        "recommend.RecommendationResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recommend.RecommendedProduct"
+                    }
                 },
-                "updated_at": {
-                    "type": "string"
+                "personalized": {
+                    "description": "是否基于用户的购买、浏览或搜索记录；为 false 时全部为热门商品",
+                    "type": "boolean"
                 }
             }
         },
-        "models.Product": {
+        "recommend.RecommendedProduct": {
             "type": "object",
             "properties": {
+                "attributes": {
+                    "description": "分类属性值",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductAttributeValue"
+                    }
+                },
+                "brand_id": {
+                    "description": "品牌 ID，0 表示无品牌",
+                    "type": "integer"
+                },
                 "category_id": {
                     "description": "分类 ID",
                     "type": "integer"
@@ -2198,6 +9933,14 @@ const docTemplate = `{
                     "description": "商品描述",
                     "type": "string"
                 },
+                "favorite_count": {
+                    "description": "收藏数，GORM 只读 (保存商品时不会覆盖)，由收藏接口以原子 SQL 增减",
+                    "type": "integer"
+                },
+                "freight_template_id": {
+                    "description": "运费模板 ID，0 表示使用默认模板",
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -2216,276 +9959,440 @@ const docTemplate = `{
                     "description": "商品基础价格",
                     "type": "number"
                 },
+                "publish_at": {
+                    "description": "定时上下架，由调度器到点执行后清空",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "推荐理由: also_bought, viewed, search, popular",
+                    "type": "string"
+                },
                 "reviews": {
                     "description": "关联的评价列表",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Review"
+                        "$ref": "#/definitions/models.Review"
+                    }
+                },
+                "score": {
+                    "description": "推荐得分，热门补充的商品为 0",
+                    "type": "number"
+                },
+                "skus": {
+                    "description": "关联的 SKU 列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductSKU"
+                    }
+                },
+                "specs": {
+                    "description": "以下关联由商品详情接口预加载",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductSpec"
+                    }
+                },
+                "status": {
+                    "description": "商品状态: 1-上架, 0-下架, 2-草稿",
+                    "type": "integer"
+                },
+                "stock": {
+                    "description": "总库存",
+                    "type": "integer"
+                },
+                "unpublish_at": {
+                    "description": "定时下架时间",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "单件重量 (kg)，按重量计费的运费模板使用",
+                    "type": "number"
+                }
+            }
+        },
+        "search.AddSearchHistoryInput": {
+            "type": "object",
+            "properties": {
+                "keyword": {
+                    "type": "string"
+                }
+            }
+        },
+        "search.FacetCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "term": {
+                    "description": "分类 ID 或价格区间标签",
+                    "type": "string"
+                }
+            }
+        },
+        "search.Facets": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/search.FacetCount"
                     }
                 },
-                "skus": {
-                    "description": "关联的 SKU 列表",
+                "price_ranges": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.ProductSKU"
+                        "$ref": "#/definitions/search.FacetCount"
                     }
-                },
-                "status": {
-                    "description": "商品状态: 1-上架, 0-下架",
-                    "type": "integer"
-                },
-                "stock": {
-                    "description": "总库存",
-                    "type": "integer"
-                },
-                "updatedAt": {
-                    "type": "string"
                 }
             }
         },
-        "models.ProductSKU": {
+        "search.Highlight": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "image": {
-                    "description": "SKU 图片",
+                "description": {
                     "type": "string"
                 },
                 "name": {
-                    "description": "SKU 名称 (如 \"红色 XL\")",
                     "type": "string"
-                },
-                "price": {
-                    "description": "SKU 价格",
-                    "type": "number"
+                }
+            }
+        },
+        "search.Hit": {
+            "type": "object",
+            "properties": {
+                "highlight": {
+                    "$ref": "#/definitions/search.Highlight"
                 },
                 "product_id": {
-                    "description": "关联的商品 ID",
                     "type": "integer"
                 },
-                "specs": {
-                    "description": "规格详情 JSON 字符串",
-                    "type": "string"
-                },
-                "stock": {
-                    "description": "SKU 库存",
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "search.HotKeyword": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "时间窗口内搜索过该词的用户数，置顶词为 0",
                     "type": "integer"
                 },
-                "updatedAt": {
+                "keyword": {
                     "type": "string"
+                },
+                "pinned": {
+                    "type": "boolean"
                 }
             }
         },
-        "models.Review": {
+        "search.ProductSearchResponse": {
             "type": "object",
             "properties": {
-                "content": {
-                    "description": "评价内容",
-                    "type": "string"
+                "facets": {
+                    "$ref": "#/definitions/search.Facets"
                 },
-                "createdAt": {
-                    "type": "string"
+                "hits": {
+                    "description": "与 Items 一一对应的得分和高亮",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/search.Hit"
+                    }
                 },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
+                "items": {
+                    "description": "按相关度排序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Product"
+                    }
                 },
-                "id": {
+                "page": {
                     "type": "integer"
                 },
-                "images": {
-                    "description": "评价图片(JSON array string)",
-                    "type": "string"
-                },
-                "order_id": {
+                "page_size": {
                     "type": "integer"
                 },
-                "product_id": {
+                "pages": {
                     "type": "integer"
                 },
-                "rating": {
-                    "description": "评分 1-5",
+                "search_id": {
+                    "description": "搜索日志 ID，上报点击事件时使用",
+                    "type": "string"
+                },
+                "total": {
                     "type": "integer"
+                }
+            }
+        },
+        "search.QueryStat": {
+            "type": "object",
+            "properties": {
+                "avg_latency_ms": {
+                    "description": "平均耗时 (毫秒)",
+                    "type": "number"
                 },
-                "reply": {
-                    "description": "商家回复",
-                    "type": "string"
+                "avg_results": {
+                    "description": "平均命中数",
+                    "type": "number"
                 },
-                "status": {
-                    "description": "0:隐藏 1:显示 (默认1)",
+                "clicked_searches": {
+                    "description": "至少有一次点击的搜索次数",
                     "type": "integer"
                 },
-                "updatedAt": {
+                "clicks": {
+                    "description": "点击次数",
+                    "type": "integer"
+                },
+                "ctr": {
+                    "description": "点击率 = ClickedSearches / Searches",
+                    "type": "number"
+                },
+                "query": {
                     "type": "string"
                 },
-                "user": {
-                    "description": "关联用户信息",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.User"
-                        }
-                    ]
+                "searches": {
+                    "description": "搜索次数",
+                    "type": "integer"
                 },
-                "user_id": {
+                "zero_results": {
+                    "description": "无结果次数",
                     "type": "integer"
                 }
             }
         },
-        "models.SearchHistory": {
+        "search.SearchClickInput": {
             "type": "object",
+            "required": [
+                "product_id",
+                "search_id"
+            ],
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "position": {
+                    "description": "商品在结果中的位置，从 1 开始",
+                    "type": "integer"
                 },
-                "id": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "search_id": {
+                    "description": "搜索接口返回的 search_id",
                     "type": "string"
+                }
+            }
+        },
+        "search.SearchTermInput": {
+            "type": "object",
+            "required": [
+                "action",
+                "keyword"
+            ],
+            "properties": {
+                "action": {
+                    "description": "pin-置顶, block-屏蔽",
+                    "type": "string",
+                    "enum": [
+                        "pin",
+                        "block"
+                    ]
                 },
                 "keyword": {
                     "type": "string"
                 },
-                "user_id": {
+                "sort_order": {
                     "type": "integer"
                 }
             }
         },
-        "models.User": {
+        "search.StopwordInput": {
             "type": "object",
+            "required": [
+                "word"
+            ],
             "properties": {
-                "avatar": {
-                    "description": "用户头像 URL",
-                    "type": "string"
-                },
-                "createdAt": {
+                "word": {
                     "type": "string"
-                },
-                "deletedAt": {
-                    "$ref": "#/definitions/gorm.DeletedAt"
-                },
-                "email": {
-                    "description": "邮箱，必须唯一且不能为空",
-                    "type": "string"
-                },
-                "id": {
+                }
+            }
+        },
+        "search.Suggestion": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "description": "Type 为 product 时的商品 ID",
                     "type": "integer"
                 },
-                "role": {
-                    "description": "用户角色 (user/admin)，默认为 user",
-                    "type": "string"
-                },
-                "updatedAt": {
+                "text": {
                     "type": "string"
                 },
-                "username": {
-                    "description": "用户名，必须唯一且不能为空",
+                "type": {
+                    "description": "product-商品名称, keyword-热门关键词",
                     "type": "string"
                 }
             }
         },
-        "order.CreateOrderInput": {
+        "search.SynonymInput": {
             "type": "object",
             "required": [
-                "address_id"
+                "terms"
             ],
             "properties": {
-                "address_id": {
-                    "description": "收货地址 ID",
-                    "type": "integer"
+                "terms": {
+                    "description": "组内互为同义的词，如 [\"iphone\", \"苹果手机\"]",
+                    "type": "array",
+                    "minItems": 2,
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "order.ReviewOrderInput": {
+        "shipping.FreightRuleInput": {
             "type": "object",
             "properties": {
-                "content": {
-                    "type": "string"
+                "additional_fee": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "rating": {
-                    "type": "integer"
+                "additional_unit": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "first_fee": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "first_unit": {
+                    "type": "number"
+                },
+                "free_threshold": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "regions": {
+                    "description": "为空表示默认规则",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "order.UpdateOrderStatusInput": {
+        "shipping.FreightTemplateInput": {
             "type": "object",
             "required": [
-                "status"
+                "charge_type",
+                "name",
+                "rules"
             ],
             "properties": {
-                "status": {
-                    "type": "integer"
+                "charge_type": {
+                    "type": "string",
+                    "enum": [
+                        "item",
+                        "weight"
+                    ]
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rules": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/shipping.FreightRuleInput"
+                    }
                 }
             }
         },
-        "product.CreateProductInput": {
+        "spec.SKUState": {
             "type": "object",
-            "required": [
-                "category_id",
-                "name",
-                "price",
-                "stock"
-            ],
             "properties": {
-                "category_id": {
-                    "type": "integer"
+                "available": {
+                    "description": "有库存即可售",
+                    "type": "boolean"
                 },
-                "cover_image": {
+                "image": {
                     "type": "string"
                 },
-                "description": {
+                "price": {
+                    "type": "number"
+                },
+                "sku_id": {
+                    "type": "integer"
+                },
+                "stock": {
+                    "type": "integer"
+                }
+            }
+        },
+        "storage.Image": {
+            "type": "object",
+            "properties": {
+                "content_type": {
                     "type": "string"
                 },
-                "name": {
+                "height": {
+                    "type": "integer"
+                },
+                "key": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "size": {
+                    "type": "integer"
                 },
-                "skus": {
-                    "description": "商品 SKU 列表",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/product.ProductSKUInput"
+                "url": {
+                    "type": "string"
+                },
+                "variants": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
                     }
                 },
-                "stock": {
+                "width": {
                     "type": "integer"
                 }
             }
         },
-        "product.ProductSKUInput": {
+        "wallet.IssueGiftCardsInput": {
             "type": "object",
             "required": [
-                "name",
-                "price",
-                "specs",
-                "stock"
+                "count",
+                "expire_at",
+                "face_value"
             ],
             "properties": {
-                "name": {
+                "batch_no": {
+                    "description": "批次号，为空时自动生成",
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "count": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
                 },
-                "specs": {
-                    "description": "JSON string",
+                "expire_at": {
                     "type": "string"
                 },
-                "stock": {
-                    "type": "integer"
+                "face_value": {
+                    "type": "number"
                 }
             }
         },
-        "search.AddSearchHistoryInput": {
+        "wallet.RedeemGiftCardInput": {
             "type": "object",
+            "required": [
+                "code"
+            ],
             "properties": {
-                "keyword": {
+                "code": {
                     "type": "string"
                 }
             }
@@ -6,8 +6,12 @@ import (
 	"time"
 
 	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/pkg/browsing"
 	"go-flutter-mall/backend/pkg/kafka"
+	"go-flutter-mall/backend/pkg/recommend"
 	"go-flutter-mall/backend/pkg/scheduler"
+	"go-flutter-mall/backend/pkg/search"
+	"go-flutter-mall/backend/pkg/storage"
 	"go-flutter-mall/backend/pkg/websocket"
 	"go-flutter-mall/backend/routes"
 
@@ -46,6 +50,14 @@ func main() {
 	config.ConnectRedis()
 	// 连接到 Kafka
 	config.ConnectKafka()
+	// 初始化商品搜索后端 (依赖数据库连接)
+	search.Init()
+	// 创建搜索历史、搜索日志和浏览历史索引 (依赖 MongoDB 连接)
+	search.InitHistory()
+	search.InitAnalytics()
+	browsing.Init()
+	// 初始化图片存储 (本地磁盘或 S3 兼容存储)
+	storage.Init()
 
 	// 2. 初始化 Gin 路由引擎
 	r := gin.Default()
@@ -53,9 +65,9 @@ func main() {
 	// 3. 配置 CORS (跨域资源共享)
 	// 允许前端应用 (如 Flutter Web 或本地调试) 访问后端 API
 	r.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},                                       // 允许所有来源 (生产环境应限制为特定域名)
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}, // 允许的 HTTP 方法
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"}, // 允许的请求头
+		AllowOrigins:     []string{"*"},                                                // 允许所有来源 (生产环境应限制为特定域名)
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}, // 允许的 HTTP 方法
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},          // 允许的请求头
 		ExposeHeaders:    []string{"Content-Length"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
@@ -63,11 +75,18 @@ func main() {
 
 	// 3.5 初始化 WebSocket Hub
 	hub := websocket.NewHub()
+	websocket.Default = hub
 	go hub.Run()
 
-	// 3.6 启动 Kafka 消费者和延时队列调度器
+	// 3.6 启动 Kafka 消费者、延时队列调度器和热搜统计任务
 	kafka.StartConsumer()
 	scheduler.StartScheduler()
+	// 商品定时上下架
+	scheduler.StartPublishScheduler()
+	// 定时统计热搜关键词
+	search.StartHotKeywordsRefresher()
+	// 定时计算商品共同购买相似度 (推荐)
+	recommend.StartSimilarityJob()
 
 	// 4. 设置路由
 	// 注册所有的 API 路由组 (Auth, Product, Cart, Order 等)
@@ -4,47 +4,90 @@ import (
 	"net/http"
 	"strings"
 
+	"go-flutter-mall/backend/models"
 	"go-flutter-mall/backend/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
+// authenticate 解析并验证 Authorization 头中的 Token，失败时写入 401 响应并终止请求
+func authenticate(c *gin.Context) (*utils.Claims, bool) {
+	// 1. 获取 Authorization 头
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
+		c.Abort() // 终止后续处理
+		return nil, false
+	}
+
+	// 2. 解析 Bearer Token
+	// 格式通常为: "Bearer <token>"
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization format"})
+		c.Abort()
+		return nil, false
+	}
+
+	tokenString := parts[1]
+
+	// 3. 验证 Token
+	claims, err := utils.ValidateToken(tokenString)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		c.Abort()
+		return nil, false
+	}
+
+	// 4. 将用户 ID 和角色存入上下文
+	// 后续的控制器可以通过 c.Get("userID") 获取当前登录用户的 ID
+	c.Set("userID", claims.UserID)
+	c.Set("role", claims.Role)
+	return claims, true
+}
+
 // AuthMiddleware 是 JWT 认证中间件
 // 它会拦截请求，检查 Authorization 头中的 Token 是否有效
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// 1. 获取 Authorization 头
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
-			c.Abort() // 终止后续处理
+		if _, ok := authenticate(c); !ok {
 			return
 		}
 
-		// 2. 解析 Bearer Token
-		// 格式通常为: "Bearer <token>"
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization format"})
-			c.Abort()
+		// 继续处理请求
+		c.Next()
+	}
+}
+
+// AdminMiddleware 管理员认证中间件
+// 在 AuthMiddleware 的基础上要求 Token 的角色为 admin，否则返回 403
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := authenticate(c)
+		if !ok {
 			return
 		}
-
-		tokenString := parts[1]
-
-		// 3. 验证 Token
-		claims, err := utils.ValidateToken(tokenString)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		if claims.Role != models.RoleAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Admin privileges required"})
 			c.Abort()
 			return
 		}
 
-		// 4. 将用户 ID 存入上下文
-		// 后续的控制器可以通过 c.Get("userID") 获取当前登录用户的 ID
-		c.Set("userID", claims.UserID)
+		c.Next()
+	}
+}
 
-		// 继续处理请求
+// OptionalAuthMiddleware 可选的 JWT 认证中间件
+// 用于公开接口: 携带有效 Token 时设置 userID，未携带或无效时按游客处理，不拦截请求
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(parts) == 2 && parts[0] == "Bearer" {
+			if claims, err := utils.ValidateToken(parts[1]); err == nil {
+				c.Set("userID", claims.UserID)
+				c.Set("role", claims.Role)
+			}
+		}
 		c.Next()
 	}
 }
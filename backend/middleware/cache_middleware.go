@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cacheWriter 只在成功响应时写入 Cache-Control，避免 404 等错误响应被长期缓存
+type cacheWriter struct {
+	gin.ResponseWriter
+	value string
+}
+
+func (w *cacheWriter) WriteHeader(code int) {
+	if code == http.StatusOK || code == http.StatusNotModified {
+		w.Header().Set("Cache-Control", w.value)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// CacheControl 为成功的响应设置 Cache-Control 头，用于静态文件
+func CacheControl(value string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer = &cacheWriter{ResponseWriter: c.Writer, value: value}
+		c.Next()
+	}
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// 分类属性类型
+const (
+	AttributeText   = "text"   // 文本
+	AttributeNumber = "number" // 数值
+	AttributeEnum   = "enum"   // 单选枚举，取值必须在 Options 中
+	AttributeBool   = "bool"   // 是/否，取值为 "true" 或 "false"
+)
+
+// CategoryAttribute 分类的商品属性模板 (如 数码: 存储容量、屏幕尺寸；生鲜: 产地、保质期)
+// 模板对该分类及其子孙分类下的商品生效
+type CategoryAttribute struct {
+	gorm.Model
+	CategoryID uint           `gorm:"index" json:"category_id"`        // 所属分类 ID
+	Name       string         `json:"name"`                            // 属性名称
+	Type       string         `gorm:"default:text" json:"type"`        // 属性类型: text, number, enum, bool
+	Required   bool           `gorm:"default:false" json:"required"`   // 商品是否必须填写
+	Options    pq.StringArray `gorm:"type:text[]" json:"options"`      // 枚举可选值 (enum 类型)
+	Unit       string         `json:"unit"`                            // 单位 (如 GB、英寸、天)
+	Filterable bool           `gorm:"default:false" json:"filterable"` // 是否可在商品列表中筛选并返回分面
+	SortOrder  int            `gorm:"default:0" json:"sort_order"`     // 排序权重，越小越靠前
+}
+
+// ProductAttributeValue 商品的属性值，值统一以字符串存储
+type ProductAttributeValue struct {
+	ID          uint               `gorm:"primaryKey" json:"-"`
+	ProductID   uint               `gorm:"uniqueIndex:idx_product_attribute" json:"product_id"`         // 关联的商品 ID
+	AttributeID uint               `gorm:"uniqueIndex:idx_product_attribute;index" json:"attribute_id"` // 关联的属性模板 ID
+	Value       string             `gorm:"index" json:"value"`                                          // 属性值
+	Attribute   *CategoryAttribute `gorm:"foreignKey:AttributeID" json:"attribute,omitempty"`           // 预加载的属性模板
+}
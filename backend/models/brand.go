@@ -0,0 +1,12 @@
+package models
+
+import "gorm.io/gorm"
+
+// Brand 表示商品品牌
+type Brand struct {
+	gorm.Model
+	Name        string `gorm:"uniqueIndex" json:"name"`     // 品牌名称
+	Logo        string `json:"logo"`                        // 品牌 Logo URL
+	Description string `json:"description"`                 // 品牌介绍
+	SortOrder   int    `gorm:"default:0" json:"sort_order"` // 排序权重，越小越靠前
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BrowsingHistory 商品浏览历史
+// 存储在 MongoDB 中，同一用户同一商品每天只有一条记录，重复浏览累加次数并刷新浏览时间
+type BrowsingHistory struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    uint               `bson:"user_id" json:"user_id"`
+	ProductID uint               `bson:"product_id" json:"product_id"`
+	Day       string             `bson:"day" json:"day"`             // 浏览日期 (2006-01-02，服务器本地时区)
+	Views     int64              `bson:"views" json:"views"`         // 当天浏览次数
+	ViewedAt  time.Time          `bson:"viewed_at" json:"viewed_at"` // 当天最后一次浏览时间
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// 优惠券类型
+const (
+	CouponTypeFixed        = "fixed"         // 无门槛立减
+	CouponTypePercent      = "percent"       // 折扣券
+	CouponTypeThreshold    = "threshold"     // 满减券
+	CouponTypeFreeShipping = "free_shipping" // 包邮券
+)
+
+// 适用范围 (优惠券与促销共用)
+const (
+	ScopeAll      = "all"      // 全场通用
+	ScopeCategory = "category" // 指定分类
+	ScopeProduct  = "product"  // 指定商品
+)
+
+// CouponTemplate 优惠券模板
+// 由管理员创建，用户从模板领取得到 UserCoupon
+type CouponTemplate struct {
+	gorm.Model
+	Name         string        `gorm:"not null" json:"name"`            // 优惠券名称
+	Type         string        `gorm:"not null" json:"type"`            // 类型: fixed, percent, threshold, free_shipping
+	Amount       float64       `json:"amount"`                          // 优惠金额 (fixed/threshold)
+	Rate         float64       `json:"rate"`                            // 折扣率 (percent)，如 0.85 表示 85 折
+	MinAmount    float64       `json:"min_amount"`                      // 使用门槛，适用商品金额满 MinAmount 才可使用
+	MaxDiscount  float64       `json:"max_discount"`                    // 最高优惠金额 (percent)，0 表示不限
+	ScopeType    string        `gorm:"default:'all'" json:"scope_type"` // 适用范围: all, category, product
+	ScopeIDs     pq.Int64Array `gorm:"type:bigint[]" json:"scope_ids"`  // 适用的分类 ID 或商品 ID 列表
+	StartAt      time.Time     `json:"start_at"`                        // 领取/使用开始时间
+	EndAt        time.Time     `json:"end_at"`                          // 领取/使用结束时间
+	ValidDays    int           `json:"valid_days"`                      // 领取后有效天数，0 表示以 EndAt 为准
+	TotalCount   int           `json:"total_count"`                     // 发行总量，0 表示不限
+	IssuedCount  int           `gorm:"default:0" json:"issued_count"`   // 已领取数量
+	PerUserLimit int           `gorm:"default:1" json:"per_user_limit"` // 每人限领张数
+	Status       int           `gorm:"default:1" json:"status"`         // 状态: 1-启用, 0-停用 (停用后不可领取，已领取的券不受影响)
+	Description  string        `json:"description"`                     // 使用说明
+}
+
+// UserCoupon 用户领取的优惠券 (券包)
+type UserCoupon struct {
+	gorm.Model
+	UserID     uint           `gorm:"index;not null" json:"user_id"`         // 关联的用户 ID
+	TemplateID uint           `gorm:"index;not null" json:"template_id"`     // 关联的模板 ID
+	Template   CouponTemplate `gorm:"foreignKey:TemplateID" json:"template"` // 预加载的模板信息
+	Status     int            `gorm:"default:0" json:"status"`               // 状态: 0-未使用, 1-已使用
+	ExpireAt   time.Time      `gorm:"index" json:"expire_at"`                // 过期时间
+	OrderID    uint           `gorm:"index;default:0" json:"order_id"`       // 使用该券的订单 ID
+	UsedAt     *time.Time     `json:"used_at"`                               // 使用时间
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Favorite 用户收藏的商品
+type Favorite struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"uniqueIndex:idx_favorites_user_product;not null" json:"user_id"`          // 用户 ID
+	ProductID uint      `gorm:"uniqueIndex:idx_favorites_user_product;index;not null" json:"product_id"` // 商品 ID
+	Product   Product   `json:"product"`                                                                 // 预加载的商品信息
+	CreatedAt time.Time `gorm:"index" json:"created_at"`                                                 // 收藏时间
+}
@@ -14,13 +14,24 @@ type Order struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
-	OrderNo     string      `gorm:"uniqueIndex;not null" json:"order_no"` // 订单编号，唯一
-	UserID      uint        `json:"user_id"`                              // 关联的用户 ID
-	TotalAmount float64     `json:"total_amount"`                         // 订单总金额
-	Status      int         `gorm:"default:0" json:"status"`              // 订单状态: 0-待支付, 1-待发货, 2-待收货, 3-待评价(已完成), 4-已取消, 5-售后中
-	AddressID   uint        `json:"address_id"`                           // 收货地址 ID
-	Address     Address     `json:"address"`                              // 收货地址快照 (简化处理，实际应复制地址信息)
-	Items       []OrderItem `gorm:"foreignKey:OrderID" json:"items"`      // 订单包含的商品项
+	OrderNo           string      `gorm:"uniqueIndex;not null" json:"order_no"` // 订单编号，唯一
+	UserID            uint        `json:"user_id"`                              // 关联的用户 ID
+	ProductAmount     float64     `json:"product_amount"`                       // 商品总金额 (优惠前)
+	PromotionDiscount float64     `json:"promotion_discount"`                   // 自动促销优惠金额
+	MemberDiscount    float64     `json:"member_discount"`                      // 会员等级折扣金额
+	CouponID          uint        `gorm:"default:0" json:"coupon_id"`           // 使用的用户优惠券 ID (UserCoupon.ID)
+	CouponDiscount    float64     `json:"coupon_discount"`                      // 优惠券抵扣金额
+	ShippingFee       float64     `json:"shipping_fee"`                         // 运费
+	PointsUsed        int         `gorm:"default:0" json:"points_used"`         // 抵扣使用的积分
+	PointsDiscount    float64     `json:"points_discount"`                      // 积分抵扣金额
+	TotalAmount       float64     `json:"total_amount"`                         // 订单实付金额
+	PaymentMethod     string      `json:"payment_method"`                       // 支付方式: gateway, wallet, mixed (钱包 + 第三方支付)
+	WalletAmount      float64     `json:"wallet_amount"`                        // 钱包支付金额
+	GatewayAmount     float64     `json:"gateway_amount"`                       // 第三方支付金额
+	Status            int         `gorm:"default:0" json:"status"`              // 订单状态: 0-待支付, 1-待发货, 2-待收货, 3-待评价, 4-已完成, 5-售后中, 6-已退款, -1-已取消
+	AddressID         uint        `json:"address_id"`                           // 收货地址 ID
+	Address           Address     `json:"address"`                              // 收货地址快照 (简化处理，实际应复制地址信息)
+	Items             []OrderItem `gorm:"foreignKey:OrderID" json:"items"`      // 订单包含的商品项
 }
 
 // OrderItem 表示订单中的具体商品项
@@ -30,12 +41,13 @@ type OrderItem struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
-	OrderID      uint    `json:"order_id"`      // 关联的订单 ID
-	ProductID    uint    `json:"product_id"`    // 商品 ID
-	ProductName  string  `json:"product_name"`  // 商品名称 (快照)
-	ProductImage string  `json:"product_image"` // 商品图片 (快照)
-	SKUID        uint    `json:"sku_id"`        // SKU ID
-	SKUName      string  `json:"sku_name"`      // SKU 名称 (快照)
-	Price        float64 `json:"price"`         // 购买时的单价
-	Quantity     int     `json:"quantity"`      // 购买数量
+	OrderID        uint    `json:"order_id"`        // 关联的订单 ID
+	ProductID      uint    `json:"product_id"`      // 商品 ID
+	ProductName    string  `json:"product_name"`    // 商品名称 (快照)
+	ProductImage   string  `json:"product_image"`   // 商品图片 (快照)
+	SKUID          uint    `json:"sku_id"`          // SKU ID
+	SKUName        string  `json:"sku_name"`        // SKU 名称 (快照)
+	Price          float64 `json:"price"`           // 购买时的单价
+	Quantity       int     `json:"quantity"`        // 购买数量
+	DiscountAmount float64 `json:"discount_amount"` // 分摊到该商品项的促销优惠
 }
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 积分流水类型
+const (
+	PointsEarnOrder    = "earn_order"    // 订单支付获得
+	PointsEarnReview   = "earn_review"   // 评价获得
+	PointsSpendOrder   = "spend_order"   // 下单抵扣
+	PointsReturnOrder  = "return_order"  // 订单取消/退款退还抵扣的积分
+	PointsReverseOrder = "reverse_order" // 订单退款扣回已获得的积分
+	PointsAdminAdjust  = "admin_adjust"  // 管理员调整
+)
+
+// PointsAccount 用户积分账户
+// 余额只能通过 pkg/points 修改，每次变动都会写入 PointsTransaction
+type PointsAccount struct {
+	gorm.Model
+	UserID          uint    `gorm:"uniqueIndex;not null" json:"user_id"` // 关联的用户 ID
+	Balance         int     `gorm:"default:0" json:"balance"`            // 可用积分
+	TotalEarned     int     `gorm:"default:0" json:"total_earned"`       // 累计获得积分
+	CumulativeSpend float64 `gorm:"default:0" json:"cumulative_spend"`   // 累计消费金额，用于计算会员等级
+}
+
+// PointsTransaction 积分流水
+// 流水只追加不修改，不包含 UpdatedAt 和 DeletedAt
+type PointsTransaction struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	UserID  uint   `gorm:"index;not null" json:"user_id"`   // 关联的用户 ID
+	Type    string `gorm:"not null" json:"type"`            // 流水类型: earn_order, earn_review, spend_order, return_order, reverse_order, admin_adjust
+	Change  int    `json:"change"`                          // 变动积分，正数为增加，负数为扣减
+	Balance int    `json:"balance"`                         // 变动后余额
+	OrderID uint   `gorm:"index;default:0" json:"order_id"` // 关联的订单 ID
+	Remark  string `json:"remark"`                          // 备注
+}
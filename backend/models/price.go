@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// 价格变动来源
+const (
+	PriceSourceAdmin  = "admin"  // 管理后台编辑商品
+	PriceSourceImport = "import" // 批量导入
+)
+
+// PriceHistory 商品或 SKU 的价格变动记录
+// SKUID 为 0 表示商品基础价格
+type PriceHistory struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	ProductID uint      `gorm:"index:idx_price_histories_product;not null" json:"product_id"` // 商品 ID
+	SKUID     uint      `gorm:"default:0" json:"sku_id"`                                      // SKU ID，0 表示商品基础价格
+	OldPrice  float64   `json:"old_price"`                                                    // 变动前价格
+	NewPrice  float64   `json:"new_price"`                                                    // 变动后价格
+	Source    string    `json:"source"`                                                       // 变动来源: admin, import
+	CreatedAt time.Time `gorm:"index:idx_price_histories_product" json:"created_at"`
+}
+
+// PriceAlert 用户订阅的降价提醒
+// 商品最低价低于 TargetPrice 时发送通知，并将 TargetPrice 更新为新价格，之后继续降价会再次提醒
+type PriceAlert struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	UserID      uint       `gorm:"uniqueIndex:idx_price_alerts_user_product;not null" json:"user_id"`          // 用户 ID
+	ProductID   uint       `gorm:"uniqueIndex:idx_price_alerts_user_product;index;not null" json:"product_id"` // 商品 ID
+	TargetPrice float64    `json:"target_price"`                                                               // 提醒价格，默认为订阅时的最低价
+	NotifiedAt  *time.Time `json:"notified_at"`                                                                // 最近一次提醒时间
+	CreatedAt   time.Time  `json:"created_at"`
+}
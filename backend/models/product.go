@@ -1,32 +1,59 @@
 package models
 
 import (
+	"time"
+
 	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
 // Category 表示商品分类
+// 通过 ParentID 组成最多三级的分类树，ParentID 为 0 表示一级分类
 type Category struct {
 	gorm.Model
-	Name      string `json:"name"`       // 分类名称
-	SortOrder int    `json:"sort_order"` // 排序权重
-	Icon      string `json:"icon"`       // 分类图标
+	ParentID  uint       `gorm:"index;default:0" json:"parent_id"` // 父分类 ID
+	Level     int        `gorm:"default:1" json:"level"`           // 层级: 1, 2, 3
+	Name      string     `json:"name"`                             // 分类名称
+	SortOrder int        `json:"sort_order"`                       // 排序权重
+	Icon      string     `json:"icon"`                             // 分类图标
+	Children  []Category `gorm:"-" json:"children,omitempty"`      // 子分类 (分类树接口填充)
 }
 
+// 商品状态
+const (
+	ProductStatusOff   = 0 // 下架
+	ProductStatusOn    = 1 // 上架
+	ProductStatusDraft = 2 // 草稿，前台列表和详情均不可见
+)
+
 // Product 表示商品信息
 // 包含商品的基本属性和关联的 SKU
 type Product struct {
 	gorm.Model
-	Name        string         `json:"name"`                                          // 商品名称
-	Description string         `json:"description"`                                   // 商品描述
-	Price       float64        `json:"price"`                                         // 商品基础价格
-	Stock       int            `json:"stock"`                                         // 总库存
-	CoverImage  string         `json:"cover_image"`                                   // 封面图片 URL
-	Images      pq.StringArray `gorm:"type:text[]" json:"images"`                     // 商品轮播图列表 (PostgreSQL 数组类型)
-	CategoryID  uint           `json:"category_id"`                                   // 分类 ID
-	Status      int            `gorm:"default:1" json:"status"`                       // 商品状态: 1-上架, 0-下架
-	SKUs        []ProductSKU   `gorm:"foreignKey:ProductID" json:"skus"`              // 关联的 SKU 列表
-	Reviews     []Review       `gorm:"foreignKey:ProductID" json:"reviews,omitempty"` // 关联的评价列表
+	Name              string         `json:"name"`                                          // 商品名称
+	Description       string         `json:"description"`                                   // 商品描述
+	Price             float64        `json:"price"`                                         // 商品基础价格
+	Stock             int            `json:"stock"`                                         // 总库存
+	CoverImage        string         `json:"cover_image"`                                   // 封面图片 URL
+	Images            pq.StringArray `gorm:"type:text[]" json:"images"`                     // 商品轮播图列表 (PostgreSQL 数组类型)
+	CategoryID        uint           `json:"category_id"`                                   // 分类 ID
+	BrandID           uint           `gorm:"index;default:0" json:"brand_id"`               // 品牌 ID，0 表示无品牌
+	Status            int            `gorm:"default:1" json:"status"`                       // 商品状态: 1-上架, 0-下架, 2-草稿
+	Weight            float64        `gorm:"default:0" json:"weight"`                       // 单件重量 (kg)，按重量计费的运费模板使用
+	FreightTemplateID uint           `gorm:"default:0" json:"freight_template_id"`          // 运费模板 ID，0 表示使用默认模板
+	SKUs              []ProductSKU   `gorm:"foreignKey:ProductID" json:"skus"`              // 关联的 SKU 列表
+	Reviews           []Review       `gorm:"foreignKey:ProductID" json:"reviews,omitempty"` // 关联的评价列表
+
+	// 定时上下架，由调度器到点执行后清空
+	PublishAt   *time.Time `gorm:"index" json:"publish_at"`   // 定时上架时间
+	UnpublishAt *time.Time `gorm:"index" json:"unpublish_at"` // 定时下架时间
+
+	// 收藏数，GORM 只读 (保存商品时不会覆盖)，由收藏接口以原子 SQL 增减
+	FavoriteCount int `gorm:"->;default:0" json:"favorite_count"`
+
+	// 以下关联由商品详情接口预加载
+	Specs      []ProductSpec           `gorm:"foreignKey:ProductID" json:"specs,omitempty"`      // 规格属性 (如颜色、尺码)
+	Attributes []ProductAttributeValue `gorm:"foreignKey:ProductID" json:"attributes,omitempty"` // 分类属性值
 }
 
 // ProductSKU 表示商品的库存量单位 (Stock Keeping Unit)
@@ -39,6 +66,29 @@ type ProductSKU struct {
 	Price     float64 `json:"price"`      // SKU 价格
 	Stock     int     `json:"stock"`      // SKU 库存
 	Image     string  `json:"image"`      // SKU 图片
+
+	// 规格组合键: 规格值 ID 升序以逗号连接 (如 "3,7")，由规格矩阵生成；手动创建的 SKU 为空
+	SpecKey string `gorm:"index" json:"spec_key"`
+	// 外部 SKU 编码，批量导入时按编码新增或更新，未删除的 SKU 之间唯一
+	Code string `gorm:"uniqueIndex:idx_product_skus_code,where:code <> '' AND deleted_at IS NULL" json:"code"`
+}
+
+// ProductSpec 商品规格属性 (如 "颜色"、"尺码")
+type ProductSpec struct {
+	gorm.Model
+	ProductID uint               `gorm:"index" json:"product_id"`         // 关联的商品 ID
+	Name      string             `json:"name"`                            // 规格名称
+	SortOrder int                `gorm:"default:0" json:"sort_order"`     // 排序权重，越小越靠前
+	Values    []ProductSpecValue `gorm:"foreignKey:SpecID" json:"values"` // 规格值列表
+}
+
+// ProductSpecValue 商品规格值 (如 "红"、"XL")
+type ProductSpecValue struct {
+	gorm.Model
+	SpecID    uint   `gorm:"index" json:"spec_id"`        // 关联的规格属性 ID
+	Value     string `json:"value"`                       // 规格值
+	Image     string `json:"image"`                       // 规格值图片 (如颜色对应的商品图)
+	SortOrder int    `gorm:"default:0" json:"sort_order"` // 排序权重，越小越靠前
 }
 
 // CartItem 表示购物车中的一项
@@ -50,4 +100,8 @@ type CartItem struct {
 	SKUID     uint    `gorm:"column:sku_id;default:0" json:"sku_id"` // 关联的 SKU ID (如果商品有规格)
 	Quantity  int     `json:"quantity"`                              // 购买数量
 	Selected  bool    `gorm:"default:true" json:"selected"`          // 是否选中
+
+	// 以下字段不入库，查询购物车时填充
+	Available         bool   `gorm:"-" json:"available"`                    // 商品和 SKU 是否仍可购买
+	UnavailableReason string `gorm:"-" json:"unavailable_reason,omitempty"` // 不可购买原因: product_removed, off_shelf, sku_removed
 }
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// 促销类型
+const (
+	PromotionTypeBuyXGetY         = "buy_x_get_y"       // 买 X 送 Y
+	PromotionTypeTiered           = "tiered"            // 阶梯满减
+	PromotionTypeCategoryDiscount = "category_discount" // 品类折扣
+	PromotionTypeBundle           = "bundle"            // 组合套装价
+)
+
+// Promotion 自动促销活动
+// 与优惠券不同，促销无需领取，满足条件的购物车行自动享受
+type Promotion struct {
+	gorm.Model
+	Name      string        `gorm:"not null" json:"name"`            // 活动名称
+	Type      string        `gorm:"not null" json:"type"`            // 类型: buy_x_get_y, tiered, category_discount, bundle
+	ScopeType string        `gorm:"default:'all'" json:"scope_type"` // 适用范围: all, category, product (与优惠券一致)
+	ScopeIDs  pq.Int64Array `gorm:"type:bigint[]" json:"scope_ids"`  // 适用的分类 ID 或商品 ID 列表；bundle 类型为套装内的商品 ID
+	Rules     string        `gorm:"type:text" json:"rules"`          // 规则详情 JSON 字符串，结构见 promotion.Rule
+	Priority  int           `gorm:"default:0" json:"priority"`       // 优先级，数值越大越先计算
+	StartAt   time.Time     `json:"start_at"`                        // 开始时间
+	EndAt     time.Time     `json:"end_at"`                          // 结束时间
+	Status    int           `gorm:"default:1" json:"status"`         // 状态: 1-启用, 0-停用
+}
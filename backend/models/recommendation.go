@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// ProductSimilarity 商品之间的共同购买相似度
+// 由推荐任务根据订单数据定期全量重算，每个商品只保留相似度最高的若干个商品
+type ProductSimilarity struct {
+	ProductID        uint      `gorm:"primaryKey;autoIncrement:false" json:"product_id"`         // 商品 ID
+	RelatedProductID uint      `gorm:"primaryKey;autoIncrement:false" json:"related_product_id"` // 相似商品 ID
+	Score            float64   `json:"score"`                                                    // 余弦相似度: 共同购买订单数 / sqrt(两者各自的购买订单数之积)
+	CoPurchases      int       `json:"co_purchases"`                                             // 共同出现的订单数
+	UpdatedAt        time.Time `json:"updated_at"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// 热搜词干预方式
+const (
+	SearchTermPin   = "pin"   // 置顶: 始终出现在热搜榜前列
+	SearchTermBlock = "block" // 屏蔽: 不出现在热搜榜和搜索建议中
+)
+
+// SearchTerm 管理员对热搜词的干预规则
+type SearchTerm struct {
+	gorm.Model
+	Keyword   string `gorm:"uniqueIndex;not null" json:"keyword"` // 关键词 (小写)
+	Action    string `gorm:"not null" json:"action"`              // 干预方式: pin-置顶, block-屏蔽
+	SortOrder int    `gorm:"default:0" json:"sort_order"`         // 置顶词排序，数值越小越靠前
+}
+
+// SearchSynonym 同义词组
+// 查询命中组内任意一个词时，同时搜索组内其他词
+type SearchSynonym struct {
+	gorm.Model
+	Terms pq.StringArray `gorm:"type:text[]" json:"terms"` // 同义词 (小写)，如 ["iphone", "苹果手机"]
+}
+
+// SearchStopword 停用词，查询前从关键词中移除
+type SearchStopword struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Word      string    `gorm:"uniqueIndex;not null" json:"word"` // 停用词 (小写)
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -14,3 +14,29 @@ type SearchHistory struct {
 	Keyword   string             `bson:"keyword" json:"keyword"`
 	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
 }
+
+// SearchLog 商品搜索日志
+// 存储在 MongoDB 中，用于统计热门查询、无结果查询和点击率
+type SearchLog struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID      uint               `bson:"user_id" json:"user_id"`           // 未登录为 0
+	Query       string             `bson:"query" json:"query"`               // 原始关键词
+	Normalized  string             `bson:"normalized" json:"normalized"`     // 小写并去除首尾空白，用于聚合
+	Filters     map[string]string  `bson:"filters" json:"filters"`           // 分类、价格、排序、页码等其他查询参数
+	Source      string             `bson:"source" json:"source"`             // 来源接口: products-商品列表, search-搜索接口
+	ResultCount int64              `bson:"result_count" json:"result_count"` // 命中总数
+	LatencyMs   int64              `bson:"latency_ms" json:"latency_ms"`     // 处理耗时 (毫秒)
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// SearchClick 搜索结果点击事件
+// 通过 SearchID 关联到产生该结果的搜索日志
+type SearchClick struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	SearchID   primitive.ObjectID `bson:"search_id" json:"search_id"`
+	UserID     uint               `bson:"user_id" json:"user_id"`
+	Normalized string             `bson:"normalized" json:"normalized"` // 冗余搜索日志的关键词，便于按查询聚合
+	ProductID  uint               `bson:"product_id" json:"product_id"`
+	Position   int                `bson:"position" json:"position"` // 商品在结果中的位置，从 1 开始
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+}
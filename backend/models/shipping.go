@@ -0,0 +1,35 @@
+package models
+
+import (
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// 运费计费方式
+const (
+	FreightChargeByItem   = "item"   // 按件数
+	FreightChargeByWeight = "weight" // 按重量 (kg)
+)
+
+// FreightTemplate 运费模板
+// 商品通过 FreightTemplateID 引用模板，未设置模板的商品使用默认模板
+type FreightTemplate struct {
+	gorm.Model
+	Name       string        `gorm:"not null" json:"name"`               // 模板名称
+	ChargeType string        `gorm:"default:'item'" json:"charge_type"`  // 计费方式: item, weight
+	IsDefault  bool          `gorm:"default:false" json:"is_default"`    // 是否为默认模板
+	Rules      []FreightRule `gorm:"foreignKey:TemplateID" json:"rules"` // 按地区的运费规则
+}
+
+// FreightRule 运费规则
+// Regions 为空表示默认规则 (其余地区)，否则按 "省" 或 "省/市" 匹配收货地址
+type FreightRule struct {
+	gorm.Model
+	TemplateID     uint           `gorm:"index;not null" json:"template_id"` // 关联的运费模板 ID
+	Regions        pq.StringArray `gorm:"type:text[]" json:"regions"`        // 适用地区，如 ["北京市", "广东省/深圳市"]
+	FirstUnit      float64        `json:"first_unit"`                        // 首件数/首重 (kg)
+	FirstFee       float64        `json:"first_fee"`                         // 首件/首重运费
+	AdditionalUnit float64        `json:"additional_unit"`                   // 续件数/续重 (kg)
+	AdditionalFee  float64        `json:"additional_fee"`                    // 每续件/续重运费
+	FreeThreshold  float64        `json:"free_threshold"`                    // 满额包邮，0 表示不包邮
+}
@@ -4,6 +4,12 @@ import (
 	"gorm.io/gorm"
 )
 
+// 角色
+const (
+	RoleUser  = "user"  // 普通用户
+	RoleAdmin = "admin" // 管理员，可访问发行礼品卡、调整积分、退款等涉及资金的管理接口
+)
+
 // User 表示系统中的用户
 // 包含用户的基本信息和认证凭据
 type User struct {
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 礼品卡状态
+const (
+	GiftCardStatusActive   = 0 // 未兑换
+	GiftCardStatusRedeemed = 1 // 已兑换
+	GiftCardStatusDisabled = 2 // 已作废
+)
+
+// 账本分录方向
+const (
+	LedgerDebit  = "debit"  // 借
+	LedgerCredit = "credit" // 贷
+)
+
+// 账本业务类型
+const (
+	LedgerGiftCardRedeem = "gift_card_redeem" // 礼品卡兑换入钱包
+	LedgerOrderPay       = "order_pay"        // 钱包支付订单
+	LedgerOrderRefund    = "order_refund"     // 订单退款至钱包
+)
+
+// GiftCard 礼品卡
+// 兑换时整卡余额转入用户钱包
+type GiftCard struct {
+	gorm.Model
+	Code       string     `gorm:"uniqueIndex;not null" json:"code"` // 卡密
+	BatchNo    string     `gorm:"index" json:"batch_no"`            // 发行批次号
+	FaceValue  float64    `json:"face_value"`                       // 面值
+	Balance    float64    `json:"balance"`                          // 剩余金额，兑换后为 0
+	ExpireAt   time.Time  `json:"expire_at"`                        // 过期时间
+	Status     int        `gorm:"default:0" json:"status"`          // 状态: 0-未兑换, 1-已兑换, 2-已作废
+	RedeemedBy uint       `gorm:"default:0" json:"redeemed_by"`     // 兑换用户 ID
+	RedeemedAt *time.Time `json:"redeemed_at"`                      // 兑换时间
+}
+
+// Wallet 用户储值钱包
+// 余额只能通过 pkg/wallet 修改，每次变动都会写入复式账本 LedgerEntry
+type Wallet struct {
+	gorm.Model
+	UserID  uint    `gorm:"uniqueIndex;not null" json:"user_id"` // 关联的用户 ID
+	Balance float64 `gorm:"default:0" json:"balance"`            // 可用余额
+}
+
+// LedgerEntry 复式记账分录
+// 每笔业务 (TxnNo) 至少包含一借一贷两条分录，且借贷金额相等；分录只追加不修改
+// 账户命名: wallet:{userID}, gift_card:{id}, order:{id}
+type LedgerEntry struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	TxnNo     string  `gorm:"index;not null" json:"txn_no"`    // 业务流水号，同一笔业务的借贷分录相同
+	Account   string  `gorm:"index;not null" json:"account"`   // 记账账户
+	Direction string  `gorm:"not null" json:"direction"`       // 方向: debit, credit
+	Amount    float64 `gorm:"not null" json:"amount"`          // 金额，始终为正数
+	Type      string  `gorm:"not null" json:"type"`            // 业务类型: gift_card_redeem, order_pay, order_refund
+	OrderID   uint    `gorm:"index;default:0" json:"order_id"` // 关联的订单 ID
+	Remark    string  `json:"remark"`                          // 备注
+}
@@ -0,0 +1,135 @@
+package attribute
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-flutter-mall/backend/models"
+	categorypkg "go-flutter-mall/backend/pkg/category"
+
+	"gorm.io/gorm"
+)
+
+// ValidationError 商品属性值不符合分类模板
+type ValidationError struct {
+	Attribute string
+	Reason    string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("attribute %q %s", e.Attribute, e.Reason)
+}
+
+// ForCategory 返回分类生效的属性模板，包括从祖先分类继承的模板
+func ForCategory(db *gorm.DB, categoryID uint) ([]models.CategoryAttribute, error) {
+	ids, err := categorypkg.WithAncestors(db, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	attrs := []models.CategoryAttribute{}
+	err = db.Where("category_id IN ?", ids).Order("sort_order asc, id asc").Find(&attrs).Error
+	return attrs, err
+}
+
+// Values 返回商品已保存的属性值，key 为属性模板 ID
+func Values(db *gorm.DB, productID uint) (map[uint]string, error) {
+	var list []models.ProductAttributeValue
+	if err := db.Where("product_id = ?", productID).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	values := make(map[uint]string, len(list))
+	for _, v := range list {
+		values[v.AttributeID] = v.Value
+	}
+	return values, nil
+}
+
+// Validate 按模板校验并规范化属性值，空值视为未填写
+// 不属于模板的属性返回错误；数值和布尔值统一格式化后保存，便于筛选
+func Validate(templates []models.CategoryAttribute, values map[uint]string) (map[uint]string, error) {
+	byID := make(map[uint]models.CategoryAttribute, len(templates))
+	for _, t := range templates {
+		byID[t.ID] = t
+	}
+	for id := range values {
+		if _, ok := byID[id]; !ok {
+			return nil, &ValidationError{Attribute: strconv.FormatUint(uint64(id), 10), Reason: "does not belong to this category"}
+		}
+	}
+
+	result := make(map[uint]string, len(values))
+	for _, t := range templates {
+		v := strings.TrimSpace(values[t.ID])
+		if v == "" {
+			if t.Required {
+				return nil, &ValidationError{Attribute: t.Name, Reason: "is required"}
+			}
+			continue
+		}
+
+		switch t.Type {
+		case models.AttributeNumber:
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, &ValidationError{Attribute: t.Name, Reason: "must be a number"}
+			}
+			v = strconv.FormatFloat(n, 'f', -1, 64)
+		case models.AttributeBool:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, &ValidationError{Attribute: t.Name, Reason: "must be true or false"}
+			}
+			v = strconv.FormatBool(b)
+		case models.AttributeEnum:
+			valid := false
+			for _, option := range t.Options {
+				if option == v {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				return nil, &ValidationError{Attribute: t.Name, Reason: "must be one of " + strings.Join(t.Options, ", ")}
+			}
+		}
+		result[t.ID] = v
+	}
+	return result, nil
+}
+
+// Save 以 values 整体替换商品的属性值
+func Save(tx *gorm.DB, productID uint, values map[uint]string) error {
+	if err := tx.Where("product_id = ?", productID).Delete(&models.ProductAttributeValue{}).Error; err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	list := make([]models.ProductAttributeValue, 0, len(values))
+	for id, v := range values {
+		list = append(list, models.ProductAttributeValue{ProductID: productID, AttributeID: id, Value: v})
+	}
+	return tx.Create(&list).Error
+}
+
+// ParseFilters 解析商品列表的属性筛选参数 attr[<属性 ID>]=值1,值2，同一属性的多个值为“或”关系
+func ParseFilters(params map[string]string) (map[uint][]string, error) {
+	filters := make(map[uint][]string, len(params))
+	for key, raw := range params {
+		id, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		var values []string
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+		if len(values) > 0 {
+			filters[uint(id)] = values
+		}
+	}
+	return filters, nil
+}
@@ -0,0 +1,164 @@
+package browsing
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go-flutter-mall/backend/config"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// HistoryCollection 商品浏览历史集合
+	HistoryCollection = "browsing_history"
+	// historyTTLIndex 浏览历史 TTL 索引名
+	historyTTLIndex = "viewed_at_ttl"
+)
+
+// ErrDisabled MongoDB 不可用
+var ErrDisabled = errors.New("browsing history requires MongoDB")
+
+// Init 创建浏览历史集合的索引
+// (user_id, product_id, day) 唯一索引保证同一商品每天只有一条记录，(user_id, viewed_at) 用于按时间查询和裁剪，
+// (day, product_id) 用于统计浏览量，viewed_at 上的 TTL 索引按 BROWSING_HISTORY_RETENTION_DAYS 自动删除过期记录
+func Init() {
+	if config.MongoDB == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	collection := config.MongoDB.Collection(HistoryCollection)
+
+	if _, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "product_id", Value: 1}, {Key: "day", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "viewed_at", Value: -1}}},
+		{Keys: bson.D{{Key: "day", Value: 1}, {Key: "product_id", Value: 1}}},
+	}); err != nil {
+		log.Printf("Failed to create browsing history indexes: %v", err)
+	}
+
+	if config.BrowsingHistoryRetentionDays <= 0 {
+		// 永久保留: 移除之前创建的 TTL 索引 (不存在时忽略错误)
+		collection.Indexes().DropOne(ctx, historyTTLIndex)
+		return
+	}
+	ttl := int32(config.BrowsingHistoryRetentionDays * 24 * 3600)
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "viewed_at", Value: 1}},
+		Options: options.Index().SetName(historyTTLIndex).SetExpireAfterSeconds(ttl),
+	})
+	if err != nil {
+		// 保留天数变更时索引选项冲突，通过 collMod 修改过期时间
+		cmd := bson.D{
+			{Key: "collMod", Value: HistoryCollection},
+			{Key: "index", Value: bson.D{{Key: "name", Value: historyTTLIndex}, {Key: "expireAfterSeconds", Value: ttl}}},
+		}
+		if modErr := config.MongoDB.RunCommand(ctx, cmd).Err(); modErr != nil {
+			log.Printf("Failed to create browsing history TTL index: %v (collMod: %v)", err, modErr)
+		}
+	}
+}
+
+// RecordView 异步记录一次商品浏览
+// 同一用户同一商品当天已有记录时累加次数并刷新浏览时间；MongoDB 不可用时不记录，写入失败只记录日志，不影响商品详情请求
+func RecordView(userID, productID uint) {
+	if config.MongoDB == nil || userID == 0 {
+		return
+	}
+	now := time.Now()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		filter := bson.M{"user_id": userID, "product_id": productID, "day": now.Format("2006-01-02")}
+		update := bson.M{
+			"$inc":         bson.M{"views": 1},
+			"$set":         bson.M{"viewed_at": now},
+			"$setOnInsert": bson.M{"created_at": now},
+		}
+		result, err := config.MongoDB.Collection(HistoryCollection).UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+		if err != nil {
+			log.Printf("Failed to record view of product %d by user %d: %v", productID, userID, err)
+			return
+		}
+		// 只有新增记录时才可能超出上限
+		if result.UpsertedCount > 0 {
+			if err := trimHistory(ctx, userID); err != nil {
+				log.Printf("Failed to trim browsing history of user %d: %v", userID, err)
+			}
+		}
+	}()
+}
+
+// trimHistory 只保留用户最新的 BROWSING_HISTORY_MAX_PER_USER 条浏览记录
+func trimHistory(ctx context.Context, userID uint) error {
+	max := config.BrowsingHistoryMaxPerUser
+	if max <= 0 {
+		return nil
+	}
+	collection := config.MongoDB.Collection(HistoryCollection)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "viewed_at", Value: -1}}).
+		SetSkip(int64(max)).
+		SetProjection(bson.M{"_id": 1})
+	cursor, err := collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return err
+	}
+	var stale []bson.M
+	if err := cursor.All(ctx, &stale); err != nil {
+		return err
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	ids := make([]interface{}, 0, len(stale))
+	for _, doc := range stale {
+		ids = append(ids, doc["_id"])
+	}
+	_, err = collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	return err
+}
+
+// ViewedProduct 用户最近浏览过的商品
+type ViewedProduct struct {
+	ProductID uint      `bson:"_id"`
+	Views     int64     `bson:"views"`     // 统计期内的浏览次数
+	ViewedAt  time.Time `bson:"viewed_at"` // 最后一次浏览时间
+}
+
+// RecentViews 用户 since 之后浏览过的商品，按最后浏览时间倒序，最多 limit 个
+func RecentViews(ctx context.Context, userID uint, since time.Time, limit int) ([]ViewedProduct, error) {
+	if config.MongoDB == nil {
+		return nil, ErrDisabled
+	}
+	pipeline := []bson.M{
+		{"$match": bson.M{"user_id": userID, "viewed_at": bson.M{"$gte": since}}},
+		{"$group": bson.M{
+			"_id":       "$product_id",
+			"views":     bson.M{"$sum": "$views"},
+			"viewed_at": bson.M{"$max": "$viewed_at"},
+		}},
+		{"$sort": bson.D{{Key: "viewed_at", Value: -1}}},
+		{"$limit": limit},
+	}
+	cursor, err := config.MongoDB.Collection(HistoryCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var viewed []ViewedProduct
+	if err := cursor.All(ctx, &viewed); err != nil {
+		return nil, err
+	}
+	return viewed, nil
+}
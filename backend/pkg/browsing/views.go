@@ -0,0 +1,94 @@
+package browsing
+
+import (
+	"context"
+	"time"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ProductViews 单个商品在时间范围内的浏览统计
+type ProductViews struct {
+	ProductID uint  `json:"product_id" bson:"_id"`
+	Views     int64 `json:"views" bson:"views"`       // 浏览次数
+	Visitors  int64 `json:"visitors" bson:"visitors"` // 浏览过的用户数 (去重)
+
+	// 商品信息，商品已删除时为空
+	Name       string  `json:"name" bson:"-"`
+	CoverImage string  `json:"cover_image" bson:"-"`
+	Price      float64 `json:"price" bson:"-"`
+	Status     int     `json:"status" bson:"-"`
+}
+
+// dayRange 将 [from, to) 转换为浏览记录的日期范围 (to 为次日零点)
+func dayRange(from, to time.Time) bson.M {
+	return bson.M{"$gte": from.Format("2006-01-02"), "$lte": to.Add(-time.Nanosecond).Format("2006-01-02")}
+}
+
+// viewCounts 按商品聚合 [from, to) 内的浏览记录
+func viewCounts(from, to time.Time, limit int) ([]ProductViews, error) {
+	if config.MongoDB == nil {
+		return nil, ErrDisabled
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pipeline := []bson.M{
+		{"$match": bson.M{"day": dayRange(from, to)}},
+		{"$group": bson.M{
+			"_id":      "$product_id",
+			"views":    bson.M{"$sum": "$views"},
+			"visitors": bson.M{"$addToSet": "$user_id"},
+		}},
+		{"$addFields": bson.M{"visitors": bson.M{"$size": "$visitors"}}},
+		{"$sort": bson.D{{Key: "views", Value: -1}, {Key: "_id", Value: 1}}},
+		{"$limit": limit},
+	}
+
+	cursor, err := config.MongoDB.Collection(HistoryCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	stats := []ProductViews{}
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// TopViewed 浏览次数最多的商品，附带商品名称、封面、价格和状态
+func TopViewed(from, to time.Time, limit int) ([]ProductViews, error) {
+	stats, err := viewCounts(from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(stats))
+	for _, s := range stats {
+		ids = append(ids, s.ProductID)
+	}
+	var products []models.Product
+	if len(ids) > 0 {
+		if err := config.DB.Select("id", "name", "cover_image", "price", "status").Where("id IN ?", ids).Find(&products).Error; err != nil {
+			return nil, err
+		}
+	}
+	byID := make(map[uint]models.Product, len(products))
+	for _, p := range products {
+		byID[p.ID] = p
+	}
+	for i := range stats {
+		if p, ok := byID[stats[i].ProductID]; ok {
+			stats[i].Name = p.Name
+			stats[i].CoverImage = p.CoverImage
+			stats[i].Price = p.Price
+			stats[i].Status = p.Status
+		}
+	}
+	return stats, nil
+}
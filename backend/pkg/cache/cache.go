@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"go-flutter-mall/backend/config"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// NegativeTTL 不存在的记录 (如无效的商品 ID) 的缓存时间
+	NegativeTTL = time.Minute
+	// opTimeout 单次 Redis 操作的超时时间，超时按缓存未命中处理
+	opTimeout = 300 * time.Millisecond
+	// downBackoff Redis 出错后暂停读写缓存的时间，期间直接查询数据库，避免每个请求都等待 Redis 超时
+	downBackoff = 30 * time.Second
+	// doubleDeleteDelay 延迟双删: 失效后再删除一次，清除并发请求在失效前读到旧数据、失效后才写入的缓存
+	doubleDeleteDelay = time.Second
+	// notFoundValue 空结果标记 (不是合法的 JSON)
+	notFoundValue = "!not-found"
+)
+
+// ErrNotFound 加载函数返回该错误时缓存空结果，NegativeTTL 内再次请求直接返回该错误
+var ErrNotFound = errors.New("cache: record not found")
+
+var (
+	group     singleflight.Group
+	downUntil atomic.Int64 // Redis 暂停使用截止时间 (UnixNano)
+)
+
+// available 判断当前是否使用 Redis 缓存
+func available() bool {
+	return config.RedisClient != nil && time.Now().UnixNano() >= downUntil.Load()
+}
+
+// markDown Redis 出错后暂停使用缓存
+func markDown(err error) {
+	log.Printf("Redis cache unavailable, reading from database for %v: %v", downBackoff, err)
+	downUntil.Store(time.Now().Add(downBackoff).UnixNano())
+}
+
+// get 读取缓存，未命中或 Redis 不可用时返回 false
+func get(key string) (string, bool) {
+	if !available() {
+		return "", false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	data, err := config.RedisClient.Get(ctx, key).Result()
+	if err != nil {
+		if err != redis.Nil {
+			markDown(err)
+		}
+		return "", false
+	}
+	return data, true
+}
+
+// set 写入缓存，失败只暂停使用缓存
+func set(key string, value interface{}, ttl time.Duration) {
+	if !available() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	if err := config.RedisClient.Set(ctx, key, value, ttl).Err(); err != nil {
+		markDown(err)
+	}
+}
+
+// Fetch 读取 JSON 缓存，未命中时调用 load 加载并写入缓存 (有效期 ttl)
+// 同一 key 的并发加载通过 singleflight 合并为一次 (防止缓存击穿)，load 返回 ErrNotFound 时缓存空结果 (防止缓存穿透)。
+// Redis 不可用时直接调用 load。并发请求共享同一个返回值，调用方不应修改其中的切片和 map
+func Fetch[T any](key string, ttl time.Duration, load func() (T, error)) (T, error) {
+	var value T
+	if data, ok := get(key); ok {
+		if data == notFoundValue {
+			return value, ErrNotFound
+		}
+		if json.Unmarshal([]byte(data), &value) == nil {
+			return value, nil
+		}
+	}
+
+	v, err, _ := group.Do(key, func() (interface{}, error) {
+		loaded, err := load()
+		if errors.Is(err, ErrNotFound) {
+			set(key, notFoundValue, NegativeTTL)
+			return loaded, err
+		}
+		if err != nil {
+			return loaded, err
+		}
+		if data, err := json.Marshal(loaded); err == nil {
+			set(key, data, ttl)
+		}
+		return loaded, nil
+	})
+	value, _ = v.(T)
+	return value, err
+}
+
+// Delete 删除缓存，并在 1 秒后再删除一次 (延迟双删)
+// 失效操作不受暂停状态影响，总是尝试执行；Redis 不可用时缓存在有效期后自然过期
+func Delete(keys ...string) {
+	if config.RedisClient == nil || len(keys) == 0 {
+		return
+	}
+	del := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+		defer cancel()
+		if err := config.RedisClient.Del(ctx, keys...).Err(); err != nil {
+			log.Printf("Failed to invalidate cache %v: %v", keys, err)
+		}
+	}
+	del()
+	time.AfterFunc(doubleDeleteDelay, del)
+}
+
+// Versioned 在 key 后加上 versionKey 的当前版本号，版本号递增后旧版本的缓存全部失效 (等待过期)
+// Redis 不可用时返回不带版本号的 key (此时不会读写缓存)
+func Versioned(versionKey, key string) string {
+	if !available() {
+		return key
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	version, err := config.RedisClient.Get(ctx, versionKey).Result()
+	if err == redis.Nil {
+		version = "0"
+	} else if err != nil {
+		markDown(err)
+		return key
+	}
+	return key + ":v" + version
+}
+
+// Bump 递增版本号，使 Versioned 生成的旧缓存失效
+func Bump(versionKey string) {
+	if config.RedisClient == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opTimeout)
+	defer cancel()
+	if err := config.RedisClient.Incr(ctx, versionKey).Err(); err != nil {
+		log.Printf("Failed to bump cache version %s: %v", versionKey, err)
+	}
+}
+
+// idKey 拼接 key 前缀和记录 ID
+func idKey(prefix string, id uint) string {
+	return prefix + strconv.FormatUint(uint64(id), 10)
+}
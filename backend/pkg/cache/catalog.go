@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"time"
+)
+
+// 商品目录缓存的 key 和有效期
+const (
+	productKeyPrefix      = "cache:product:"
+	productListVersionKey = "cache:products:list:version"
+	CategoryTreeKey       = "cache:categories:tree"
+
+	ProductTTL      = 10 * time.Minute // 商品详情
+	ProductListTTL  = 2 * time.Minute  // 商品列表页
+	CategoryTreeTTL = 30 * time.Minute // 分类树
+
+	// MaxCachedListPage 只缓存前几页的商品列表 (热门页面)
+	MaxCachedListPage = 5
+)
+
+// ProductKey 商品详情的缓存 key
+func ProductKey(id uint) string {
+	return idKey(productKeyPrefix, id)
+}
+
+// ProductListKey 商品列表页的缓存 key，query 为规范化后的查询参数 (url.Values.Encode 按参数名排序)
+// 带列表版本号，任何商品或分类写入后递增版本号使全部列表页失效
+func ProductListKey(query string) string {
+	sum := sha1.Sum([]byte(query))
+	return Versioned(productListVersionKey, "cache:products:list:"+hex.EncodeToString(sum[:]))
+}
+
+// InvalidateProducts 商品、SKU、规格或库存写入后调用: 删除这些商品的详情缓存，并使全部列表页失效
+// 新建商品也需要调用，清除该 ID 之前可能缓存的空结果
+func InvalidateProducts(ids ...uint) {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, ProductKey(id))
+	}
+	Delete(keys...)
+	Bump(productListVersionKey)
+}
+
+// InvalidateCategories 分类写入后调用: 删除分类树缓存，并使全部列表页失效 (分面中的分类名称)
+func InvalidateCategories() {
+	Delete(CategoryTreeKey)
+	Bump(productListVersionKey)
+}
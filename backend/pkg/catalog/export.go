@@ -0,0 +1,91 @@
+package catalog
+
+import (
+	"strconv"
+	"strings"
+
+	"go-flutter-mall/backend/models"
+
+	"gorm.io/gorm"
+)
+
+// Export 导出全部商品 (含下架) 为表格行，格式与导入一致，可修改后重新导入
+// 每个 SKU 一行；没有 SKU 的商品输出一行空 SKU 字段。未设置编码的 SKU 需补充 sku_code 后才能导入
+func Export(db *gorm.DB) ([][]string, error) {
+	var products []models.Product
+	if err := db.Preload("SKUs", func(db *gorm.DB) *gorm.DB { return db.Order("id asc") }).
+		Preload("Attributes.Attribute").
+		Order("id asc").
+		Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	var brands []models.Brand
+	if err := db.Find(&brands).Error; err != nil {
+		return nil, err
+	}
+	brandNames := make(map[uint]string, len(brands))
+	for _, b := range brands {
+		brandNames[b.ID] = b.Name
+	}
+
+	// 属性列按首次出现的顺序追加在标准列之后
+	var attrNames []string
+	attrIndex := make(map[string]int)
+	for _, p := range products {
+		for _, v := range p.Attributes {
+			if v.Attribute == nil {
+				continue
+			}
+			if _, ok := attrIndex[v.Attribute.Name]; !ok {
+				attrIndex[v.Attribute.Name] = len(Columns) + len(attrNames)
+				attrNames = append(attrNames, v.Attribute.Name)
+			}
+		}
+	}
+
+	header := append([]string{}, Columns...)
+	for _, name := range attrNames {
+		header = append(header, AttributePrefix+name)
+	}
+	rows := [][]string{header}
+
+	col := make(map[string]int, len(Columns))
+	for i, name := range Columns {
+		col[name] = i
+	}
+	for _, p := range products {
+		base := make([]string, len(header))
+		base[col[ColProductName]] = p.Name
+		base[col[ColDescription]] = p.Description
+		base[col[ColCategoryID]] = strconv.FormatUint(uint64(p.CategoryID), 10)
+		base[col[ColBrand]] = brandNames[p.BrandID]
+		base[col[ColProductPrice]] = strconv.FormatFloat(p.Price, 'f', -1, 64)
+		base[col[ColProductStock]] = strconv.Itoa(p.Stock)
+		base[col[ColCoverImage]] = p.CoverImage
+		base[col[ColImages]] = strings.Join(p.Images, ImageSeparator)
+		base[col[ColWeight]] = strconv.FormatFloat(p.Weight, 'f', -1, 64)
+		base[col[ColStatus]] = strconv.Itoa(p.Status)
+		for _, v := range p.Attributes {
+			if v.Attribute != nil {
+				base[attrIndex[v.Attribute.Name]] = v.Value
+			}
+		}
+
+		if len(p.SKUs) == 0 {
+			rows = append(rows, base)
+			continue
+		}
+		for _, sku := range p.SKUs {
+			row := append([]string{}, base...)
+			row[col[ColSKUCode]] = sku.Code
+			row[col[ColSKUName]] = sku.Name
+			row[col[ColSpecs]] = sku.Specs
+			row[col[ColSKUPrice]] = strconv.FormatFloat(sku.Price, 'f', -1, 64)
+			row[col[ColSKUStock]] = strconv.Itoa(sku.Stock)
+			row[col[ColSKUImage]] = sku.Image
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
@@ -0,0 +1,600 @@
+package catalog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/attribute"
+	categorypkg "go-flutter-mall/backend/pkg/category"
+	"go-flutter-mall/backend/pkg/pricewatch"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// 表格列名，每行对应一个 SKU，product_name 相同的行属于同一个商品
+const (
+	ColSKUCode      = "sku_code"      // 外部 SKU 编码 (必填，导入按编码新增或更新)
+	ColProductName  = "product_name"  // 商品名称 (必填)
+	ColDescription  = "description"   // 商品描述
+	ColCategoryID   = "category_id"   // 分类 ID (必填)
+	ColBrand        = "brand"         // 品牌名称
+	ColProductPrice = "product_price" // 商品价格 (新商品必填)
+	ColProductStock = "product_stock" // 商品库存，新商品为空时取各 SKU 库存之和
+	ColCoverImage   = "cover_image"   // 封面图片
+	ColImages       = "images"        // 轮播图，多个以 | 分隔
+	ColWeight       = "weight"        // 重量 (kg)
+	ColStatus       = "status"        // 1-上架, 0-下架, 2-草稿
+	ColSKUName      = "sku_name"      // SKU 名称，默认为商品名称
+	ColSpecs        = "specs"         // 规格 JSON
+	ColSKUPrice     = "sku_price"     // SKU 价格 (必填)
+	ColSKUStock     = "sku_stock"     // SKU 库存
+	ColSKUImage     = "sku_image"     // SKU 图片
+
+	// AttributePrefix 分类属性列的前缀，如 "attr:存储容量"
+	AttributePrefix = "attr:"
+	// ImageSeparator 轮播图列中多个图片的分隔符
+	ImageSeparator = "|"
+)
+
+// Columns 导入导出的标准列顺序 (不含分类属性列)
+var Columns = []string{
+	ColSKUCode, ColProductName, ColDescription, ColCategoryID, ColBrand, ColProductPrice, ColProductStock,
+	ColCoverImage, ColImages, ColWeight, ColStatus, ColSKUName, ColSpecs, ColSKUPrice, ColSKUStock, ColSKUImage,
+}
+
+var requiredColumns = []string{ColSKUCode, ColProductName, ColCategoryID, ColSKUPrice}
+
+// MaxRows 单次导入的最大数据行数
+const MaxRows = 5000
+
+// 导入动作
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+)
+
+var (
+	ErrEmptyTable  = errors.New("file has no data rows")
+	ErrTooManyRows = fmt.Errorf("too many rows, at most %d per import", MaxRows)
+)
+
+// HeaderError 表头缺少必填列
+type HeaderError struct {
+	Missing []string
+}
+
+func (e *HeaderError) Error() string {
+	return "missing required columns: " + strings.Join(e.Missing, ", ")
+}
+
+// RowError 单行的校验错误，Row 为表格中的行号 (表头为第 1 行)
+type RowError struct {
+	Row     int    `json:"row"`
+	SKUCode string `json:"sku_code,omitempty"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// RowResult 单行将要执行 (试运行) 或已执行的变更
+type RowResult struct {
+	Row           int    `json:"row"`
+	SKUCode       string `json:"sku_code"`
+	ProductName   string `json:"product_name"`
+	ProductID     uint   `json:"product_id,omitempty"` // 已有商品的 ID；提交后新商品也会填充
+	ProductAction string `json:"product_action"`       // create / update
+	SKUAction     string `json:"sku_action"`           // create / update
+}
+
+// Report 导入结果报告
+type Report struct {
+	DryRun          bool        `json:"dry_run"`
+	Committed       bool        `json:"committed"` // 是否已写入数据库，存在错误时不写入任何数据
+	TotalRows       int         `json:"total_rows"`
+	ErrorRows       int         `json:"error_rows"`
+	ProductsCreated int         `json:"products_created"`
+	ProductsUpdated int         `json:"products_updated"`
+	SKUsCreated     int         `json:"skus_created"`
+	SKUsUpdated     int         `json:"skus_updated"`
+	Errors          []RowError  `json:"errors"`
+	Rows            []RowResult `json:"rows"`
+
+	// 提交后受影响的商品 ID，用于同步搜索索引
+	ProductIDs []uint `json:"-"`
+}
+
+// importRow 解析后的数据行
+type importRow struct {
+	line   int
+	cells  map[string]string
+	attrs  map[string]string // 属性名 -> 值，只包含非空单元格
+	failed bool
+
+	code       string
+	sku        *models.ProductSKU // 编码已存在时为对应的 SKU
+	categoryID uint
+	brandID    uint
+	price      *float64
+	stock      *int
+	weight     *float64
+	status     *int
+	skuPrice   float64
+	skuStock   int
+}
+
+// productPlan 同一商品的所有行及其变更
+type productPlan struct {
+	product models.Product
+	isNew   bool
+	rows    []*importRow
+	attrs   map[uint]string
+}
+
+// importer 一次导入的解析和校验状态
+type importer struct {
+	db         *gorm.DB
+	report     *Report
+	categories map[uint]bool
+	brands     map[string]uint
+	templates  map[uint][]models.CategoryAttribute
+}
+
+func (im *importer) fail(r *importRow, column, format string, args ...interface{}) {
+	if !r.failed {
+		r.failed = true
+		im.report.ErrorRows++
+	}
+	im.report.Errors = append(im.report.Errors, RowError{Row: r.line, SKUCode: r.code, Column: column, Message: fmt.Sprintf(format, args...)})
+}
+
+func (r *importRow) get(column string) string {
+	return strings.TrimSpace(r.cells[column])
+}
+
+// parseFloat 解析可选的数值列，空值返回 nil
+func (im *importer) parseFloat(r *importRow, column string) *float64 {
+	v := r.get(column)
+	if v == "" {
+		return nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f < 0 {
+		im.fail(r, column, "must be a non-negative number")
+		return nil
+	}
+	return &f
+}
+
+// parseInt 解析可选的整数列，空值返回 nil
+func (im *importer) parseInt(r *importRow, column string) *int {
+	v := r.get(column)
+	if v == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		im.fail(r, column, "must be a non-negative integer")
+		return nil
+	}
+	return &n
+}
+
+// parseRow 解析并校验单行中与其他行无关的字段
+func (im *importer) parseRow(r *importRow) {
+	if r.code == "" {
+		im.fail(r, ColSKUCode, "is required")
+	}
+	if r.get(ColProductName) == "" {
+		im.fail(r, ColProductName, "is required")
+	}
+
+	id, err := strconv.ParseUint(r.get(ColCategoryID), 10, 64)
+	if err != nil || !im.categories[uint(id)] {
+		im.fail(r, ColCategoryID, "category %q not found", r.get(ColCategoryID))
+	}
+	r.categoryID = uint(id)
+
+	if name := r.get(ColBrand); name != "" {
+		brandID, ok := im.brands[name]
+		if !ok {
+			im.fail(r, ColBrand, "brand %q not found", name)
+		}
+		r.brandID = brandID
+	}
+
+	r.price = im.parseFloat(r, ColProductPrice)
+	r.stock = im.parseInt(r, ColProductStock)
+	r.weight = im.parseFloat(r, ColWeight)
+	if v := r.get(ColStatus); v != "" {
+		if v != "0" && v != "1" && v != "2" {
+			im.fail(r, ColStatus, "must be 0, 1 or 2")
+		} else {
+			status, _ := strconv.Atoi(v)
+			r.status = &status
+		}
+	}
+
+	if specs := r.get(ColSpecs); specs != "" && !json.Valid([]byte(specs)) {
+		im.fail(r, ColSpecs, "must be valid JSON")
+	}
+	if r.get(ColSKUPrice) == "" {
+		im.fail(r, ColSKUPrice, "is required")
+	} else if price := im.parseFloat(r, ColSKUPrice); price != nil {
+		if *price <= 0 {
+			im.fail(r, ColSKUPrice, "must be greater than 0")
+		}
+		r.skuPrice = *price
+	}
+	if stock := im.parseInt(r, ColSKUStock); stock != nil {
+		r.skuStock = *stock
+	}
+}
+
+// templatesFor 带缓存地获取分类生效的属性模板
+func (im *importer) templatesFor(categoryID uint) ([]models.CategoryAttribute, error) {
+	if t, ok := im.templates[categoryID]; ok {
+		return t, nil
+	}
+	t, err := attribute.ForCategory(im.db, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	im.templates[categoryID] = t
+	return t, nil
+}
+
+// planProduct 根据同一商品的行确定目标商品、商品字段和属性值
+// 商品级字段取自该商品的第一行；更新已有商品时空单元格保持原值
+func (im *importer) planProduct(name string, rows []*importRow, products map[uint]models.Product) (*productPlan, error) {
+	plan := &productPlan{rows: rows}
+	first := rows[0]
+
+	// 已存在的 SKU 编码决定目标商品，同一商品名下的编码必须属于同一个已有商品
+	var productID uint
+	for _, r := range rows {
+		if r.sku == nil {
+			continue
+		}
+		if productID != 0 && r.sku.ProductID != productID {
+			im.fail(r, ColSKUCode, "belongs to product %d, but other rows of %q belong to product %d", r.sku.ProductID, name, productID)
+			continue
+		}
+		productID = r.sku.ProductID
+	}
+
+	if productID != 0 {
+		plan.product = products[productID]
+	} else {
+		plan.isNew = true
+		plan.product = models.Product{Status: models.ProductStatusOn}
+		if first.price == nil || *first.price <= 0 {
+			im.fail(first, ColProductPrice, "is required for a new product and must be greater than 0")
+		}
+		if first.stock == nil {
+			total := 0
+			for _, r := range rows {
+				total += r.skuStock
+			}
+			plan.product.Stock = total
+		}
+	}
+
+	p := &plan.product
+	p.Name = name
+	p.CategoryID = first.categoryID
+	if v := first.get(ColDescription); v != "" || plan.isNew {
+		p.Description = v
+	}
+	if first.brandID != 0 {
+		p.BrandID = first.brandID
+	}
+	if first.price != nil {
+		p.Price = *first.price
+	}
+	if first.stock != nil {
+		p.Stock = *first.stock
+	}
+	if v := first.get(ColCoverImage); v != "" {
+		p.CoverImage = v
+	}
+	if v := first.get(ColImages); v != "" {
+		var images []string
+		for _, image := range strings.Split(v, ImageSeparator) {
+			if image = strings.TrimSpace(image); image != "" {
+				images = append(images, image)
+			}
+		}
+		p.Images = pq.StringArray(images)
+	}
+	if first.weight != nil {
+		p.Weight = *first.weight
+	}
+	if first.status != nil {
+		p.Status = *first.status
+	}
+	if first.failed {
+		return plan, nil
+	}
+
+	// 分类属性: 已有商品在原值基础上覆盖非空的属性列，再按分类模板整体校验
+	templates, err := im.templatesFor(p.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[uint]string)
+	if !plan.isNew {
+		if values, err = attribute.Values(im.db, p.ID); err != nil {
+			return nil, err
+		}
+	}
+	byName := make(map[string]uint, len(templates))
+	for _, t := range templates {
+		byName[t.Name] = t.ID
+	}
+	for attrName, v := range first.attrs {
+		id, ok := byName[attrName]
+		if !ok {
+			im.fail(first, AttributePrefix+attrName, "is not an attribute of category %d", p.CategoryID)
+			continue
+		}
+		values[id] = v
+	}
+	if plan.attrs, err = attribute.Validate(templates, values); err != nil {
+		var ve *attribute.ValidationError
+		if errors.As(err, &ve) {
+			im.fail(first, AttributePrefix+ve.Attribute, "%s", ve.Reason)
+			return plan, nil
+		}
+		return nil, err
+	}
+	return plan, nil
+}
+
+// Import 校验表格并按 SKU 编码新增或更新商品和 SKU
+// 任一行校验失败时不写入任何数据；dryRun 为 true 时只返回将要执行的变更
+// 返回的 error 表示表格结构错误 (HeaderError、ErrEmptyTable、ErrTooManyRows) 或数据库错误
+func Import(db *gorm.DB, table [][]string, dryRun bool) (*Report, error) {
+	if len(table) < 2 {
+		return nil, ErrEmptyTable
+	}
+	if len(table)-1 > MaxRows {
+		return nil, ErrTooManyRows
+	}
+
+	header := make([]string, len(table[0]))
+	present := make(map[string]bool)
+	for i, h := range table[0] {
+		header[i] = strings.TrimSpace(h)
+		if !strings.HasPrefix(header[i], AttributePrefix) {
+			header[i] = strings.ToLower(header[i])
+		}
+		present[header[i]] = true
+	}
+	var missing []string
+	for _, col := range requiredColumns {
+		if !present[col] {
+			missing = append(missing, col)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, &HeaderError{Missing: missing}
+	}
+
+	report := &Report{DryRun: dryRun, Errors: []RowError{}, Rows: []RowResult{}}
+	im := &importer{db: db, report: report, categories: make(map[uint]bool), brands: make(map[string]uint), templates: make(map[uint][]models.CategoryAttribute)}
+
+	categories, err := categorypkg.LoadAll(db)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range categories {
+		im.categories[c.ID] = true
+	}
+	var brands []models.Brand
+	if err := db.Find(&brands).Error; err != nil {
+		return nil, err
+	}
+	for _, b := range brands {
+		im.brands[b.Name] = b.ID
+	}
+
+	// 解析数据行，跳过空行
+	var rows []*importRow
+	for i, record := range table[1:] {
+		r := &importRow{line: i + 2, cells: make(map[string]string), attrs: make(map[string]string)}
+		empty := true
+		for j, v := range record {
+			if j >= len(header) || header[j] == "" {
+				continue
+			}
+			if strings.TrimSpace(v) != "" {
+				empty = false
+			}
+			if name := strings.TrimPrefix(header[j], AttributePrefix); name != header[j] {
+				if v = strings.TrimSpace(v); v != "" {
+					r.attrs[name] = v
+				}
+				continue
+			}
+			r.cells[header[j]] = v
+		}
+		if empty {
+			continue
+		}
+		r.code = r.get(ColSKUCode)
+		rows = append(rows, r)
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmptyTable
+	}
+	report.TotalRows = len(rows)
+
+	seen := make(map[string]int)
+	var codes []string
+	for _, r := range rows {
+		im.parseRow(r)
+		if r.code == "" {
+			continue
+		}
+		if line, ok := seen[r.code]; ok {
+			im.fail(r, ColSKUCode, "duplicates row %d", line)
+			continue
+		}
+		seen[r.code] = r.line
+		codes = append(codes, r.code)
+	}
+
+	// 加载已存在的 SKU 及其商品
+	skus := make(map[string]models.ProductSKU)
+	products := make(map[uint]models.Product)
+	if len(codes) > 0 {
+		var list []models.ProductSKU
+		if err := db.Where("code IN ?", codes).Find(&list).Error; err != nil {
+			return nil, err
+		}
+		var productIDs []uint
+		for _, sku := range list {
+			skus[sku.Code] = sku
+			productIDs = append(productIDs, sku.ProductID)
+		}
+		if len(productIDs) > 0 {
+			var plist []models.Product
+			if err := db.Where("id IN ?", productIDs).Find(&plist).Error; err != nil {
+				return nil, err
+			}
+			for _, p := range plist {
+				products[p.ID] = p
+			}
+		}
+	}
+	for _, r := range rows {
+		if sku, ok := skus[r.code]; ok {
+			if _, ok := products[sku.ProductID]; !ok {
+				im.fail(r, ColSKUCode, "belongs to a deleted product")
+				continue
+			}
+			r.sku = &sku
+		}
+	}
+
+	// 按商品名称分组，保持在表格中首次出现的顺序
+	var names []string
+	groups := make(map[string][]*importRow)
+	for _, r := range rows {
+		name := r.get(ColProductName)
+		if name == "" {
+			continue
+		}
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+		}
+		groups[name] = append(groups[name], r)
+	}
+
+	var plans []*productPlan
+	for _, name := range names {
+		plan, err := im.planProduct(name, groups[name], products)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+
+		productAction := ActionUpdate
+		if plan.isNew {
+			productAction = ActionCreate
+			report.ProductsCreated++
+		} else {
+			report.ProductsUpdated++
+		}
+		for _, r := range plan.rows {
+			skuAction := ActionCreate
+			if r.sku != nil {
+				skuAction = ActionUpdate
+				report.SKUsUpdated++
+			} else {
+				report.SKUsCreated++
+			}
+			report.Rows = append(report.Rows, RowResult{
+				Row:           r.line,
+				SKUCode:       r.code,
+				ProductName:   name,
+				ProductID:     plan.product.ID,
+				ProductAction: productAction,
+				SKUAction:     skuAction,
+			})
+		}
+	}
+
+	if dryRun || report.ErrorRows > 0 {
+		return report, nil
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, plan := range plans {
+			// 已有商品记录修改前的价格，用于写入价格历史
+			var prices pricewatch.Snapshot
+			if plan.product.ID != 0 {
+				snapshot, err := pricewatch.Take(tx, plan.product.ID)
+				if err != nil {
+					return err
+				}
+				prices = snapshot
+			}
+			if err := tx.Save(&plan.product).Error; err != nil {
+				return err
+			}
+			if err := attribute.Save(tx, plan.product.ID, plan.attrs); err != nil {
+				return err
+			}
+			for _, r := range plan.rows {
+				sku := models.ProductSKU{ProductID: plan.product.ID, Code: r.code}
+				if r.sku != nil {
+					sku = *r.sku
+				}
+				sku.Name = r.get(ColSKUName)
+				if sku.Name == "" {
+					sku.Name = plan.product.Name
+				}
+				sku.Specs = r.get(ColSpecs)
+				if sku.Specs == "" {
+					sku.Specs = "{}"
+				}
+				sku.Price = r.skuPrice
+				sku.Stock = r.skuStock
+				if v := r.get(ColSKUImage); v != "" {
+					sku.Image = v
+				}
+				if err := tx.Save(&sku).Error; err != nil {
+					return err
+				}
+			}
+			if prices != nil {
+				if err := pricewatch.Record(tx, plan.product.ID, prices, models.PriceSourceImport); err != nil {
+					return err
+				}
+			}
+			report.ProductIDs = append(report.ProductIDs, plan.product.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 回填新商品的 ID
+	ids := make(map[int]uint)
+	for _, plan := range plans {
+		for _, r := range plan.rows {
+			ids[r.line] = plan.product.ID
+		}
+	}
+	for i := range report.Rows {
+		report.Rows[i].ProductID = ids[report.Rows[i].Row]
+	}
+	report.Committed = true
+	return report, nil
+}
@@ -0,0 +1,128 @@
+package catalog
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestImportTableStructure(t *testing.T) {
+	tooMany := [][]string{{ColSKUCode, ColProductName, ColCategoryID, ColSKUPrice}}
+	for i := 0; i <= MaxRows; i++ {
+		tooMany = append(tooMany, []string{"SKU", "Phone", "1", "10"})
+	}
+
+	tests := []struct {
+		name        string
+		table       [][]string
+		wantErr     error
+		wantMissing []string
+	}{
+		{"no rows", nil, ErrEmptyTable, nil},
+		{"header only", [][]string{{ColSKUCode}}, ErrEmptyTable, nil},
+		{"too many rows", tooMany, ErrTooManyRows, nil},
+		{
+			name:        "missing required columns",
+			table:       [][]string{{ColSKUCode, ColProductName}, {"SKU", "Phone"}},
+			wantMissing: []string{ColCategoryID, ColSKUPrice},
+		},
+		{
+			name:        "header is case insensitive",
+			table:       [][]string{{" SKU_CODE ", "Product_Name", "category_id"}, {"SKU", "Phone", "1"}},
+			wantMissing: []string{ColSKUPrice},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 表格结构错误在访问数据库之前返回
+			_, err := Import(nil, tt.table, true)
+			if tt.wantMissing != nil {
+				var headerErr *HeaderError
+				if !errors.As(err, &headerErr) || !reflect.DeepEqual(headerErr.Missing, tt.wantMissing) {
+					t.Fatalf("Import() error = %v, want missing %v", err, tt.wantMissing)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Import() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseRow(t *testing.T) {
+	valid := map[string]string{
+		ColSKUCode:      "SKU-1",
+		ColProductName:  "Phone",
+		ColCategoryID:   "3",
+		ColBrand:        "Acme",
+		ColProductPrice: "99.5",
+		ColProductStock: "10",
+		ColStatus:       "2",
+		ColSpecs:        `{"color":"red"}`,
+		ColSKUPrice:     "89",
+		ColSKUStock:     "4",
+	}
+	with := func(column, value string) map[string]string {
+		cells := make(map[string]string, len(valid))
+		for k, v := range valid {
+			cells[k] = v
+		}
+		cells[column] = value
+		return cells
+	}
+
+	tests := []struct {
+		name        string
+		cells       map[string]string
+		wantColumns []string // 出错的列，按报告顺序
+	}{
+		{"valid row", valid, nil},
+		{"optional columns may be empty", map[string]string{ColSKUCode: "SKU-1", ColProductName: "Phone", ColCategoryID: "3", ColSKUPrice: "89"}, nil},
+		{"missing sku code", with(ColSKUCode, ""), []string{ColSKUCode}},
+		{"missing product name", with(ColProductName, " "), []string{ColProductName}},
+		{"unknown category", with(ColCategoryID, "99"), []string{ColCategoryID}},
+		{"invalid category", with(ColCategoryID, "abc"), []string{ColCategoryID}},
+		{"unknown brand", with(ColBrand, "Nope"), []string{ColBrand}},
+		{"negative product price", with(ColProductPrice, "-1"), []string{ColProductPrice}},
+		{"fractional stock", with(ColProductStock, "1.5"), []string{ColProductStock}},
+		{"invalid status", with(ColStatus, "3"), []string{ColStatus}},
+		{"invalid specs", with(ColSpecs, "{color"), []string{ColSpecs}},
+		{"missing sku price", with(ColSKUPrice, ""), []string{ColSKUPrice}},
+		{"zero sku price", with(ColSKUPrice, "0"), []string{ColSKUPrice}},
+		{"negative sku stock", with(ColSKUStock, "-2"), []string{ColSKUStock}},
+		{"every error is reported", map[string]string{ColCategoryID: "99", ColSKUPrice: "x"}, []string{ColSKUCode, ColProductName, ColCategoryID, ColSKUPrice}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &Report{Errors: []RowError{}}
+			im := &importer{report: report, categories: map[uint]bool{3: true}, brands: map[string]uint{"Acme": 7}}
+			r := &importRow{line: 2, cells: tt.cells}
+			r.code = r.get(ColSKUCode)
+
+			im.parseRow(r)
+
+			var columns []string
+			for _, e := range report.Errors {
+				columns = append(columns, e.Column)
+			}
+			if !reflect.DeepEqual(columns, tt.wantColumns) {
+				t.Fatalf("parseRow() errors = %+v, want columns %v", report.Errors, tt.wantColumns)
+			}
+			if r.failed != (len(tt.wantColumns) > 0) {
+				t.Errorf("parseRow() failed = %v, want %v", r.failed, len(tt.wantColumns) > 0)
+			}
+			if r.failed && report.ErrorRows != 1 {
+				t.Errorf("parseRow() error rows = %d, want 1", report.ErrorRows)
+			}
+		})
+	}
+
+	// 校验通过时解析出各列的值
+	im := &importer{report: &Report{}, categories: map[uint]bool{3: true}, brands: map[string]uint{"Acme": 7}}
+	r := &importRow{line: 2, cells: valid, code: "SKU-1"}
+	im.parseRow(r)
+	if r.categoryID != 3 || r.brandID != 7 || *r.price != 99.5 || *r.stock != 10 || *r.status != 2 || r.skuPrice != 89 || r.skuStock != 4 {
+		t.Errorf("parseRow() parsed %+v", r)
+	}
+}
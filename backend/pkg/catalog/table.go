@@ -0,0 +1,104 @@
+package catalog
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// 支持的表格格式
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// sheetName 导出 XLSX 时使用的工作表名称
+const sheetName = "products"
+
+var ErrUnsupportedFormat = errors.New("unsupported file format, use .csv or .xlsx")
+
+// FormatOf 根据文件扩展名判断表格格式
+func FormatOf(filename string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".xlsx":
+		return FormatXLSX, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// ReadTable 读取 CSV 或 XLSX (第一个工作表) 的全部行，第一行为表头
+func ReadTable(format string, r io.Reader) ([][]string, error) {
+	switch format {
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1 // 允许各行列数不同，缺失的列按空值处理
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		// 去掉 Excel 另存为 CSV 时写入的 UTF-8 BOM
+		if len(rows) > 0 && len(rows[0]) > 0 {
+			rows[0][0] = strings.TrimPrefix(rows[0][0], "\ufeff")
+		}
+		return rows, nil
+	case FormatXLSX:
+		f, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		sheets := f.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, errors.New("workbook has no sheets")
+		}
+		return f.GetRows(sheets[0])
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// WriteTable 将行写为 CSV 或 XLSX
+func WriteTable(format string, w io.Writer, rows [][]string) error {
+	switch format {
+	case FormatCSV:
+		// 写入 BOM，使 Excel 正确识别 UTF-8 中文
+		if _, err := io.WriteString(w, "\ufeff"); err != nil {
+			return err
+		}
+		writer := csv.NewWriter(w)
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	case FormatXLSX:
+		f := excelize.NewFile()
+		defer f.Close()
+		if err := f.SetSheetName("Sheet1", sheetName); err != nil {
+			return err
+		}
+		stream, err := f.NewStreamWriter(sheetName)
+		if err != nil {
+			return err
+		}
+		for i, row := range rows {
+			cells := make([]interface{}, len(row))
+			for j, v := range row {
+				cells[j] = v
+			}
+			cell, _ := excelize.CoordinatesToCellName(1, i+1)
+			if err := stream.SetRow(cell, cells); err != nil {
+				return err
+			}
+		}
+		if err := stream.Flush(); err != nil {
+			return err
+		}
+		return f.Write(w)
+	}
+	return ErrUnsupportedFormat
+}
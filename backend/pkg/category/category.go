@@ -0,0 +1,121 @@
+package category
+
+import (
+	"sort"
+
+	"go-flutter-mall/backend/models"
+
+	"gorm.io/gorm"
+)
+
+// MaxLevel 分类树最大层级
+const MaxLevel = 3
+
+// LoadAll 按排序权重加载所有分类
+// 分类数量有限，树的构建和子孙查询都在内存中完成
+func LoadAll(db *gorm.DB) ([]models.Category, error) {
+	var list []models.Category
+	if err := db.Order("sort_order asc, id asc").Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// BuildTree 将扁平分类列表组装为树，同级按 SortOrder 排序
+// 父分类不存在的节点作为根节点返回，避免数据异常时丢失分类
+func BuildTree(list []models.Category) []models.Category {
+	children := make(map[uint][]models.Category)
+	exists := make(map[uint]bool, len(list))
+	for _, c := range list {
+		exists[c.ID] = true
+	}
+	for _, c := range list {
+		parent := c.ParentID
+		if !exists[parent] {
+			parent = 0
+		}
+		children[parent] = append(children[parent], c)
+	}
+
+	var build func(parent uint) []models.Category
+	build = func(parent uint) []models.Category {
+		nodes := children[parent]
+		sort.SliceStable(nodes, func(i, j int) bool {
+			return nodes[i].SortOrder < nodes[j].SortOrder
+		})
+		for i := range nodes {
+			nodes[i].Children = build(nodes[i].ID)
+		}
+		return nodes
+	}
+	tree := build(0)
+	if tree == nil {
+		tree = []models.Category{}
+	}
+	return tree
+}
+
+// Descendants 返回 id 的所有子孙分类 ID (不含自身)
+func Descendants(list []models.Category, id uint) []uint {
+	children := make(map[uint][]uint)
+	for _, c := range list {
+		children[c.ParentID] = append(children[c.ParentID], c.ID)
+	}
+
+	var ids []uint
+	queue := children[id]
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		ids = append(ids, current)
+		queue = append(queue, children[current]...)
+	}
+	return ids
+}
+
+// WithDescendants 返回 id 及其所有子孙分类 ID
+func WithDescendants(db *gorm.DB, id uint) ([]uint, error) {
+	list, err := LoadAll(db)
+	if err != nil {
+		return nil, err
+	}
+	return append([]uint{id}, Descendants(list, id)...), nil
+}
+
+// ExpandIDs 返回 ids 及其所有子孙分类 ID (去重)，用于优惠券和促销活动的分类适用范围
+func ExpandIDs(list []models.Category, ids []int64) []int64 {
+	seen := make(map[int64]bool)
+	var result []int64
+	for _, id := range ids {
+		for _, cid := range append([]uint{uint(id)}, Descendants(list, uint(id))...) {
+			if !seen[int64(cid)] {
+				seen[int64(cid)] = true
+				result = append(result, int64(cid))
+			}
+		}
+	}
+	return result
+}
+
+// Ancestors 返回 id 的所有祖先分类 ID，从父分类到一级分类
+func Ancestors(list []models.Category, id uint) []uint {
+	parents := make(map[uint]uint, len(list))
+	for _, c := range list {
+		parents[c.ID] = c.ParentID
+	}
+
+	var ids []uint
+	for current := parents[id]; current != 0 && len(ids) < MaxLevel; current = parents[current] {
+		ids = append(ids, current)
+	}
+	return ids
+}
+
+// WithAncestors 返回 id 及其所有祖先分类 ID
+func WithAncestors(db *gorm.DB, id uint) ([]uint, error) {
+	list, err := LoadAll(db)
+	if err != nil {
+		return nil, err
+	}
+	return append([]uint{id}, Ancestors(list, id)...), nil
+}
@@ -0,0 +1,59 @@
+package category
+
+import (
+	"reflect"
+	"testing"
+
+	"go-flutter-mall/backend/models"
+
+	"gorm.io/gorm"
+)
+
+// 测试用分类树: 1 > 2 > 4，1 > 3，5
+var testCategories = []models.Category{
+	{Model: gorm.Model{ID: 1}},
+	{Model: gorm.Model{ID: 2}, ParentID: 1},
+	{Model: gorm.Model{ID: 3}, ParentID: 1},
+	{Model: gorm.Model{ID: 4}, ParentID: 2},
+	{Model: gorm.Model{ID: 5}},
+}
+
+func TestDescendants(t *testing.T) {
+	tests := []struct {
+		name string
+		id   uint
+		want []uint
+	}{
+		{"root", 1, []uint{2, 3, 4}},
+		{"middle", 2, []uint{4}},
+		{"leaf", 4, nil},
+		{"unknown", 99, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Descendants(testCategories, tt.id); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Descendants(%d) = %v, want %v", tt.id, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		ids  []int64
+		want []int64
+	}{
+		{"root expands to subtree", []int64{1}, []int64{1, 2, 3, 4}},
+		{"overlapping ids are deduplicated", []int64{2, 1}, []int64{2, 4, 1, 3}},
+		{"separate trees", []int64{3, 5}, []int64{3, 5}},
+		{"empty", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExpandIDs(testCategories, tt.ids); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExpandIDs(%v) = %v, want %v", tt.ids, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package coupon
+
+import (
+	"errors"
+	"time"
+
+	"go-flutter-mall/backend/models"
+	categorypkg "go-flutter-mall/backend/pkg/category"
+	"go-flutter-mall/backend/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrCouponNotFound    = errors.New("Coupon not found")
+	ErrCouponUnavailable = errors.New("Coupon is not available")
+	ErrCouponExpired     = errors.New("Coupon has expired")
+	ErrCouponUsed        = errors.New("Coupon has already been used")
+	ErrCouponSoldOut     = errors.New("Coupon has been fully claimed")
+	ErrCouponLimit       = errors.New("Coupon claim limit reached")
+	ErrCouponNotApplied  = errors.New("Coupon does not apply to selected items")
+	ErrCouponThreshold   = errors.New("Order amount does not reach coupon threshold")
+)
+
+// Item 参与优惠券计算的商品行
+type Item struct {
+	ProductID  uint
+	CategoryID uint
+	Amount     float64 // 该行应付金额 (单价 * 数量)
+}
+
+// inScope 判断商品行是否在模板的适用范围内，分类范围需先经 expandCategoryScope 展开到子分类
+func inScope(tpl *models.CouponTemplate, item Item) bool {
+	switch tpl.ScopeType {
+	case models.ScopeCategory:
+		for _, id := range tpl.ScopeIDs {
+			if uint(id) == item.CategoryID {
+				return true
+			}
+		}
+		return false
+	case models.ScopeProduct:
+		for _, id := range tpl.ScopeIDs {
+			if uint(id) == item.ProductID {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// expandCategoryScope 将分类范围展开为所选分类及其所有子孙分类，适用于父分类的券对子分类商品同样生效
+func expandCategoryScope(db *gorm.DB, tpl *models.CouponTemplate) error {
+	if tpl.ScopeType != models.ScopeCategory || len(tpl.ScopeIDs) == 0 {
+		return nil
+	}
+	list, err := categorypkg.LoadAll(db)
+	if err != nil {
+		return err
+	}
+	tpl.ScopeIDs = categorypkg.ExpandIDs(list, tpl.ScopeIDs)
+	return nil
+}
+
+// Calculate 计算优惠券对给定商品行可抵扣的金额
+// shippingFee 为当前运费，仅包邮券使用
+func Calculate(tpl *models.CouponTemplate, items []Item, shippingFee float64) (float64, error) {
+	var eligible float64
+	for _, item := range items {
+		if inScope(tpl, item) {
+			eligible += item.Amount
+		}
+	}
+
+	if eligible <= 0 {
+		return 0, ErrCouponNotApplied
+	}
+	if eligible < tpl.MinAmount {
+		return 0, ErrCouponThreshold
+	}
+
+	var discount float64
+	switch tpl.Type {
+	case models.CouponTypeFixed, models.CouponTypeThreshold:
+		discount = tpl.Amount
+	case models.CouponTypePercent:
+		discount = eligible * (1 - tpl.Rate)
+		if tpl.MaxDiscount > 0 && discount > tpl.MaxDiscount {
+			discount = tpl.MaxDiscount
+		}
+	case models.CouponTypeFreeShipping:
+		return utils.RoundAmount(shippingFee), nil
+	}
+
+	// 抵扣金额不能超过适用商品金额
+	if discount > eligible {
+		discount = eligible
+	}
+	return utils.RoundAmount(discount), nil
+}
+
+// Claim 用户领取优惠券
+// 通过对模板行加锁保证发行总量和每人限领不会被并发突破
+func Claim(tx *gorm.DB, userID uint, templateID uint) (*models.UserCoupon, error) {
+	var tpl models.CouponTemplate
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&tpl, templateID).Error; err != nil {
+		return nil, ErrCouponNotFound
+	}
+
+	now := time.Now()
+	if tpl.Status != 1 || now.Before(tpl.StartAt) || now.After(tpl.EndAt) {
+		return nil, ErrCouponUnavailable
+	}
+	if tpl.TotalCount > 0 && tpl.IssuedCount >= tpl.TotalCount {
+		return nil, ErrCouponSoldOut
+	}
+
+	var claimed int64
+	if err := tx.Model(&models.UserCoupon{}).Where("user_id = ? AND template_id = ?", userID, tpl.ID).Count(&claimed).Error; err != nil {
+		return nil, err
+	}
+	if tpl.PerUserLimit > 0 && int(claimed) >= tpl.PerUserLimit {
+		return nil, ErrCouponLimit
+	}
+
+	expireAt := tpl.EndAt
+	if tpl.ValidDays > 0 {
+		expireAt = now.AddDate(0, 0, tpl.ValidDays)
+	}
+
+	userCoupon := models.UserCoupon{
+		UserID:     userID,
+		TemplateID: tpl.ID,
+		Status:     0,
+		ExpireAt:   expireAt,
+	}
+	if err := tx.Create(&userCoupon).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Model(&tpl).UpdateColumn("issued_count", gorm.Expr("issued_count + 1")).Error; err != nil {
+		return nil, err
+	}
+
+	userCoupon.Template = tpl
+	return &userCoupon, nil
+}
+
+// Validate 校验用户优惠券是否可用于给定商品行，返回可抵扣金额
+func Validate(tx *gorm.DB, userID uint, userCouponID uint, items []Item, shippingFee float64) (*models.UserCoupon, float64, error) {
+	var userCoupon models.UserCoupon
+	// 模板停用或删除只影响领取，已领取的券仍可使用，因此这里不过滤已删除的模板
+	if err := tx.Preload("Template", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("id = ? AND user_id = ?", userCouponID, userID).First(&userCoupon).Error; err != nil {
+		return nil, 0, ErrCouponNotFound
+	}
+
+	if userCoupon.Status != 0 {
+		return nil, 0, ErrCouponUsed
+	}
+	now := time.Now()
+	if now.After(userCoupon.ExpireAt) {
+		return nil, 0, ErrCouponExpired
+	}
+	if now.Before(userCoupon.Template.StartAt) {
+		return nil, 0, ErrCouponUnavailable
+	}
+
+	// 展开到模板副本上，返回的 userCoupon 保持原始的适用范围
+	tpl := userCoupon.Template
+	if err := expandCategoryScope(tx, &tpl); err != nil {
+		return nil, 0, err
+	}
+	discount, err := Calculate(&tpl, items, shippingFee)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &userCoupon, discount, nil
+}
+
+// Redeem 核销优惠券
+// 使用带状态条件的 UPDATE 保证同一张券只能被一个订单使用
+func Redeem(tx *gorm.DB, userID uint, userCouponID uint, orderID uint) error {
+	now := time.Now()
+	result := tx.Model(&models.UserCoupon{}).
+		Where("id = ? AND user_id = ? AND status = ? AND expire_at > ?", userCouponID, userID, 0, now).
+		Updates(map[string]interface{}{"status": 1, "order_id": orderID, "used_at": now})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCouponUsed
+	}
+	return nil
+}
+
+// Release 退还订单使用的优惠券 (订单取消、超时或退款)
+func Release(tx *gorm.DB, orderID uint) error {
+	return tx.Model(&models.UserCoupon{}).
+		Where("order_id = ? AND status = ?", orderID, 1).
+		Updates(map[string]interface{}{"status": 0, "order_id": 0, "used_at": nil}).Error
+}
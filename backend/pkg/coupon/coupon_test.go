@@ -0,0 +1,112 @@
+package coupon
+
+import (
+	"errors"
+	"testing"
+
+	"go-flutter-mall/backend/models"
+	categorypkg "go-flutter-mall/backend/pkg/category"
+
+	"gorm.io/gorm"
+)
+
+func TestCalculate(t *testing.T) {
+	items := []Item{
+		{ProductID: 1, CategoryID: 5, Amount: 60},
+		{ProductID: 2, CategoryID: 6, Amount: 100},
+	}
+	tests := []struct {
+		name        string
+		tpl         models.CouponTemplate
+		items       []Item
+		shippingFee float64
+		want        float64
+		wantErr     error
+	}{
+		{
+			name:  "fixed",
+			tpl:   models.CouponTemplate{Type: models.CouponTypeFixed, Amount: 20, ScopeType: models.ScopeAll},
+			items: items,
+			want:  20,
+		},
+		{
+			name:  "fixed is capped at the eligible amount",
+			tpl:   models.CouponTemplate{Type: models.CouponTypeFixed, Amount: 80, ScopeType: models.ScopeProduct, ScopeIDs: []int64{1}},
+			items: items,
+			want:  60,
+		},
+		{
+			name:  "threshold reached",
+			tpl:   models.CouponTemplate{Type: models.CouponTypeThreshold, Amount: 30, MinAmount: 150, ScopeType: models.ScopeAll},
+			items: items,
+			want:  30,
+		},
+		{
+			name:    "threshold only counts items in scope",
+			tpl:     models.CouponTemplate{Type: models.CouponTypeThreshold, Amount: 10, MinAmount: 100, ScopeType: models.ScopeProduct, ScopeIDs: []int64{1}},
+			items:   items,
+			wantErr: ErrCouponThreshold,
+		},
+		{
+			name:  "percent",
+			tpl:   models.CouponTemplate{Type: models.CouponTypePercent, Rate: 0.9, ScopeType: models.ScopeAll},
+			items: items,
+			want:  16,
+		},
+		{
+			name:  "percent is capped by max discount",
+			tpl:   models.CouponTemplate{Type: models.CouponTypePercent, Rate: 0.8, MaxDiscount: 25, ScopeType: models.ScopeAll},
+			items: items,
+			want:  25,
+		},
+		{
+			name:        "free shipping deducts the shipping fee",
+			tpl:         models.CouponTemplate{Type: models.CouponTypeFreeShipping, ScopeType: models.ScopeAll},
+			items:       items,
+			shippingFee: 12,
+			want:        12,
+		},
+		{
+			name:  "category scope",
+			tpl:   models.CouponTemplate{Type: models.CouponTypeFixed, Amount: 10, ScopeType: models.ScopeCategory, ScopeIDs: []int64{6}},
+			items: items,
+			want:  10,
+		},
+		{
+			name:    "no item in scope",
+			tpl:     models.CouponTemplate{Type: models.CouponTypeFixed, Amount: 10, ScopeType: models.ScopeCategory, ScopeIDs: []int64{7}},
+			items:   items,
+			wantErr: ErrCouponNotApplied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Calculate(&tt.tpl, tt.items, tt.shippingFee)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Calculate() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Calculate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateExpandedCategoryScope(t *testing.T) {
+	// 1 > 5 > 8: 适用于一级分类 1 的券对三级分类 8 的商品同样生效
+	list := []models.Category{
+		{Model: gorm.Model{ID: 1}},
+		{Model: gorm.Model{ID: 5}, ParentID: 1},
+		{Model: gorm.Model{ID: 8}, ParentID: 5},
+		{Model: gorm.Model{ID: 9}},
+	}
+	tpl := models.CouponTemplate{Type: models.CouponTypeFixed, Amount: 10, ScopeType: models.ScopeCategory, ScopeIDs: []int64{1}}
+	tpl.ScopeIDs = categorypkg.ExpandIDs(list, tpl.ScopeIDs)
+
+	if got, err := Calculate(&tpl, []Item{{ProductID: 1, CategoryID: 8, Amount: 50}}, 0); err != nil || got != 10 {
+		t.Errorf("Calculate() on subcategory item = %v, %v, want 10, nil", got, err)
+	}
+	if _, err := Calculate(&tpl, []Item{{ProductID: 2, CategoryID: 9, Amount: 50}}, 0); !errors.Is(err, ErrCouponNotApplied) {
+		t.Errorf("Calculate() on unrelated category error = %v, want %v", err, ErrCouponNotApplied)
+	}
+}
@@ -7,9 +7,12 @@ import (
 
 	"go-flutter-mall/backend/config"
 	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/cache"
+	"go-flutter-mall/backend/pkg/coupon"
+	"go-flutter-mall/backend/pkg/points"
+	"go-flutter-mall/backend/pkg/stock"
 
 	"github.com/IBM/sarama"
-	"gorm.io/gorm"
 )
 
 // OrderEvent 订单事件消息结构
@@ -123,14 +126,37 @@ func handleOrderTimeout(event OrderEvent) {
 		// 需要预加载 Items 以获取 ProductID 和 Quantity
 		var orderWithItems models.Order
 		if err := tx.Preload("Items").First(&orderWithItems, order.ID).Error; err == nil {
-			for _, item := range orderWithItems.Items {
-				// 增加库存
-				tx.Model(&models.Product{}).Where("id = ?", item.ProductID).UpdateColumn("stock", gorm.Expr("stock + ?", item.Quantity))
+			// 增加商品及 SKU 库存
+			if err := stock.Restore(tx, orderWithItems.Items); err != nil {
+				tx.Rollback()
+				log.Printf("Failed to restore stock for order %d: %v", event.OrderID, err)
+				return
 			}
 		}
 
+		// 退还优惠券
+		if err := coupon.Release(tx, order.ID); err != nil {
+			tx.Rollback()
+			log.Printf("Failed to return coupon for order %d: %v", event.OrderID, err)
+			return
+		}
+
+		// 退还抵扣的积分
+		if err := points.Release(tx, &order); err != nil {
+			tx.Rollback()
+			log.Printf("Failed to return points for order %d: %v", event.OrderID, err)
+			return
+		}
+
 		tx.Commit()
 
+		// 库存已恢复，清除商品缓存
+		productIDs := make([]uint, 0, len(orderWithItems.Items))
+		for _, item := range orderWithItems.Items {
+			productIDs = append(productIDs, item.ProductID)
+		}
+		cache.InvalidateProducts(productIDs...)
+
 		// 2. 发送消息通知
 		notification := models.Notification{
 			UserID:  event.UserID,
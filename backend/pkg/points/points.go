@@ -0,0 +1,205 @@
+package points
+
+import (
+	"errors"
+	"math"
+
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInsufficientPoints = errors.New("Insufficient points")
+	ErrInvalidPoints      = errors.New("Points must be a positive number")
+)
+
+const (
+	EarnRate       = 1   // 每实付 1 元获得的积分
+	ReviewReward   = 10  // 评价订单获得的积分
+	PointsPerYuan  = 100 // 抵扣时 100 积分 = 1 元
+	MaxDeductRatio = 0.5 // 积分最多抵扣应付金额的比例
+)
+
+// Level 会员等级
+type Level struct {
+	Level        int     `json:"level"`         // 等级
+	Name         string  `json:"name"`          // 等级名称
+	MinSpend     float64 `json:"min_spend"`     // 达到该等级所需的累计消费
+	DiscountRate float64 `json:"discount_rate"` // 会员折扣，1 表示无折扣
+	FreeShipping bool    `json:"free_shipping"` // 是否包邮
+}
+
+// Levels 会员等级表，按 MinSpend 升序排列
+var Levels = []Level{
+	{Level: 0, Name: "普通会员", MinSpend: 0, DiscountRate: 1},
+	{Level: 1, Name: "白银会员", MinSpend: 1000, DiscountRate: 0.98},
+	{Level: 2, Name: "黄金会员", MinSpend: 5000, DiscountRate: 0.95, FreeShipping: true},
+	{Level: 3, Name: "钻石会员", MinSpend: 20000, DiscountRate: 0.9, FreeShipping: true},
+}
+
+// LevelFor 根据累计消费计算会员等级
+func LevelFor(spend float64) Level {
+	level := Levels[0]
+	for _, l := range Levels {
+		if spend >= l.MinSpend {
+			level = l
+		}
+	}
+	return level
+}
+
+// NextLevel 返回下一个等级，已是最高等级时返回 nil
+func NextLevel(spend float64) *Level {
+	for i := range Levels {
+		if spend < Levels[i].MinSpend {
+			return &Levels[i]
+		}
+	}
+	return nil
+}
+
+// Value 积分可抵扣的金额
+func Value(points int) float64 {
+	return utils.RoundAmount(float64(points) / PointsPerYuan)
+}
+
+// MaxDeductible 给定应付金额最多可使用的积分
+func MaxDeductible(amount float64) int {
+	if amount <= 0 {
+		return 0
+	}
+	return int(math.Floor(amount * MaxDeductRatio * PointsPerYuan))
+}
+
+// FindAccount 查询用户积分账户，不存在时返回空账户 (不创建)
+func FindAccount(db *gorm.DB, userID uint) (models.PointsAccount, error) {
+	account := models.PointsAccount{UserID: userID}
+	if err := db.Where("user_id = ?", userID).Limit(1).Find(&account).Error; err != nil {
+		return account, err
+	}
+	return account, nil
+}
+
+// lockAccount 获取并锁定用户积分账户，不存在时先创建
+func lockAccount(tx *gorm.DB, userID uint) (*models.PointsAccount, error) {
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.PointsAccount{UserID: userID}).Error; err != nil {
+		return nil, err
+	}
+	var account models.PointsAccount
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", userID).First(&account).Error; err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// apply 在事务中变更积分余额和累计消费，并写入流水
+// change 为 0 时只更新累计消费，不写流水
+func apply(tx *gorm.DB, userID uint, change int, txType string, orderID uint, remark string, spend float64) (*models.PointsTransaction, error) {
+	account, err := lockAccount(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Balance+change < 0 {
+		return nil, ErrInsufficientPoints
+	}
+
+	updates := map[string]interface{}{"balance": account.Balance + change}
+	switch txType {
+	case models.PointsEarnOrder, models.PointsEarnReview, models.PointsReverseOrder:
+		updates["total_earned"] = account.TotalEarned + change
+	}
+	if spend != 0 {
+		updates["cumulative_spend"] = math.Max(0, utils.RoundAmount(account.CumulativeSpend+spend))
+	}
+	if err := tx.Model(account).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+
+	if change == 0 {
+		return nil, nil
+	}
+	record := models.PointsTransaction{
+		UserID:  userID,
+		Type:    txType,
+		Change:  change,
+		Balance: account.Balance + change,
+		OrderID: orderID,
+		Remark:  remark,
+	}
+	if err := tx.Create(&record).Error; err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Adjust 管理员调整积分，change 可为负数
+func Adjust(tx *gorm.DB, userID uint, change int, remark string) (*models.PointsTransaction, error) {
+	if change == 0 {
+		return nil, ErrInvalidPoints
+	}
+	return apply(tx, userID, change, models.PointsAdminAdjust, 0, remark, 0)
+}
+
+// Spend 下单时抵扣积分
+func Spend(tx *gorm.DB, userID uint, points int, orderID uint) error {
+	if points <= 0 {
+		return nil
+	}
+	_, err := apply(tx, userID, -points, models.PointsSpendOrder, orderID, "下单抵扣", 0)
+	return err
+}
+
+// EarnForOrder 订单支付后按实付金额发放积分，并累计消费金额
+func EarnForOrder(tx *gorm.DB, order *models.Order) error {
+	earned := int(math.Floor(order.TotalAmount * EarnRate))
+	_, err := apply(tx, order.UserID, earned, models.PointsEarnOrder, order.ID, "订单支付奖励", order.TotalAmount)
+	return err
+}
+
+// EarnForReview 评价订单奖励积分
+func EarnForReview(tx *gorm.DB, userID uint, orderID uint) error {
+	_, err := apply(tx, userID, ReviewReward, models.PointsEarnReview, orderID, "评价奖励", 0)
+	return err
+}
+
+// Release 未支付订单取消时退还抵扣的积分
+func Release(tx *gorm.DB, order *models.Order) error {
+	return returnUsed(tx, order, "订单取消退还")
+}
+
+// returnUsed 退还订单抵扣的积分
+func returnUsed(tx *gorm.DB, order *models.Order, remark string) error {
+	if order.PointsUsed <= 0 {
+		return nil
+	}
+	_, err := apply(tx, order.UserID, order.PointsUsed, models.PointsReturnOrder, order.ID, remark, 0)
+	return err
+}
+
+// Refund 订单退款时退还抵扣的积分，扣回该订单获得的积分并扣减累计消费
+// 已获得的积分若已被使用，最多扣至余额为 0
+func Refund(tx *gorm.DB, order *models.Order) error {
+	if err := returnUsed(tx, order, "订单退款退还"); err != nil {
+		return err
+	}
+
+	var earned int
+	if err := tx.Model(&models.PointsTransaction{}).
+		Where("order_id = ? AND type = ?", order.ID, models.PointsEarnOrder).
+		Select("COALESCE(SUM(change), 0)").Scan(&earned).Error; err != nil {
+		return err
+	}
+
+	account, err := lockAccount(tx, order.UserID)
+	if err != nil {
+		return err
+	}
+	if earned > account.Balance {
+		earned = account.Balance
+	}
+	_, err = apply(tx, order.UserID, -earned, models.PointsReverseOrder, order.ID, "订单退款扣回", -order.TotalAmount)
+	return err
+}
@@ -0,0 +1,66 @@
+package points
+
+import "testing"
+
+func TestLevelFor(t *testing.T) {
+	tests := []struct {
+		spend     float64
+		wantLevel int
+		wantNext  int // -1 表示已是最高等级
+	}{
+		{0, 0, 1},
+		{999.99, 0, 1},
+		{1000, 1, 2},
+		{4999, 1, 2},
+		{5000, 2, 3},
+		{19999.99, 2, 3},
+		{20000, 3, -1},
+		{1000000, 3, -1},
+	}
+	for _, tt := range tests {
+		if got := LevelFor(tt.spend); got.Level != tt.wantLevel {
+			t.Errorf("LevelFor(%v) = %d, want %d", tt.spend, got.Level, tt.wantLevel)
+		}
+		next := NextLevel(tt.spend)
+		if tt.wantNext < 0 {
+			if next != nil {
+				t.Errorf("NextLevel(%v) = %d, want nil", tt.spend, next.Level)
+			}
+		} else if next == nil || next.Level != tt.wantNext {
+			t.Errorf("NextLevel(%v) = %v, want %d", tt.spend, next, tt.wantNext)
+		}
+	}
+}
+
+func TestValueAndMaxDeductible(t *testing.T) {
+	tests := []struct {
+		points int
+		want   float64
+	}{
+		{0, 0},
+		{1, 0.01},
+		{150, 1.5},
+		{10000, 100},
+	}
+	for _, tt := range tests {
+		if got := Value(tt.points); got != tt.want {
+			t.Errorf("Value(%d) = %v, want %v", tt.points, got, tt.want)
+		}
+	}
+
+	deductible := []struct {
+		amount float64
+		want   int
+	}{
+		{0, 0},
+		{-10, 0},
+		{100, 5000},
+		{0.03, 1},
+		{99.99, 4999},
+	}
+	for _, tt := range deductible {
+		if got := MaxDeductible(tt.amount); got != tt.want {
+			t.Errorf("MaxDeductible(%v) = %d, want %d", tt.amount, got, tt.want)
+		}
+	}
+}
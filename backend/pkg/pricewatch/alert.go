@@ -0,0 +1,82 @@
+package pricewatch
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/websocket"
+
+	"gorm.io/gorm"
+)
+
+// LowestPrice 商品的最低售价: 有 SKU 时为 SKU 最低价，否则为商品基础价格
+func LowestPrice(product *models.Product) float64 {
+	if len(product.SKUs) == 0 {
+		return product.Price
+	}
+	lowest := product.SKUs[0].Price
+	for _, sku := range product.SKUs[1:] {
+		if sku.Price < lowest {
+			lowest = sku.Price
+		}
+	}
+	return lowest
+}
+
+// CurrentPrice 读取商品及其 SKU 并返回最低售价
+func CurrentPrice(db *gorm.DB, productID uint) (*models.Product, float64, error) {
+	var product models.Product
+	if err := db.Preload("SKUs").First(&product, productID).Error; err != nil {
+		return nil, 0, err
+	}
+	return &product, LowestPrice(&product), nil
+}
+
+// CheckAlerts 商品价格修改后检查降价提醒，应在事务提交后调用
+// 最低价低于提醒价格的订阅会收到一条消息通知和 WebSocket 推送，并将提醒价格更新为当前价格；
+// 下架或草稿商品不提醒。失败只记录日志，不影响商品写入
+func CheckAlerts(db *gorm.DB, productID uint) {
+	product, price, err := CurrentPrice(db, productID)
+	if err != nil {
+		log.Printf("Failed to load product %d for price alerts: %v", productID, err)
+		return
+	}
+	if product.Status != models.ProductStatusOn {
+		return
+	}
+
+	var alerts []models.PriceAlert
+	if err := db.Where("product_id = ? AND target_price > ?", productID, price).Find(&alerts).Error; err != nil {
+		log.Printf("Failed to fetch price alerts of product %d: %v", productID, err)
+		return
+	}
+
+	now := time.Now()
+	for _, alert := range alerts {
+		// 条件更新，并发修改价格时同一次降价只提醒一次
+		result := db.Model(&models.PriceAlert{}).
+			Where("id = ? AND target_price > ?", alert.ID, price).
+			Updates(map[string]interface{}{"target_price": price, "notified_at": now})
+		if result.Error != nil {
+			log.Printf("Failed to update price alert %d: %v", alert.ID, result.Error)
+			continue
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		notification := models.Notification{
+			UserID:  alert.UserID,
+			Title:   "降价提醒",
+			Content: fmt.Sprintf("您关注的商品「%s」降价了，现价 ¥%.2f，比提醒价格低 ¥%.2f。", product.Name, price, alert.TargetPrice-price),
+			IsRead:  false,
+		}
+		if err := db.Create(&notification).Error; err != nil {
+			log.Printf("Failed to create price alert notification for user %d: %v", alert.UserID, err)
+			continue
+		}
+		websocket.PushNotification(&notification)
+	}
+}
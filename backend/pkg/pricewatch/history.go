@@ -0,0 +1,58 @@
+package pricewatch
+
+import (
+	"go-flutter-mall/backend/models"
+
+	"gorm.io/gorm"
+)
+
+// Snapshot 商品基础价格和各 SKU 价格，key 为 SKU ID (0 表示商品基础价格)
+type Snapshot map[uint]float64
+
+// Take 在修改价格前读取商品当前价格 (应与修改在同一事务中调用)
+func Take(tx *gorm.DB, productID uint) (Snapshot, error) {
+	snapshot := make(Snapshot)
+
+	var product models.Product
+	if err := tx.Select("id", "price").First(&product, productID).Error; err != nil {
+		return nil, err
+	}
+	snapshot[0] = product.Price
+
+	var skus []models.ProductSKU
+	if err := tx.Select("id", "price").Where("product_id = ?", productID).Find(&skus).Error; err != nil {
+		return nil, err
+	}
+	for _, sku := range skus {
+		snapshot[sku.ID] = sku.Price
+	}
+	return snapshot, nil
+}
+
+// Record 对比修改前的价格，为每个价格有变化的商品或 SKU 写入一条价格历史
+// 新增的 SKU 不记录 (没有原价)，删除的 SKU 不记录
+func Record(tx *gorm.DB, productID uint, before Snapshot, source string) error {
+	after, err := Take(tx, productID)
+	if err != nil {
+		return err
+	}
+
+	var changes []models.PriceHistory
+	for skuID, newPrice := range after {
+		oldPrice, ok := before[skuID]
+		if !ok || oldPrice == newPrice {
+			continue
+		}
+		changes = append(changes, models.PriceHistory{
+			ProductID: productID,
+			SKUID:     skuID,
+			OldPrice:  oldPrice,
+			NewPrice:  newPrice,
+			Source:    source,
+		})
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return tx.Create(&changes).Error
+}
@@ -0,0 +1,64 @@
+package pricing
+
+import (
+	"go-flutter-mall/backend/models"
+
+	"gorm.io/gorm"
+)
+
+// 购物车项不可购买的原因
+const (
+	UnavailableProductRemoved = "product_removed" // 商品已删除
+	UnavailableOffShelf       = "off_shelf"       // 商品已下架
+	UnavailableSKURemoved     = "sku_removed"     // 规格已删除或不属于该商品
+)
+
+// MarkAvailability 检查购物车项对应的商品和 SKU 是否仍可购买，并填充 Available / UnavailableReason
+// items 需预加载 Product；商品被删除时预加载结果为空 (ID 为 0)
+func MarkAvailability(db *gorm.DB, items []models.CartItem) error {
+	var skuIDs []uint
+	for _, item := range items {
+		if item.SKUID != 0 {
+			skuIDs = append(skuIDs, item.SKUID)
+		}
+	}
+	skus := make(map[uint]models.ProductSKU)
+	if len(skuIDs) > 0 {
+		var list []models.ProductSKU
+		if err := db.Where("id IN ?", skuIDs).Find(&list).Error; err != nil {
+			return err
+		}
+		for _, sku := range list {
+			skus[sku.ID] = sku
+		}
+	}
+
+	for i := range items {
+		item := &items[i]
+		item.Available = false
+		switch sku, ok := skus[item.SKUID]; {
+		case item.Product.ID == 0:
+			item.UnavailableReason = UnavailableProductRemoved
+		case item.Product.Status != models.ProductStatusOn:
+			item.UnavailableReason = UnavailableOffShelf
+		case item.SKUID != 0 && (!ok || sku.ProductID != item.ProductID):
+			item.UnavailableReason = UnavailableSKURemoved
+		default:
+			item.Available = true
+			item.UnavailableReason = ""
+		}
+	}
+	return nil
+}
+
+// AvailableItems 返回可购买的购物车项，以及不可购买项的 ID
+func AvailableItems(items []models.CartItem) (available []models.CartItem, unavailableIDs []uint) {
+	for _, item := range items {
+		if item.Available {
+			available = append(available, item)
+		} else {
+			unavailableIDs = append(unavailableIDs, item.ID)
+		}
+	}
+	return available, unavailableIDs
+}
@@ -0,0 +1,183 @@
+package pricing
+
+import (
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/coupon"
+	"go-flutter-mall/backend/pkg/points"
+	"go-flutter-mall/backend/pkg/promotion"
+	"go-flutter-mall/backend/pkg/shipping"
+	"go-flutter-mall/backend/utils"
+
+	"gorm.io/gorm"
+)
+
+// Input 计价输入
+type Input struct {
+	UserID   uint              // 当前用户 ID
+	Items    []models.CartItem // 参与计价的购物车项 (需预加载 Product)
+	CouponID uint              // 使用的用户优惠券 ID，0 表示不使用
+	Address  *models.Address   // 收货地址，用于计算运费；为空时按默认规则计算
+	Points   int               // 希望使用的积分，超出上限时按上限抵扣
+}
+
+// Line 计价后的商品行
+type Line struct {
+	promotion.Allocation
+	CartItemID   uint   `json:"cart_item_id"`
+	ProductName  string `json:"product_name"`
+	ProductImage string `json:"product_image"`
+	SKUName      string `json:"sku_name"`
+}
+
+// Quote 价格明细
+// 购物车汇总、结算预览和创建订单共用同一份计算结果，保证三处金额一致
+type Quote struct {
+	Lines             []Line              `json:"lines"`
+	Promotions        []promotion.Applied `json:"promotions"`
+	ProductAmount     float64             `json:"product_amount"`     // 商品原价总额
+	PromotionDiscount float64             `json:"promotion_discount"` // 促销优惠
+	MemberLevel       points.Level        `json:"member_level"`       // 当前会员等级
+	MemberDiscount    float64             `json:"member_discount"`    // 会员折扣优惠
+	CouponID          uint                `json:"coupon_id"`          // 使用的用户优惠券 ID
+	CouponDiscount    float64             `json:"coupon_discount"`    // 优惠券抵扣
+	ShippingFee       float64             `json:"shipping_fee"`       // 运费
+	PointsUsed        int                 `json:"points_used"`        // 使用的积分
+	PointsDiscount    float64             `json:"points_discount"`    // 积分抵扣
+	TotalAmount       float64             `json:"total_amount"`       // 应付金额
+}
+
+// Calculate 计算购物车项的价格明细
+// 顺序: SKU 单价 -> 自动促销 -> 会员折扣 -> 运费 -> 优惠券 -> 积分
+func Calculate(db *gorm.DB, in Input) (*Quote, error) {
+	account, err := points.FindAccount(db, in.UserID)
+	if err != nil {
+		return nil, err
+	}
+	level := points.LevelFor(account.CumulativeSpend)
+
+	quote := &Quote{Lines: []Line{}, Promotions: []promotion.Applied{}, MemberLevel: level, CouponID: in.CouponID}
+	if len(in.Items) == 0 {
+		return quote, nil
+	}
+
+	// 1. 查询 SKU 价格，有 SKU 时以 SKU 价格为准
+	var skuIDs []uint
+	for _, item := range in.Items {
+		if item.SKUID != 0 {
+			skuIDs = append(skuIDs, item.SKUID)
+		}
+	}
+	skus := make(map[uint]models.ProductSKU)
+	if len(skuIDs) > 0 {
+		var list []models.ProductSKU
+		if err := db.Where("id IN ?", skuIDs).Find(&list).Error; err != nil {
+			return nil, err
+		}
+		for _, sku := range list {
+			skus[sku.ID] = sku
+		}
+	}
+
+	lines := make([]promotion.Line, len(in.Items))
+	for i, item := range in.Items {
+		price := item.Product.Price
+		if sku, ok := skus[item.SKUID]; ok && sku.ProductID == item.ProductID {
+			price = sku.Price
+		}
+		lines[i] = promotion.Line{
+			ProductID:  item.ProductID,
+			SKUID:      item.SKUID,
+			CategoryID: item.Product.CategoryID,
+			Price:      price,
+			Quantity:   item.Quantity,
+		}
+	}
+
+	// 2. 自动促销
+	promotions, err := promotion.LoadActive(db)
+	if err != nil {
+		return nil, err
+	}
+	if err := promotion.ExpandCategoryScopes(db, promotions); err != nil {
+		return nil, err
+	}
+	result := promotion.Calculate(lines, promotions)
+
+	quote.Promotions = result.Applied
+	quote.ProductAmount = result.OriginalAmount
+	quote.PromotionDiscount = result.Discount
+	for i, allocation := range result.Lines {
+		item := in.Items[i]
+		quote.Lines = append(quote.Lines, Line{
+			Allocation:   allocation,
+			CartItemID:   item.ID,
+			ProductName:  item.Product.Name,
+			ProductImage: item.Product.CoverImage,
+			SKUName:      skus[item.SKUID].Name,
+		})
+	}
+
+	// 3. 会员折扣基于促销后的金额计算
+	memberAmounts := make([]float64, len(result.Lines))
+	var memberDiscount float64
+	for i, allocation := range result.Lines {
+		discount := utils.RoundAmount(allocation.FinalAmount * (1 - level.DiscountRate))
+		memberAmounts[i] = allocation.FinalAmount - discount
+		memberDiscount += discount
+	}
+	quote.MemberDiscount = utils.RoundAmount(memberDiscount)
+
+	// 4. 运费基于促销后的金额判断满额包邮，包邮等级会员免运费
+	freightItems := make([]shipping.Item, len(result.Lines))
+	for i, allocation := range result.Lines {
+		freightItems[i] = shipping.Item{
+			TemplateID: in.Items[i].Product.FreightTemplateID,
+			Weight:     in.Items[i].Product.Weight,
+			Quantity:   allocation.Quantity,
+			Amount:     allocation.FinalAmount,
+		}
+	}
+	if !level.FreeShipping {
+		quote.ShippingFee, err = shipping.Calculate(db, in.Address, freightItems)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 5. 优惠券基于会员折扣后的金额计算，包邮券抵扣运费
+	if in.CouponID != 0 {
+		items := make([]coupon.Item, len(result.Lines))
+		for i, allocation := range result.Lines {
+			items[i] = coupon.Item{
+				ProductID:  allocation.ProductID,
+				CategoryID: allocation.CategoryID,
+				Amount:     memberAmounts[i],
+			}
+		}
+		_, discount, err := coupon.Validate(db, in.UserID, in.CouponID, items, quote.ShippingFee)
+		if err != nil {
+			return nil, err
+		}
+		quote.CouponDiscount = discount
+	}
+
+	payable := utils.RoundAmount(quote.ProductAmount - quote.PromotionDiscount - quote.MemberDiscount + quote.ShippingFee - quote.CouponDiscount)
+	if payable < 0 {
+		payable = 0
+	}
+
+	// 6. 积分抵扣，最多抵扣应付金额的 MaxDeductRatio
+	if in.Points > 0 {
+		if in.Points > account.Balance {
+			return nil, points.ErrInsufficientPoints
+		}
+		quote.PointsUsed = in.Points
+		if limit := points.MaxDeductible(payable); quote.PointsUsed > limit {
+			quote.PointsUsed = limit
+		}
+		quote.PointsDiscount = points.Value(quote.PointsUsed)
+	}
+
+	quote.TotalAmount = utils.RoundAmount(payable - quote.PointsDiscount)
+	return quote, nil
+}
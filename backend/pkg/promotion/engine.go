@@ -0,0 +1,421 @@
+package promotion
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go-flutter-mall/backend/models"
+	categorypkg "go-flutter-mall/backend/pkg/category"
+	"go-flutter-mall/backend/utils"
+
+	"gorm.io/gorm"
+)
+
+// Tier 阶梯满减的一档
+type Tier struct {
+	Threshold float64 `json:"threshold"` // 满 Threshold
+	Discount  float64 `json:"discount"`  // 减 Discount
+}
+
+// Rule 促销规则，对应 models.Promotion.Rules 的 JSON 结构
+// 不同类型只使用其中的部分字段
+type Rule struct {
+	BuyQuantity  int     `json:"buy_quantity,omitempty"`  // buy_x_get_y: 购买数量 X
+	FreeQuantity int     `json:"free_quantity,omitempty"` // buy_x_get_y: 赠送数量 Y
+	Tiers        []Tier  `json:"tiers,omitempty"`         // tiered: 满减阶梯
+	Rate         float64 `json:"rate,omitempty"`          // category_discount: 折扣率，如 0.9 表示 9 折
+	BundlePrice  float64 `json:"bundle_price,omitempty"`  // bundle: 每套的套装价
+}
+
+// ParseRule 解析并校验促销规则
+func ParseRule(promotionType string, raw string) (Rule, error) {
+	var rule Rule
+	if raw != "" {
+		if err := json.Unmarshal([]byte(raw), &rule); err != nil {
+			return rule, fmt.Errorf("invalid rules: %v", err)
+		}
+	}
+
+	switch promotionType {
+	case models.PromotionTypeBuyXGetY:
+		if rule.BuyQuantity <= 0 || rule.FreeQuantity <= 0 {
+			return rule, fmt.Errorf("buy_quantity and free_quantity must be greater than 0")
+		}
+	case models.PromotionTypeTiered:
+		if len(rule.Tiers) == 0 {
+			return rule, fmt.Errorf("tiers is required")
+		}
+		for _, tier := range rule.Tiers {
+			if tier.Threshold <= 0 || tier.Discount <= 0 || tier.Discount > tier.Threshold {
+				return rule, fmt.Errorf("invalid tier: threshold %.2f, discount %.2f", tier.Threshold, tier.Discount)
+			}
+		}
+	case models.PromotionTypeCategoryDiscount:
+		if rule.Rate <= 0 || rule.Rate >= 1 {
+			return rule, fmt.Errorf("rate must be between 0 and 1")
+		}
+	case models.PromotionTypeBundle:
+		if rule.BundlePrice <= 0 {
+			return rule, fmt.Errorf("bundle_price must be greater than 0")
+		}
+	default:
+		return rule, fmt.Errorf("unknown promotion type: %s", promotionType)
+	}
+	return rule, nil
+}
+
+// Line 参与促销计算的商品行 (来自购物车或订单)
+type Line struct {
+	ProductID  uint    `json:"product_id"`
+	SKUID      uint    `json:"sku_id"`
+	CategoryID uint    `json:"category_id"`
+	Price      float64 `json:"price"`
+	Quantity   int     `json:"quantity"`
+}
+
+// Amount 行原价金额
+func (l Line) Amount() float64 {
+	return l.Price * float64(l.Quantity)
+}
+
+// Allocation 单行的优惠分摊结果
+type Allocation struct {
+	Line
+	OriginalAmount float64 `json:"original_amount"` // 原价金额
+	Discount       float64 `json:"discount"`        // 分摊到该行的促销优惠
+	FinalAmount    float64 `json:"final_amount"`    // 优惠后金额
+	PromotionIDs   []uint  `json:"promotion_ids"`   // 命中的促销 ID
+}
+
+// Applied 命中的促销活动
+type Applied struct {
+	PromotionID uint    `json:"promotion_id"`
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+	Discount    float64 `json:"discount"`
+}
+
+// Result 促销计算结果
+type Result struct {
+	Lines          []Allocation `json:"lines"`
+	Applied        []Applied    `json:"applied"`
+	OriginalAmount float64      `json:"original_amount"`
+	Discount       float64      `json:"discount"`
+	FinalAmount    float64      `json:"final_amount"`
+}
+
+// LoadActive 查询当前生效的促销活动
+func LoadActive(db *gorm.DB) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	now := time.Now()
+	err := db.Where("status = ? AND start_at <= ? AND end_at > ?", 1, now, now).
+		Order("priority desc, id asc").Find(&promotions).Error
+	return promotions, err
+}
+
+// ExpandCategoryScopes 将分类范围的活动展开为所选分类及其所有子孙分类，适用于父分类的活动对子分类商品同样生效
+// 直接修改传入的活动，只用于计价，不应再写回数据库或返回给前台
+func ExpandCategoryScopes(db *gorm.DB, promotions []models.Promotion) error {
+	var list []models.Category
+	for i := range promotions {
+		p := &promotions[i]
+		if p.ScopeType != models.ScopeCategory || len(p.ScopeIDs) == 0 {
+			continue
+		}
+		if list == nil {
+			var err error
+			if list, err = categorypkg.LoadAll(db); err != nil {
+				return err
+			}
+		}
+		p.ScopeIDs = categorypkg.ExpandIDs(list, p.ScopeIDs)
+	}
+	return nil
+}
+
+// inScope 判断商品行是否在促销的适用范围内，分类范围需先经 ExpandCategoryScopes 展开到子分类
+func inScope(p *models.Promotion, line Line) bool {
+	switch p.ScopeType {
+	case models.ScopeCategory:
+		for _, id := range p.ScopeIDs {
+			if uint(id) == line.CategoryID {
+				return true
+			}
+		}
+		return false
+	case models.ScopeProduct:
+		for _, id := range p.ScopeIDs {
+			if uint(id) == line.ProductID {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// Calculate 对商品行应用促销活动
+//
+// 计算规则:
+//  1. 单品级促销 (buy_x_get_y, category_discount, bundle) 按优先级依次计算，
+//     每个商品行最多享受一个单品级促销，先命中者优先。
+//  2. 订单级促销 (tiered) 基于单品级优惠后的金额计算，多个满减活动只取优惠最大的一个。
+func Calculate(lines []Line, promotions []models.Promotion) Result {
+	result := Result{Lines: make([]Allocation, len(lines)), Applied: []Applied{}}
+	for i, line := range lines {
+		result.Lines[i] = Allocation{
+			Line:           line,
+			OriginalAmount: utils.RoundAmount(line.Amount()),
+			FinalAmount:    utils.RoundAmount(line.Amount()),
+			PromotionIDs:   []uint{},
+		}
+		result.OriginalAmount += line.Amount()
+	}
+
+	sorted := make([]models.Promotion, len(promotions))
+	copy(sorted, promotions)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Priority > sorted[j].Priority })
+
+	claimed := make([]bool, len(lines))
+	var tiered []models.Promotion
+
+	for i := range sorted {
+		p := &sorted[i]
+		if p.Type == models.PromotionTypeTiered {
+			tiered = append(tiered, *p)
+			continue
+		}
+		rule, err := ParseRule(p.Type, p.Rules)
+		if err != nil {
+			continue
+		}
+
+		// 收集未被其他单品级促销占用且在范围内的行
+		var idx []int
+		for j, line := range lines {
+			if !claimed[j] && inScope(p, line) {
+				idx = append(idx, j)
+			}
+		}
+		if len(idx) == 0 {
+			continue
+		}
+
+		var discounts map[int]float64
+		switch p.Type {
+		case models.PromotionTypeBuyXGetY:
+			discounts = buyXGetY(lines, idx, rule)
+		case models.PromotionTypeCategoryDiscount:
+			discounts = categoryDiscount(lines, idx, rule)
+		case models.PromotionTypeBundle:
+			discounts = bundle(p, lines, idx, rule)
+		}
+
+		if applied, ok := apply(&result, p, discounts); ok {
+			// 参与活动的行 (包括买 X 送 Y 中被"买"的部分) 不再叠加其他单品级促销
+			// 套装只占用组成套装的行
+			if p.Type == models.PromotionTypeBundle {
+				for j := range discounts {
+					claimed[j] = true
+				}
+			} else {
+				for _, j := range idx {
+					claimed[j] = true
+				}
+			}
+			result.Applied = append(result.Applied, applied)
+		}
+	}
+
+	// 订单级满减: 取优惠最大的一档
+	var best map[int]float64
+	var bestPromotion *models.Promotion
+	var bestDiscount float64
+	for i := range tiered {
+		p := &tiered[i]
+		rule, err := ParseRule(p.Type, p.Rules)
+		if err != nil {
+			continue
+		}
+		discounts := tieredDiscount(p, result.Lines, rule)
+		var total float64
+		for _, d := range discounts {
+			total += d
+		}
+		if total > bestDiscount {
+			best, bestPromotion, bestDiscount = discounts, p, total
+		}
+	}
+	if bestPromotion != nil {
+		if applied, ok := apply(&result, bestPromotion, best); ok {
+			result.Applied = append(result.Applied, applied)
+		}
+	}
+
+	result.OriginalAmount = utils.RoundAmount(result.OriginalAmount)
+	for _, a := range result.Lines {
+		result.Discount += a.Discount
+	}
+	result.Discount = utils.RoundAmount(result.Discount)
+	result.FinalAmount = utils.RoundAmount(result.OriginalAmount - result.Discount)
+	return result
+}
+
+// apply 将一个促销的分摊结果写入计算结果
+func apply(result *Result, p *models.Promotion, discounts map[int]float64) (Applied, bool) {
+	applied := Applied{PromotionID: p.ID, Name: p.Name, Type: p.Type}
+	for j, d := range discounts {
+		d = utils.RoundAmount(math.Min(d, result.Lines[j].FinalAmount))
+		if d <= 0 {
+			continue
+		}
+		result.Lines[j].Discount = utils.RoundAmount(result.Lines[j].Discount + d)
+		result.Lines[j].FinalAmount = utils.RoundAmount(result.Lines[j].FinalAmount - d)
+		result.Lines[j].PromotionIDs = append(result.Lines[j].PromotionIDs, p.ID)
+		applied.Discount += d
+	}
+	applied.Discount = utils.RoundAmount(applied.Discount)
+	return applied, applied.Discount > 0
+}
+
+// allocate 将总优惠按金额比例分摊到各行，尾差计入最后一行
+func allocate(amounts map[int]float64, discount float64) map[int]float64 {
+	var total float64
+	keys := make([]int, 0, len(amounts))
+	for j, amount := range amounts {
+		total += amount
+		keys = append(keys, j)
+	}
+	if total <= 0 {
+		return nil
+	}
+	sort.Ints(keys)
+
+	discounts := make(map[int]float64, len(keys))
+	var allocated float64
+	for i, j := range keys {
+		if i == len(keys)-1 {
+			discounts[j] = utils.RoundAmount(discount - allocated)
+			break
+		}
+		d := utils.RoundAmount(discount * amounts[j] / total)
+		discounts[j] = d
+		allocated += d
+	}
+	return discounts
+}
+
+// buyXGetY 买 X 送 Y: 范围内所有件数合并计算，每 X+Y 件中价格最低的 Y 件免费
+func buyXGetY(lines []Line, idx []int, rule Rule) map[int]float64 {
+	type unit struct {
+		line  int
+		price float64
+	}
+	var units []unit
+	for _, j := range idx {
+		for k := 0; k < lines[j].Quantity; k++ {
+			units = append(units, unit{line: j, price: lines[j].Price})
+		}
+	}
+	sort.SliceStable(units, func(a, b int) bool { return units[a].price > units[b].price })
+
+	groups := len(units) / (rule.BuyQuantity + rule.FreeQuantity)
+	free := groups * rule.FreeQuantity
+	if free == 0 {
+		return nil
+	}
+
+	discounts := make(map[int]float64)
+	for _, u := range units[len(units)-free:] {
+		discounts[u.line] += u.price
+	}
+	return discounts
+}
+
+// categoryDiscount 品类折扣: 范围内商品按折扣率打折
+func categoryDiscount(lines []Line, idx []int, rule Rule) map[int]float64 {
+	discounts := make(map[int]float64, len(idx))
+	for _, j := range idx {
+		discounts[j] = lines[j].Amount() * (1 - rule.Rate)
+	}
+	return discounts
+}
+
+// bundle 组合套装: ScopeIDs 中的商品各一件组成一套，每套按 BundlePrice 计价
+func bundle(p *models.Promotion, lines []Line, idx []int, rule Rule) map[int]float64 {
+	if len(p.ScopeIDs) == 0 {
+		return nil
+	}
+
+	// 每个套装商品可用的件数及其所在行 (同一商品可能有多个 SKU 行)
+	byProduct := make(map[uint][]int)
+	for _, j := range idx {
+		byProduct[lines[j].ProductID] = append(byProduct[lines[j].ProductID], j)
+	}
+
+	sets := -1
+	for _, id := range p.ScopeIDs {
+		var qty int
+		for _, j := range byProduct[uint(id)] {
+			qty += lines[j].Quantity
+		}
+		if sets < 0 || qty < sets {
+			sets = qty
+		}
+	}
+	if sets <= 0 {
+		return nil
+	}
+
+	// 每个商品取 sets 件参与套装，计算套装原价
+	amounts := make(map[int]float64)
+	var original float64
+	for _, id := range p.ScopeIDs {
+		remaining := sets
+		for _, j := range byProduct[uint(id)] {
+			n := lines[j].Quantity
+			if n > remaining {
+				n = remaining
+			}
+			amounts[j] += lines[j].Price * float64(n)
+			original += lines[j].Price * float64(n)
+			remaining -= n
+			if remaining == 0 {
+				break
+			}
+		}
+	}
+
+	discount := original - rule.BundlePrice*float64(sets)
+	if discount <= 0 {
+		return nil
+	}
+	return allocate(amounts, discount)
+}
+
+// tieredDiscount 阶梯满减: 基于范围内商品的当前金额匹配最高一档
+func tieredDiscount(p *models.Promotion, allocations []Allocation, rule Rule) map[int]float64 {
+	amounts := make(map[int]float64)
+	var total float64
+	for j, a := range allocations {
+		if inScope(p, a.Line) && a.FinalAmount > 0 {
+			amounts[j] = a.FinalAmount
+			total += a.FinalAmount
+		}
+	}
+
+	var discount float64
+	for _, tier := range rule.Tiers {
+		if total >= tier.Threshold && tier.Discount > discount {
+			discount = tier.Discount
+		}
+	}
+	if discount <= 0 {
+		return nil
+	}
+	return allocate(amounts, discount)
+}
@@ -0,0 +1,187 @@
+package promotion
+
+import (
+	"reflect"
+	"testing"
+
+	"go-flutter-mall/backend/models"
+)
+
+// promo 构造测试用促销活动
+func promo(id uint, promotionType, rules string, priority int, scopeType string, scopeIDs ...int64) models.Promotion {
+	p := models.Promotion{Name: promotionType, Type: promotionType, Rules: rules, Priority: priority, ScopeType: scopeType, ScopeIDs: scopeIDs}
+	p.ID = id
+	return p
+}
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		name          string
+		promotionType string
+		raw           string
+		wantErr       bool
+	}{
+		{"buy x get y", models.PromotionTypeBuyXGetY, `{"buy_quantity":2,"free_quantity":1}`, false},
+		{"buy x get y missing free quantity", models.PromotionTypeBuyXGetY, `{"buy_quantity":2}`, true},
+		{"tiered", models.PromotionTypeTiered, `{"tiers":[{"threshold":100,"discount":10}]}`, false},
+		{"tiered without tiers", models.PromotionTypeTiered, `{}`, true},
+		{"tiered discount above threshold", models.PromotionTypeTiered, `{"tiers":[{"threshold":100,"discount":120}]}`, true},
+		{"category discount", models.PromotionTypeCategoryDiscount, `{"rate":0.8}`, false},
+		{"category discount rate of 1", models.PromotionTypeCategoryDiscount, `{"rate":1}`, true},
+		{"bundle", models.PromotionTypeBundle, `{"bundle_price":99}`, false},
+		{"bundle without price", models.PromotionTypeBundle, `{}`, true},
+		{"invalid json", models.PromotionTypeBundle, `{`, true},
+		{"unknown type", "flash_sale", `{}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRule(tt.promotionType, tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name         string
+		lines        []Line
+		promotions   []models.Promotion
+		wantDiscount float64
+		wantFinal    float64
+		wantApplied  []uint
+		wantLines    []float64
+	}{
+		{
+			name:         "no promotions",
+			lines:        []Line{{ProductID: 1, Price: 100, Quantity: 2}},
+			wantDiscount: 0,
+			wantFinal:    200,
+			wantApplied:  []uint{},
+			wantLines:    []float64{0},
+		},
+		{
+			name:  "buy two get the cheapest free",
+			lines: []Line{{ProductID: 1, Price: 100, Quantity: 2}, {ProductID: 2, Price: 30, Quantity: 1}},
+			promotions: []models.Promotion{
+				promo(1, models.PromotionTypeBuyXGetY, `{"buy_quantity":2,"free_quantity":1}`, 0, models.ScopeAll),
+			},
+			wantDiscount: 30,
+			wantFinal:    200,
+			wantApplied:  []uint{1},
+			wantLines:    []float64{0, 30},
+		},
+		{
+			name:  "buy x get y needs a full group",
+			lines: []Line{{ProductID: 1, Price: 100, Quantity: 2}},
+			promotions: []models.Promotion{
+				promo(1, models.PromotionTypeBuyXGetY, `{"buy_quantity":2,"free_quantity":1}`, 0, models.ScopeAll),
+			},
+			wantDiscount: 0,
+			wantFinal:    200,
+			wantApplied:  []uint{},
+			wantLines:    []float64{0},
+		},
+		{
+			name:  "category discount only applies in scope",
+			lines: []Line{{ProductID: 1, CategoryID: 5, Price: 100, Quantity: 1}, {ProductID: 2, CategoryID: 6, Price: 50, Quantity: 1}},
+			promotions: []models.Promotion{
+				promo(1, models.PromotionTypeCategoryDiscount, `{"rate":0.9}`, 0, models.ScopeCategory, 5),
+			},
+			wantDiscount: 10,
+			wantFinal:    140,
+			wantApplied:  []uint{1},
+			wantLines:    []float64{10, 0},
+		},
+		{
+			name:  "higher priority item promotion claims the line",
+			lines: []Line{{ProductID: 1, Price: 100, Quantity: 1}},
+			promotions: []models.Promotion{
+				promo(1, models.PromotionTypeCategoryDiscount, `{"rate":0.5}`, 1, models.ScopeAll),
+				promo(2, models.PromotionTypeCategoryDiscount, `{"rate":0.9}`, 5, models.ScopeAll),
+			},
+			wantDiscount: 10,
+			wantFinal:    90,
+			wantApplied:  []uint{2},
+			wantLines:    []float64{10},
+		},
+		{
+			name:  "tiered uses amount after item discounts",
+			lines: []Line{{ProductID: 1, Price: 100, Quantity: 2}, {ProductID: 2, Price: 50, Quantity: 1}},
+			promotions: []models.Promotion{
+				promo(1, models.PromotionTypeCategoryDiscount, `{"rate":0.8}`, 10, models.ScopeAll),
+				promo(2, models.PromotionTypeTiered, `{"tiers":[{"threshold":100,"discount":10},{"threshold":200,"discount":30},{"threshold":250,"discount":60}]}`, 0, models.ScopeAll),
+			},
+			wantDiscount: 80,
+			wantFinal:    170,
+			wantApplied:  []uint{1, 2},
+			wantLines:    []float64{64, 16},
+		},
+		{
+			name:  "only the best tiered promotion applies",
+			lines: []Line{{ProductID: 1, Price: 120, Quantity: 1}},
+			promotions: []models.Promotion{
+				promo(1, models.PromotionTypeTiered, `{"tiers":[{"threshold":100,"discount":10}]}`, 0, models.ScopeAll),
+				promo(2, models.PromotionTypeTiered, `{"tiers":[{"threshold":100,"discount":20}]}`, 0, models.ScopeAll),
+			},
+			wantDiscount: 20,
+			wantFinal:    100,
+			wantApplied:  []uint{2},
+			wantLines:    []float64{20},
+		},
+		{
+			name:  "bundle allocates by amount and leaves extra units",
+			lines: []Line{{ProductID: 1, Price: 100, Quantity: 2}, {ProductID: 2, Price: 80, Quantity: 1}},
+			promotions: []models.Promotion{
+				promo(1, models.PromotionTypeBundle, `{"bundle_price":150}`, 0, models.ScopeProduct, 1, 2),
+			},
+			wantDiscount: 30,
+			wantFinal:    250,
+			wantApplied:  []uint{1},
+			wantLines:    []float64{16.67, 13.33},
+		},
+		{
+			name:  "bundle needs every product",
+			lines: []Line{{ProductID: 1, Price: 100, Quantity: 2}},
+			promotions: []models.Promotion{
+				promo(1, models.PromotionTypeBundle, `{"bundle_price":150}`, 0, models.ScopeProduct, 1, 2),
+			},
+			wantDiscount: 0,
+			wantFinal:    200,
+			wantApplied:  []uint{},
+			wantLines:    []float64{0},
+		},
+		{
+			name:  "invalid rules are skipped",
+			lines: []Line{{ProductID: 1, Price: 100, Quantity: 1}},
+			promotions: []models.Promotion{
+				promo(1, models.PromotionTypeCategoryDiscount, `{"rate":2}`, 0, models.ScopeAll),
+			},
+			wantDiscount: 0,
+			wantFinal:    100,
+			wantApplied:  []uint{},
+			wantLines:    []float64{0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Calculate(tt.lines, tt.promotions)
+			if result.Discount != tt.wantDiscount || result.FinalAmount != tt.wantFinal {
+				t.Fatalf("Calculate() discount = %v, final = %v, want %v, %v", result.Discount, result.FinalAmount, tt.wantDiscount, tt.wantFinal)
+			}
+			applied := []uint{}
+			for _, a := range result.Applied {
+				applied = append(applied, a.PromotionID)
+			}
+			if !reflect.DeepEqual(applied, tt.wantApplied) {
+				t.Errorf("Calculate() applied = %v, want %v", applied, tt.wantApplied)
+			}
+			for i, want := range tt.wantLines {
+				if got := result.Lines[i].Discount; got != want {
+					t.Errorf("line %d discount = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
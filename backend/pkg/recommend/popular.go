@@ -0,0 +1,38 @@
+package recommend
+
+import (
+	"time"
+
+	"go-flutter-mall/backend/models"
+
+	"gorm.io/gorm"
+)
+
+// PopularDays 热门商品统计最近多少天的销量
+const PopularDays = 30
+
+// Popular 热门上架商品 ID: 按最近 30 天销量、收藏数、上架时间排序
+// categoryID 不为 0 时只取该分类的商品，exclude 中的商品不返回
+func Popular(db *gorm.DB, categoryID uint, exclude []uint, limit int) ([]uint, error) {
+	sales := db.Table("order_items oi").
+		Select("oi.product_id, SUM(oi.quantity) AS sold").
+		Joins("JOIN orders o ON o.id = oi.order_id").
+		Where("oi.deleted_at IS NULL AND o.deleted_at IS NULL AND "+paidOrderStatuses+" AND o.created_at >= ?", time.Now().AddDate(0, 0, -PopularDays)).
+		Group("oi.product_id")
+
+	query := db.Model(&models.Product{}).
+		Joins("LEFT JOIN (?) AS sales ON sales.product_id = products.id", sales).
+		Where("products.status = ?", models.ProductStatusOn)
+	if categoryID != 0 {
+		query = query.Where("products.category_id = ?", categoryID)
+	}
+	if len(exclude) > 0 {
+		query = query.Where("products.id NOT IN ?", exclude)
+	}
+
+	var ids []uint
+	err := query.Order("COALESCE(sales.sold, 0) DESC, products.favorite_count DESC, products.created_at DESC").
+		Limit(limit).
+		Pluck("products.id", &ids).Error
+	return ids, err
+}
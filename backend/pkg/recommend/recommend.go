@@ -0,0 +1,230 @@
+package recommend
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/browsing"
+	"go-flutter-mall/backend/pkg/search"
+
+	"gorm.io/gorm"
+)
+
+// 推荐理由
+const (
+	ReasonAlsoBought = "also_bought" // 与购买或浏览过的商品经常一起购买
+	ReasonViewed     = "viewed"      // 最近浏览过但未购买
+	ReasonSearch     = "search"      // 与最近的搜索相关
+	ReasonPopular    = "popular"     // 热门商品 (冷启动或个性化结果不足时补充)
+)
+
+// 个性化推荐的信号权重
+const (
+	purchaseWeight       = 1.0 // 已购买商品作为种子
+	viewWeight           = 0.6 // 浏览过的商品作为种子 (按时间衰减)
+	viewedSelfWeight     = 0.3 // 浏览过但未购买的商品本身
+	searchWeight         = 0.5 // 最近搜索的命中结果
+	viewHalfLifeDays     = 7.0 // 浏览信号的半衰期
+	recentViewDays       = 30  // 只使用最近 30 天的浏览
+	maxViewSeeds         = 50  // 最多使用的浏览商品数
+	maxKeywords          = 5   // 最多使用的最近搜索关键词数
+	searchHitsPerKeyword = 10  // 每个关键词取前 10 个命中
+	keywordDecay         = 0.7 // 越早的关键词权重越低
+)
+
+// Item 推荐结果项
+type Item struct {
+	ProductID uint
+	Score     float64 // 推荐得分，热门补充的商品为 0
+	Reason    string  // 贡献得分最多的推荐理由
+}
+
+// candidates 候选商品得分，记录每个商品贡献最大的信号作为推荐理由
+type candidates struct {
+	scores map[uint]float64
+	best   map[uint]float64
+	reason map[uint]string
+}
+
+func newCandidates() *candidates {
+	return &candidates{scores: map[uint]float64{}, best: map[uint]float64{}, reason: map[uint]string{}}
+}
+
+func (c *candidates) add(productID uint, score float64, reason string) {
+	c.scores[productID] += score
+	if score > c.best[productID] {
+		c.best[productID] = score
+		c.reason[productID] = reason
+	}
+}
+
+// top 过滤出上架商品并按得分降序取前 limit 个
+func (c *candidates) top(db *gorm.DB, exclude map[uint]bool, limit int) ([]Item, error) {
+	ids := make([]uint, 0, len(c.scores))
+	for id := range c.scores {
+		if !exclude[id] {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var visible []uint
+	if err := db.Model(&models.Product{}).Where("id IN ? AND status = ?", ids, models.ProductStatusOn).Pluck("id", &visible).Error; err != nil {
+		return nil, err
+	}
+
+	sort.Slice(visible, func(i, j int) bool {
+		si, sj := c.scores[visible[i]], c.scores[visible[j]]
+		if si != sj {
+			return si > sj
+		}
+		return visible[i] < visible[j]
+	})
+	if len(visible) > limit {
+		visible = visible[:limit]
+	}
+	items := make([]Item, 0, len(visible))
+	for _, id := range visible {
+		items = append(items, Item{ProductID: id, Score: c.scores[id], Reason: c.reason[id]})
+	}
+	return items, nil
+}
+
+// fillPopular 用热门商品补足 limit 个，categoryID 不为 0 时优先取同分类商品
+func fillPopular(db *gorm.DB, items []Item, exclude map[uint]bool, categoryID uint, limit int) ([]Item, error) {
+	categories := []uint{0}
+	if categoryID != 0 {
+		categories = []uint{categoryID, 0}
+	}
+	for _, category := range categories {
+		if len(items) >= limit {
+			break
+		}
+		skip := make([]uint, 0, len(exclude)+len(items))
+		for id := range exclude {
+			skip = append(skip, id)
+		}
+		for _, item := range items {
+			skip = append(skip, item.ProductID)
+		}
+		ids, err := Popular(db, category, skip, limit-len(items))
+		if err != nil {
+			return items, err
+		}
+		for _, id := range ids {
+			items = append(items, Item{ProductID: id, Reason: ReasonPopular})
+		}
+	}
+	return items, nil
+}
+
+// Related 与商品经常一起购买的商品，不足时依次用同分类和全站热门商品补充
+func Related(db *gorm.DB, product *models.Product, limit int) ([]Item, error) {
+	var similar []models.ProductSimilarity
+	if err := db.Joins("JOIN products ON products.id = product_similarities.related_product_id AND products.deleted_at IS NULL").
+		Where("product_similarities.product_id = ? AND products.status = ?", product.ID, models.ProductStatusOn).
+		Order("product_similarities.score DESC, product_similarities.co_purchases DESC, product_similarities.related_product_id").
+		Limit(limit).
+		Find(&similar).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]Item, 0, limit)
+	for _, s := range similar {
+		items = append(items, Item{ProductID: s.RelatedProductID, Score: s.Score, Reason: ReasonAlsoBought})
+	}
+	return fillPopular(db, items, map[uint]bool{product.ID: true}, product.CategoryID, limit)
+}
+
+// ForUser 个性化推荐 ("猜你喜欢")，返回推荐结果及是否使用了个性化信号
+//   - 购买: 最近 RECOMMEND_ORDER_DAYS 天已支付订单中的商品作为种子，本身不再推荐
+//   - 浏览: 最近 30 天浏览过的商品作为种子 (半衰期 7 天，浏览次数取对数加权)，本身也以较低权重推荐
+//   - 搜索: 最近 5 个搜索关键词的前 10 个命中，越早的关键词权重越低
+//
+// 种子商品的共同购买相似商品按 种子权重 × 相似度 累加得分；没有任何信号或结果不足时用热门商品补充。
+// 浏览和搜索信号依赖 MongoDB，不可用时只使用购买信号
+func ForUser(db *gorm.DB, userID uint, limit int) ([]Item, bool, error) {
+	if userID == 0 {
+		items, err := fillPopular(db, nil, nil, 0, limit)
+		return items, false, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	now := time.Now()
+
+	var purchased []uint
+	if err := db.Table("order_items oi").
+		Joins("JOIN orders o ON o.id = oi.order_id").
+		Where("o.user_id = ? AND oi.deleted_at IS NULL AND o.deleted_at IS NULL AND "+paidOrderStatuses+" AND o.created_at >= ?",
+			userID, now.AddDate(0, 0, -config.RecommendOrderDays)).
+		Distinct().
+		Pluck("oi.product_id", &purchased).Error; err != nil {
+		return nil, false, err
+	}
+	exclude := make(map[uint]bool, len(purchased))
+	seeds := make(map[uint]float64)
+	for _, id := range purchased {
+		exclude[id] = true
+		seeds[id] = purchaseWeight
+	}
+
+	cands := newCandidates()
+
+	viewed, err := browsing.RecentViews(ctx, userID, now.AddDate(0, 0, -recentViewDays), maxViewSeeds)
+	if err != nil && !errors.Is(err, browsing.ErrDisabled) {
+		log.Printf("Failed to load browsing history of user %d for recommendations: %v", userID, err)
+	}
+	for _, v := range viewed {
+		ageDays := now.Sub(v.ViewedAt).Hours() / 24
+		w := math.Pow(0.5, ageDays/viewHalfLifeDays) * (1 + math.Log(float64(v.Views)))
+		seeds[v.ProductID] += viewWeight * w
+		cands.add(v.ProductID, viewedSelfWeight*w, ReasonViewed)
+	}
+
+	if len(seeds) > 0 {
+		ids := make([]uint, 0, len(seeds))
+		for id := range seeds {
+			ids = append(ids, id)
+		}
+		var similar []models.ProductSimilarity
+		if err := db.Where("product_id IN ?", ids).Find(&similar).Error; err != nil {
+			return nil, false, err
+		}
+		for _, s := range similar {
+			cands.add(s.RelatedProductID, seeds[s.ProductID]*s.Score, ReasonAlsoBought)
+		}
+	}
+
+	keywords, err := search.RecentKeywords(ctx, userID, maxKeywords)
+	if err != nil {
+		log.Printf("Failed to load search history of user %d for recommendations: %v", userID, err)
+	}
+	if search.Default != nil {
+		for i, keyword := range keywords {
+			result, err := search.Search(search.Query{Text: keyword, Page: 1, PageSize: searchHitsPerKeyword})
+			if err != nil {
+				log.Printf("Failed to search %q for recommendations: %v", keyword, err)
+				continue
+			}
+			for rank, hit := range result.Hits {
+				score := searchWeight * math.Pow(keywordDecay, float64(i)) * (1 - float64(rank)/searchHitsPerKeyword)
+				cands.add(hit.ProductID, score, ReasonSearch)
+			}
+		}
+	}
+
+	personalized := len(seeds) > 0 || len(keywords) > 0
+	items, err := cands.top(db, exclude, limit)
+	if err != nil {
+		return nil, personalized, err
+	}
+	items, err = fillPopular(db, items, exclude, 0, limit)
+	return items, personalized, err
+}
@@ -0,0 +1,88 @@
+package recommend
+
+import (
+	"log"
+	"time"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+
+	"gorm.io/gorm"
+)
+
+// paidOrderStatuses 计入推荐的订单状态: 已支付 (待发货) 至售后中，与商品列表的销量排序一致
+const paidOrderStatuses = "o.status BETWEEN 1 AND 5"
+
+// similaritySQL 基于订单的商品共同购买相似度
+// 同一订单内的商品两两配对，相似度为余弦相似度 co / sqrt(n_a * n_b)，每个商品按相似度保留前 N 个
+const similaritySQL = `
+WITH paid AS (
+	SELECT DISTINCT oi.order_id, oi.product_id
+	FROM order_items oi
+	JOIN orders o ON o.id = oi.order_id
+	WHERE oi.deleted_at IS NULL AND o.deleted_at IS NULL AND ` + paidOrderStatuses + ` AND o.created_at >= @since
+),
+counts AS (
+	SELECT product_id, COUNT(*) AS n FROM paid GROUP BY product_id
+),
+pairs AS (
+	SELECT a.product_id, b.product_id AS related_product_id, COUNT(*) AS co_purchases
+	FROM paid a
+	JOIN paid b ON a.order_id = b.order_id AND a.product_id <> b.product_id
+	GROUP BY a.product_id, b.product_id
+),
+scored AS (
+	SELECT p.product_id, p.related_product_id, p.co_purchases,
+		p.co_purchases / SQRT(ca.n * cb.n) AS score
+	FROM pairs p
+	JOIN counts ca ON ca.product_id = p.product_id
+	JOIN counts cb ON cb.product_id = p.related_product_id
+),
+ranked AS (
+	SELECT *, ROW_NUMBER() OVER (PARTITION BY product_id ORDER BY score DESC, co_purchases DESC, related_product_id) AS rn
+	FROM scored
+)
+INSERT INTO product_similarities (product_id, related_product_id, score, co_purchases, updated_at)
+SELECT product_id, related_product_id, score, co_purchases, @now FROM ranked WHERE rn <= @top`
+
+// ComputeSimilarities 根据最近 RECOMMEND_ORDER_DAYS 天的已支付订单全量重算商品相似度，返回写入的记录数
+// 在事务中先清空再写入，多实例同时执行时结果相同，读取方不会看到空表
+func ComputeSimilarities(db *gorm.DB, now time.Time) (int64, error) {
+	var rows int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.ProductSimilarity{}).Error; err != nil {
+			return err
+		}
+		result := tx.Exec(similaritySQL, map[string]interface{}{
+			"since": now.AddDate(0, 0, -config.RecommendOrderDays),
+			"now":   now,
+			"top":   config.RecommendRelatedPerProduct,
+		})
+		rows = result.RowsAffected
+		return result.Error
+	})
+	return rows, err
+}
+
+// StartSimilarityJob 启动共同购买相似度的定时重算任务 (启动时先执行一次)
+func StartSimilarityJob() {
+	interval := time.Duration(config.RecommendRefreshMinutes) * time.Minute
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	run := func() {
+		if _, err := ComputeSimilarities(config.DB, time.Now()); err != nil {
+			log.Printf("Failed to compute product similarities: %v", err)
+		}
+	}
+
+	go func() {
+		run()
+		ticker := time.NewTicker(interval)
+		for range ticker.C {
+			run()
+		}
+	}()
+
+	log.Println("Product similarity job started...")
+}
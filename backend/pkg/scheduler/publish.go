@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/pkg/cache"
+	"go-flutter-mall/backend/pkg/search"
+
+	"gorm.io/gorm"
+)
+
+// PublishInterval 定时上下架的检查间隔
+const PublishInterval = time.Minute
+
+// StartPublishScheduler 启动商品定时上下架任务 (轮询数据库，不依赖 Redis)
+func StartPublishScheduler() {
+	run := func() {
+		changed, err := RunProductSchedule(config.DB, time.Now())
+		if err != nil {
+			log.Printf("Failed to run product schedule: %v", err)
+		}
+		if len(changed) > 0 {
+			cache.InvalidateProducts(changed...)
+		}
+		reindexProducts(changed)
+	}
+
+	go func() {
+		// 启动时先执行一次，补上停机期间错过的计划
+		run()
+		ticker := time.NewTicker(PublishInterval)
+		for range ticker.C {
+			run()
+		}
+	}()
+
+	log.Println("Product publish scheduler started...")
+}
+
+// RunProductSchedule 执行已到时间的定时下架和定时上架，返回状态或计划被修改的商品 ID
+// 先处理下架再处理上架，服务停机期间错过的计划按时间先后得到正确的最终状态:
+//   - unpublish_at 已到: 上架商品改为下架；若上架时间早于下架时间 (整个上架窗口已过)，一并清空 publish_at
+//   - publish_at 已到: 改为上架 (草稿和下架商品均可)
+//
+// 执行后清空对应时间，之后手动修改状态不会被再次覆盖；UPDATE 中重复检查条件，多实例同时执行也只生效一次
+func RunProductSchedule(db *gorm.DB, now time.Time) ([]uint, error) {
+	var changed []uint
+
+	var due []uint
+	if err := db.Model(&models.Product{}).Where("unpublish_at <= ?", now).Pluck("id", &due).Error; err != nil {
+		return nil, err
+	}
+	if len(due) > 0 {
+		if err := db.Model(&models.Product{}).Where("id IN ? AND unpublish_at <= ?", due, now).Updates(map[string]interface{}{
+			"status":       gorm.Expr("CASE WHEN status = ? THEN ? ELSE status END", models.ProductStatusOn, models.ProductStatusOff),
+			"publish_at":   gorm.Expr("CASE WHEN publish_at <= unpublish_at THEN NULL ELSE publish_at END"),
+			"unpublish_at": nil,
+		}).Error; err != nil {
+			return nil, err
+		}
+		changed = append(changed, due...)
+	}
+
+	due = nil
+	if err := db.Model(&models.Product{}).Where("publish_at <= ?", now).Pluck("id", &due).Error; err != nil {
+		return changed, err
+	}
+	if len(due) > 0 {
+		if err := db.Model(&models.Product{}).Where("id IN ? AND publish_at <= ?", due, now).Updates(map[string]interface{}{
+			"status":     models.ProductStatusOn,
+			"publish_at": nil,
+		}).Error; err != nil {
+			return changed, err
+		}
+		changed = append(changed, due...)
+	}
+	return changed, nil
+}
+
+// reindexProducts 状态变化后同步搜索索引
+func reindexProducts(ids []uint) {
+	if len(ids) == 0 {
+		return
+	}
+	var products []models.Product
+	if err := config.DB.Where("id IN ?", ids).Find(&products).Error; err != nil {
+		log.Printf("Failed to reload scheduled products: %v", err)
+		return
+	}
+	for i := range products {
+		search.IndexProduct(&products[i])
+	}
+	log.Printf("Product schedule applied to %d products", len(products))
+}
@@ -0,0 +1,139 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var (
+	selectUnpublish = regexp.QuoteMeta(`SELECT "id" FROM "products" WHERE unpublish_at <= $1`)
+	updateUnpublish = regexp.QuoteMeta(`UPDATE "products" SET "publish_at"=CASE WHEN publish_at <= unpublish_at THEN NULL ELSE publish_at END,"status"=CASE WHEN status = $1 THEN $2 ELSE status END,"unpublish_at"=$3`)
+	selectPublish   = regexp.QuoteMeta(`SELECT "id" FROM "products" WHERE publish_at <= $1`)
+	updatePublish   = regexp.QuoteMeta(`UPDATE "products" SET "publish_at"=$1,"status"=$2`)
+)
+
+// newMockDB 返回连接到 sqlmock 的 GORM 实例，按顺序校验执行的 SQL
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, mock
+}
+
+func ids(values ...int) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id"})
+	for _, v := range values {
+		rows.AddRow(v)
+	}
+	return rows
+}
+
+func TestRunProductSchedule(t *testing.T) {
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	errDB := errors.New("connection reset")
+
+	tests := []struct {
+		name    string
+		expect  func(mock sqlmock.Sqlmock)
+		want    []uint
+		wantErr error
+	}{
+		{
+			name: "nothing due",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectUnpublish).WillReturnRows(ids())
+				mock.ExpectQuery(selectPublish).WillReturnRows(ids())
+			},
+		},
+		{
+			name: "unpublish only",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectUnpublish).WillReturnRows(ids(1))
+				mock.ExpectBegin()
+				mock.ExpectExec(updateUnpublish).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectQuery(selectPublish).WillReturnRows(ids())
+			},
+			want: []uint{1},
+		},
+		{
+			name: "publish only",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectUnpublish).WillReturnRows(ids())
+				mock.ExpectQuery(selectPublish).WillReturnRows(ids(2, 3))
+				mock.ExpectBegin()
+				mock.ExpectExec(updatePublish).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			want: []uint{2, 3},
+		},
+		{
+			// 下架先于上架执行: 停机期间错过的 "上架 -> 下架" 窗口不会把商品重新上架，之后的上架计划仍然生效
+			name: "unpublish runs before publish",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectUnpublish).WillReturnRows(ids(1, 2))
+				mock.ExpectBegin()
+				mock.ExpectExec(updateUnpublish).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+				mock.ExpectQuery(selectPublish).WillReturnRows(ids(2))
+				mock.ExpectBegin()
+				mock.ExpectExec(updatePublish).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: []uint{1, 2, 2},
+		},
+		{
+			name: "unpublish failure stops the run",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectUnpublish).WillReturnRows(ids(1))
+				mock.ExpectBegin()
+				mock.ExpectExec(updateUnpublish).WillReturnError(errDB)
+				mock.ExpectRollback()
+			},
+			wantErr: errDB,
+		},
+		{
+			name: "publish failure keeps unpublished ids",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(selectUnpublish).WillReturnRows(ids(1))
+				mock.ExpectBegin()
+				mock.ExpectExec(updateUnpublish).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectQuery(selectPublish).WillReturnError(errDB)
+			},
+			want:    []uint{1},
+			wantErr: errDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			tt.expect(mock)
+
+			got, err := RunProductSchedule(db, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RunProductSchedule() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RunProductSchedule() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// 搜索分析使用的 MongoDB 集合
+const (
+	SearchLogCollection   = "search_logs"
+	SearchClickCollection = "search_clicks"
+)
+
+// 搜索来源
+const (
+	SourceProducts = "products" // GET /products?search=
+	SourceSearch   = "search"   // GET /search/products
+)
+
+var (
+	ErrAnalyticsDisabled = errors.New("search analytics requires MongoDB")
+	ErrSearchNotFound    = errors.New("search log not found")
+)
+
+// QueryStat 单个查询在时间范围内的统计
+type QueryStat struct {
+	Query           string  `json:"query" bson:"_id"`
+	Searches        int64   `json:"searches" bson:"searches"`                 // 搜索次数
+	ZeroResults     int64   `json:"zero_results" bson:"zero_results"`         // 无结果次数
+	AvgResults      float64 `json:"avg_results" bson:"avg_results"`           // 平均命中数
+	AvgLatencyMs    float64 `json:"avg_latency_ms" bson:"avg_latency_ms"`     // 平均耗时 (毫秒)
+	Clicks          int64   `json:"clicks" bson:"clicks"`                     // 点击次数
+	ClickedSearches int64   `json:"clicked_searches" bson:"clicked_searches"` // 至少有一次点击的搜索次数
+	CTR             float64 `json:"ctr" bson:"ctr"`                           // 点击率 = ClickedSearches / Searches
+}
+
+// InitAnalytics 创建搜索分析集合的索引
+func InitAnalytics() {
+	if config.MongoDB == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := config.MongoDB.Collection(SearchLogCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "created_at", Value: 1}}},
+		{Keys: bson.D{{Key: "normalized", Value: 1}, {Key: "created_at", Value: 1}}},
+	}); err != nil {
+		log.Printf("Failed to create search log indexes: %v", err)
+	}
+	if _, err := config.MongoDB.Collection(SearchClickCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "search_id", Value: 1}},
+	}); err != nil {
+		log.Printf("Failed to create search click indexes: %v", err)
+	}
+}
+
+// SearchFilters 提取搜索请求中除关键词外的查询参数，多值参数以逗号连接
+func SearchFilters(values url.Values, exclude ...string) map[string]string {
+	filters := make(map[string]string)
+	for key, v := range values {
+		filters[key] = strings.Join(v, ",")
+	}
+	for _, key := range exclude {
+		delete(filters, key)
+	}
+	return filters
+}
+
+// LogSearch 异步记录一次搜索，返回搜索日志 ID (用于关联点击事件)
+// MongoDB 不可用时不记录并返回空字符串，写入失败只记录日志，不影响搜索请求
+func LogSearch(entry models.SearchLog) string {
+	if config.MongoDB == nil {
+		return ""
+	}
+	entry.ID = primitive.NewObjectID()
+	entry.Normalized = normalizeKeyword(entry.Query)
+	entry.CreatedAt = time.Now()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := config.MongoDB.Collection(SearchLogCollection).InsertOne(ctx, entry); err != nil {
+			log.Printf("Failed to log search %q: %v", entry.Query, err)
+		}
+	}()
+	return entry.ID.Hex()
+}
+
+// RecordClick 记录搜索结果点击，searchID 必须对应已记录的搜索日志
+func RecordClick(searchID string, userID uint, productID uint, position int) error {
+	if config.MongoDB == nil {
+		return ErrAnalyticsDisabled
+	}
+	id, err := primitive.ObjectIDFromHex(searchID)
+	if err != nil {
+		return ErrSearchNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var entry models.SearchLog
+	if err := config.MongoDB.Collection(SearchLogCollection).FindOne(ctx, bson.M{"_id": id}).Decode(&entry); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return ErrSearchNotFound
+		}
+		return err
+	}
+
+	_, err = config.MongoDB.Collection(SearchClickCollection).InsertOne(ctx, models.SearchClick{
+		SearchID:   id,
+		UserID:     userID,
+		Normalized: entry.Normalized,
+		ProductID:  productID,
+		Position:   position,
+		CreatedAt:  time.Now(),
+	})
+	return err
+}
+
+// queryReport 按查询聚合 [from, to) 内的搜索日志，关联点击事件计算点击率
+// having 为聚合后的过滤条件，sort 为排序方式
+func queryReport(from, to time.Time, having bson.M, sort bson.D, limit int) ([]QueryStat, error) {
+	if config.MongoDB == nil {
+		return nil, ErrAnalyticsDisabled
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pipeline := []bson.M{
+		{"$match": bson.M{"created_at": bson.M{"$gte": from, "$lt": to}}},
+		{"$lookup": bson.M{
+			"from":         SearchClickCollection,
+			"localField":   "_id",
+			"foreignField": "search_id",
+			"as":           "clicks",
+		}},
+		{"$group": bson.M{
+			"_id":              "$normalized",
+			"searches":         bson.M{"$sum": 1},
+			"zero_results":     bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$result_count", 0}}, 1, 0}}},
+			"avg_results":      bson.M{"$avg": "$result_count"},
+			"avg_latency_ms":   bson.M{"$avg": "$latency_ms"},
+			"clicks":           bson.M{"$sum": bson.M{"$size": "$clicks"}},
+			"clicked_searches": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{bson.M{"$size": "$clicks"}, 0}}, 1, 0}}},
+		}},
+		{"$addFields": bson.M{"ctr": bson.M{"$divide": bson.A{"$clicked_searches", "$searches"}}}},
+	}
+	if len(having) > 0 {
+		pipeline = append(pipeline, bson.M{"$match": having})
+	}
+	pipeline = append(pipeline, bson.M{"$sort": sort}, bson.M{"$limit": limit})
+
+	cursor, err := config.MongoDB.Collection(SearchLogCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	stats := []QueryStat{}
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// TopQueries 搜索次数最多的查询
+func TopQueries(from, to time.Time, limit int) ([]QueryStat, error) {
+	return queryReport(from, to, nil, bson.D{{Key: "searches", Value: -1}, {Key: "_id", Value: 1}}, limit)
+}
+
+// ZeroResultQueries 出现过无结果的查询，按无结果次数排序
+func ZeroResultQueries(from, to time.Time, limit int) ([]QueryStat, error) {
+	return queryReport(from, to, bson.M{"zero_results": bson.M{"$gt": 0}},
+		bson.D{{Key: "zero_results", Value: -1}, {Key: "_id", Value: 1}}, limit)
+}
+
+// ClickThroughRates 搜索次数不少于 minSearches 的查询的点击率，点击率低的排在前面
+func ClickThroughRates(from, to time.Time, minSearches int64, limit int) ([]QueryStat, error) {
+	return queryReport(from, to, bson.M{"searches": bson.M{"$gte": minSearches}},
+		bson.D{{Key: "ctr", Value: 1}, {Key: "searches", Value: -1}, {Key: "_id", Value: 1}}, limit)
+}
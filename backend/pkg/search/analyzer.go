@@ -0,0 +1,199 @@
+package search
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+
+	"github.com/mozillazg/go-pinyin"
+)
+
+const (
+	// dictionaryTTL 同义词和停用词在内存中的缓存时间，多实例部署时其他实例最多延迟该时间生效
+	dictionaryTTL = time.Minute
+	// maxSynonyms 单次查询最多扩展的同义词改写数
+	maxSynonyms = 10
+)
+
+// dictionary 查询改写使用的同义词组和停用词
+type dictionary struct {
+	synonyms  [][]string
+	stopwords map[string]bool
+	loadedAt  time.Time
+}
+
+var (
+	dictMu sync.Mutex
+	dict   *dictionary
+)
+
+// loadDictionary 返回缓存的词典，过期时从数据库重新加载，加载失败时沿用旧词典
+func loadDictionary() *dictionary {
+	dictMu.Lock()
+	defer dictMu.Unlock()
+
+	if dict != nil && time.Since(dict.loadedAt) < dictionaryTTL {
+		return dict
+	}
+
+	var synonyms []models.SearchSynonym
+	var stopwords []models.SearchStopword
+	if err := config.DB.Find(&synonyms).Error; err != nil {
+		log.Printf("Failed to load search synonyms: %v", err)
+		return fallbackDictionary()
+	}
+	if err := config.DB.Find(&stopwords).Error; err != nil {
+		log.Printf("Failed to load search stopwords: %v", err)
+		return fallbackDictionary()
+	}
+
+	d := &dictionary{stopwords: make(map[string]bool), loadedAt: time.Now()}
+	for _, s := range synonyms {
+		d.synonyms = append(d.synonyms, s.Terms)
+	}
+	for _, s := range stopwords {
+		d.stopwords[s.Word] = true
+	}
+	dict = d
+	return dict
+}
+
+// fallbackDictionary 加载失败时返回旧词典或空词典 (调用方持有锁)
+func fallbackDictionary() *dictionary {
+	if dict != nil {
+		return dict
+	}
+	return &dictionary{stopwords: map[string]bool{}}
+}
+
+// ReloadDictionary 使词典缓存失效，管理员修改同义词或停用词后调用
+func ReloadDictionary() {
+	dictMu.Lock()
+	dict = nil
+	dictMu.Unlock()
+}
+
+// isAllHan 判断字符串是否全部由汉字组成
+func isAllHan(s string) bool {
+	for _, r := range s {
+		if !isHan(r) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// removeStopwords 移除查询中的停用词
+// 字母数字停用词需要整词匹配，中文停用词按子串移除 (中文查询通常不含空格)
+func removeStopwords(text string, stopwords map[string]bool) string {
+	var kept []string
+	for _, field := range strings.Fields(strings.ToLower(text)) {
+		if stopwords[field] {
+			continue
+		}
+		for word := range stopwords {
+			if isAllHan(word) {
+				field = strings.ReplaceAll(field, word, "")
+			}
+		}
+		if field != "" {
+			kept = append(kept, field)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// expandSynonyms 将查询中命中的同义词替换为组内其他词，返回改写后的查询 (不含原查询)
+func expandSynonyms(text string, groups [][]string) []string {
+	var result []string
+	seen := map[string]bool{text: true}
+	for _, group := range groups {
+		for _, term := range group {
+			if term == "" || !strings.Contains(text, term) {
+				continue
+			}
+			for _, other := range group {
+				alt := strings.ReplaceAll(text, term, other)
+				if seen[alt] {
+					continue
+				}
+				seen[alt] = true
+				result = append(result, alt)
+				if len(result) >= maxSynonyms {
+					return result
+				}
+			}
+			break
+		}
+	}
+	return result
+}
+
+// Analyze 查询改写: 移除停用词 (全部为停用词时保留原查询)，并根据同义词组扩展查询
+func Analyze(q Query) Query {
+	d := loadDictionary()
+	if text := removeStopwords(q.Text, d.stopwords); text != "" {
+		q.Text = text
+	} else {
+		q.Text = strings.ToLower(strings.TrimSpace(q.Text))
+	}
+	q.Synonyms = expandSynonyms(q.Text, d.synonyms)
+	return q
+}
+
+// Search 对查询做停用词和同义词处理后交给当前搜索后端，调用方需确认 Default 不为 nil
+func Search(q Query) (*Result, error) {
+	return Default.Search(Analyze(q))
+}
+
+// texts 返回原查询及同义词改写后的全部查询文本
+func (q Query) texts() []string {
+	return append([]string{q.Text}, q.Synonyms...)
+}
+
+// highlightTerms 返回原查询及同义词的高亮词
+func (q Query) highlightTerms() []string {
+	var terms []string
+	for _, text := range q.texts() {
+		terms = append(terms, Terms(text)...)
+	}
+	return terms
+}
+
+// PinyinTokens 生成商品名称的拼音索引词
+// 每个汉字串从每个字开始到串尾生成全拼和首字母 (首字母至少两个字)，
+// 如 "苹果手机" 生成 pingguoshouji、pgsj、guoshouji、gsj、shouji、sj、ji，
+// 查询时对字母串做前缀匹配，因此 "pingguo"、"pg"、"shouji" 都能命中
+func PinyinTokens(text string) []string {
+	var tokens []string
+	seen := make(map[string]bool)
+	add := func(token string) {
+		if token != "" && !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+
+	args := pinyin.NewArgs()
+	for _, run := range splitRuns(text) {
+		if !isHan([]rune(run)[0]) {
+			continue
+		}
+		syllables := pinyin.LazyPinyin(run, args)
+		for i := range syllables {
+			add(strings.Join(syllables[i:], ""))
+			if len(syllables)-i >= 2 {
+				var initials strings.Builder
+				for _, s := range syllables[i:] {
+					initials.WriteByte(s[0])
+				}
+				add(initials.String())
+			}
+		}
+	}
+	return tokens
+}
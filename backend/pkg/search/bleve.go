@@ -0,0 +1,254 @@
+package search
+
+import (
+	"strconv"
+	"strings"
+
+	"go-flutter-mall/backend/models"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/simple"
+	"github.com/blevesearch/bleve/v2/analysis/lang/cjk"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"gorm.io/gorm"
+)
+
+// nameBoost 名称字段相对描述字段的权重
+const nameBoost = 3.0
+
+// bleveDoc 写入 bleve 索引的商品文档
+type bleveDoc struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Pinyin      string  `json:"pinyin"` // 名称的拼音索引词，空格分隔
+	CategoryID  string  `json:"category_id"`
+	Price       float64 `json:"price"`
+	Status      float64 `json:"status"`
+}
+
+// BleveEngine 基于 bleve 的嵌入式搜索后端
+// 索引保存在本地磁盘，不依赖数据库扩展；名称和描述使用 CJK 分词，名称权重高于描述，
+// 名称拼音单独建字段用于前缀匹配
+type BleveEngine struct {
+	index bleve.Index
+}
+
+// NewBleveEngine 打开 path 下的索引，不存在时创建新索引
+// 新建的索引为空，Init 会在后台从数据库导入商品
+func NewBleveEngine(path string) (*BleveEngine, error) {
+	index, err := bleve.Open(path)
+	if err == bleve.ErrorIndexPathDoesNotExist {
+		index, err = bleve.New(path, newBleveMapping())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &BleveEngine{index: index}, nil
+}
+
+// Empty 索引中是否没有任何文档
+func (e *BleveEngine) Empty() bool {
+	count, err := e.index.DocCount()
+	return err == nil && count == 0
+}
+
+// newBleveMapping 商品文档的字段映射
+func newBleveMapping() mapping.IndexMapping {
+	text := bleve.NewTextFieldMapping()
+	text.Analyzer = cjk.AnalyzerName
+	text.Store = true
+
+	pinyinField := bleve.NewTextFieldMapping()
+	pinyinField.Analyzer = simple.Name
+
+	keywordField := bleve.NewTextFieldMapping()
+	keywordField.Analyzer = keyword.Name
+
+	numeric := bleve.NewNumericFieldMapping()
+
+	doc := bleve.NewDocumentMapping()
+	doc.AddFieldMappingsAt("name", text)
+	doc.AddFieldMappingsAt("description", text)
+	doc.AddFieldMappingsAt("pinyin", pinyinField)
+	doc.AddFieldMappingsAt("category_id", keywordField)
+	doc.AddFieldMappingsAt("price", numeric)
+	doc.AddFieldMappingsAt("status", numeric)
+
+	m := bleve.NewIndexMapping()
+	m.DefaultMapping = doc
+	return m
+}
+
+// docID 商品在索引中的文档 ID
+func docID(productID uint) string {
+	return strconv.FormatUint(uint64(productID), 10)
+}
+
+// toDoc 将商品转换为索引文档
+func toDoc(product *models.Product) bleveDoc {
+	return bleveDoc{
+		Name:        product.Name,
+		Description: product.Description,
+		Pinyin:      strings.Join(PinyinTokens(product.Name), " "),
+		CategoryID:  docID(product.CategoryID),
+		Price:       product.Price,
+		Status:      float64(product.Status),
+	}
+}
+
+// Index 写入或覆盖商品文档，下架商品同样写入，查询时按 status 过滤
+func (e *BleveEngine) Index(product *models.Product) error {
+	return e.index.Index(docID(product.ID), toDoc(product))
+}
+
+// Delete 从索引中删除商品
+func (e *BleveEngine) Delete(productID uint) error {
+	return e.index.Delete(docID(productID))
+}
+
+// Rebuild 从数据库重新导入全部商品
+// 先写入现有商品，再删除索引中已不存在于数据库的文档
+func (e *BleveEngine) Rebuild(db *gorm.DB) error {
+	seen := make(map[string]bool)
+	var batch []models.Product
+	err := db.Select("id", "name", "description", "category_id", "price", "status").
+		FindInBatches(&batch, 200, func(tx *gorm.DB, _ int) error {
+			b := e.index.NewBatch()
+			for i := range batch {
+				id := docID(batch[i].ID)
+				seen[id] = true
+				if err := b.Index(id, toDoc(&batch[i])); err != nil {
+					return err
+				}
+			}
+			return e.index.Batch(b)
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	total, err := e.index.DocCount()
+	if err != nil {
+		return err
+	}
+	req := bleve.NewSearchRequestOptions(bleve.NewMatchAllQuery(), int(total), 0, false)
+	res, err := e.index.Search(req)
+	if err != nil {
+		return err
+	}
+	b := e.index.NewBatch()
+	for _, hit := range res.Hits {
+		if !seen[hit.ID] {
+			b.Delete(hit.ID)
+		}
+	}
+	return e.index.Batch(b)
+}
+
+// textQueries 单个查询文本对应的子查询: 名称、描述匹配，以及字母串对拼音的前缀匹配
+func textQueries(text string) []query.Query {
+	name := bleve.NewMatchQuery(text)
+	name.SetField("name")
+	name.SetOperator(query.MatchQueryOperatorAnd)
+	name.SetBoost(nameBoost)
+	description := bleve.NewMatchQuery(text)
+	description.SetField("description")
+	description.SetOperator(query.MatchQueryOperatorAnd)
+	queries := []query.Query{name, description}
+
+	if _, prefixes := QueryTokens(text); len(prefixes) > 0 {
+		pinyinQuery := bleve.NewConjunctionQuery()
+		for _, p := range prefixes {
+			prefix := bleve.NewPrefixQuery(p)
+			prefix.SetField("pinyin")
+			pinyinQuery.AddQuery(prefix)
+		}
+		queries = append(queries, pinyinQuery)
+	}
+	return queries
+}
+
+// Search 检索上架商品，返回分页命中、高亮和分面
+func (e *BleveEngine) Search(q Query) (*Result, error) {
+	result := &Result{Hits: []Hit{}, Facets: Facets{Categories: []FacetCount{}, PriceRanges: []FacetCount{}}}
+
+	// 原查询和同义词改写任一命中即可
+	matches := bleve.NewDisjunctionQuery()
+	for _, text := range q.texts() {
+		matches.AddQuery(textQueries(text)...)
+	}
+
+	onShelf, inclusive := 1.0, true
+	status := bleve.NewNumericRangeInclusiveQuery(&onShelf, &onShelf, &inclusive, &inclusive)
+	status.SetField("status")
+
+	conjuncts := []query.Query{matches, status}
+	if len(q.CategoryIDs) > 0 {
+		categories := bleve.NewDisjunctionQuery()
+		for _, id := range q.CategoryIDs {
+			term := bleve.NewTermQuery(docID(id))
+			term.SetField("category_id")
+			categories.AddQuery(term)
+		}
+		conjuncts = append(conjuncts, categories)
+	}
+
+	req := bleve.NewSearchRequestOptions(bleve.NewConjunctionQuery(conjuncts...), q.PageSize, (q.Page-1)*q.PageSize, false)
+	req.Fields = []string{"name", "description"}
+
+	req.AddFacet("categories", bleve.NewFacetRequest("category_id", 100))
+	priceFacet := bleve.NewFacetRequest("price", len(PriceRanges))
+	for _, r := range PriceRanges {
+		min := r.Min
+		if r.Max > 0 {
+			max := r.Max
+			priceFacet.AddNumericRange(r.Label(), &min, &max)
+		} else {
+			priceFacet.AddNumericRange(r.Label(), &min, nil)
+		}
+	}
+	req.AddFacet("price", priceFacet)
+
+	res, err := e.index.Search(req)
+	if err != nil {
+		return nil, err
+	}
+	result.Total = int64(res.Total)
+
+	if facet, ok := res.Facets["categories"]; ok && facet.Terms != nil {
+		for _, t := range facet.Terms.Terms() {
+			result.Facets.Categories = append(result.Facets.Categories, FacetCount{Term: t.Term, Count: int64(t.Count)})
+		}
+	}
+	// 价格区间按 PriceRanges 顺序返回，未命中的区间计数为 0
+	counts := make(map[string]int64)
+	if facet, ok := res.Facets["price"]; ok {
+		for _, r := range facet.NumericRanges {
+			counts[r.Name] = int64(r.Count)
+		}
+	}
+	for _, r := range PriceRanges {
+		result.Facets.PriceRanges = append(result.Facets.PriceRanges, FacetCount{Term: r.Label(), Count: counts[r.Label()]})
+	}
+
+	terms := q.highlightTerms()
+	for _, hit := range res.Hits {
+		id, err := strconv.ParseUint(hit.ID, 10, 64)
+		if err != nil {
+			continue
+		}
+		nameText, _ := hit.Fields["name"].(string)
+		descText, _ := hit.Fields["description"].(string)
+		result.Hits = append(result.Hits, Hit{
+			ProductID: uint(id),
+			Score:     hit.Score,
+			Highlight: Highlight{
+				Name:        MakeHighlight(nameText, terms, highlightWidth),
+				Description: MakeHighlight(descText, terms, highlightWidth),
+			},
+		})
+	}
+	return result, nil
+}
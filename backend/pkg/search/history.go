@@ -0,0 +1,119 @@
+package search
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// HistoryCollection 用户搜索历史集合
+	HistoryCollection = "search_history"
+	// historyTTLIndex 搜索历史 TTL 索引名
+	historyTTLIndex = "created_at_ttl"
+)
+
+// InitHistory 创建搜索历史集合的索引
+// (user_id, keyword) 唯一索引保证每个用户的同一关键词只有一条记录，(user_id, created_at) 用于按时间查询和裁剪，
+// created_at 上的 TTL 索引按 SEARCH_HISTORY_RETENTION_DAYS 自动删除过期记录
+func InitHistory() {
+	if config.MongoDB == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	collection := config.MongoDB.Collection(HistoryCollection)
+
+	if _, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "keyword", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	}); err != nil {
+		log.Printf("Failed to create search history indexes: %v", err)
+	}
+
+	if config.SearchHistoryRetentionDays <= 0 {
+		// 永久保留: 移除之前创建的 TTL 索引 (不存在时忽略错误)
+		collection.Indexes().DropOne(ctx, historyTTLIndex)
+		return
+	}
+	ttl := int32(config.SearchHistoryRetentionDays * 24 * 3600)
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "created_at", Value: 1}},
+		Options: options.Index().SetName(historyTTLIndex).SetExpireAfterSeconds(ttl),
+	})
+	if err != nil {
+		// 保留天数变更时索引选项冲突，通过 collMod 修改过期时间
+		cmd := bson.D{
+			{Key: "collMod", Value: HistoryCollection},
+			{Key: "index", Value: bson.D{{Key: "name", Value: historyTTLIndex}, {Key: "expireAfterSeconds", Value: ttl}}},
+		}
+		if modErr := config.MongoDB.RunCommand(ctx, cmd).Err(); modErr != nil {
+			log.Printf("Failed to create search history TTL index: %v (collMod: %v)", err, modErr)
+		}
+	}
+}
+
+// TrimHistory 只保留用户最新的 SEARCH_HISTORY_MAX_PER_USER 条搜索历史
+func TrimHistory(ctx context.Context, userID interface{}) error {
+	max := config.SearchHistoryMaxPerUser
+	if max <= 0 {
+		return nil
+	}
+	collection := config.MongoDB.Collection(HistoryCollection)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64(max)).
+		SetProjection(bson.M{"_id": 1})
+	cursor, err := collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return err
+	}
+	var stale []bson.M
+	if err := cursor.All(ctx, &stale); err != nil {
+		return err
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	ids := make([]interface{}, 0, len(stale))
+	for _, doc := range stale {
+		ids = append(ids, doc["_id"])
+	}
+	_, err = collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	return err
+}
+
+// RecentKeywords 用户最近搜索的关键词，按时间倒序，最多 limit 个；MongoDB 不可用时返回空列表
+func RecentKeywords(ctx context.Context, userID uint, limit int) ([]string, error) {
+	if config.MongoDB == nil {
+		return nil, nil
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{"keyword": 1})
+	cursor, err := config.MongoDB.Collection(HistoryCollection).Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var history []models.SearchHistory
+	if err := cursor.All(ctx, &history); err != nil {
+		return nil, err
+	}
+	keywords := make([]string, 0, len(history))
+	for _, h := range history {
+		keywords = append(keywords, h.Keyword)
+	}
+	return keywords, nil
+}
@@ -0,0 +1,194 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// HotKeywordsKey Redis 中缓存热搜统计结果的键
+	HotKeywordsKey = "search:hot_keywords"
+	// HotWindow 热搜统计的时间窗口
+	HotWindow = 7 * 24 * time.Hour
+	// HotRefreshInterval 热搜统计的刷新间隔
+	HotRefreshInterval = 10 * time.Minute
+	// hotPoolSize 缓存的热门关键词数量，同时作为搜索建议的候选池
+	hotPoolSize = 200
+)
+
+// HotKeyword 热门关键词
+type HotKeyword struct {
+	Keyword string `json:"keyword" bson:"_id"`
+	Count   int64  `json:"count" bson:"count"` // 时间窗口内搜索过该词的用户数，置顶词为 0
+	Pinned  bool   `json:"pinned" bson:"-"`
+}
+
+// normalizeKeyword 统一关键词大小写和首尾空白
+func normalizeKeyword(keyword string) string {
+	return strings.ToLower(strings.TrimSpace(keyword))
+}
+
+// aggregateHotKeywords 统计时间窗口内的热门关键词
+// search_history 中每个用户的同一关键词只保留一条记录，计数即搜索该词的用户数
+func aggregateHotKeywords() ([]HotKeyword, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pipeline := []bson.M{
+		{"$match": bson.M{"created_at": bson.M{"$gte": time.Now().Add(-HotWindow)}}},
+		{"$group": bson.M{"_id": bson.M{"$toLower": "$keyword"}, "count": bson.M{"$sum": 1}}},
+		{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+		{"$limit": hotPoolSize},
+	}
+	cursor, err := config.MongoDB.Collection(HistoryCollection).Aggregate(ctx, pipeline, options.Aggregate())
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	keywords := []HotKeyword{}
+	if err := cursor.All(ctx, &keywords); err != nil {
+		return nil, err
+	}
+	return keywords, nil
+}
+
+// RefreshHotKeywords 重新统计热门关键词并写入 Redis 缓存
+func RefreshHotKeywords() ([]HotKeyword, error) {
+	keywords, err := aggregateHotKeywords()
+	if err != nil {
+		return nil, err
+	}
+	if config.RedisClient != nil {
+		data, _ := json.Marshal(keywords)
+		// 缓存有效期为两个刷新周期，刷新任务停止后缓存自然过期
+		if err := config.RedisClient.Set(context.Background(), HotKeywordsKey, data, 2*HotRefreshInterval).Err(); err != nil {
+			log.Printf("Failed to cache hot keywords: %v", err)
+		}
+	}
+	return keywords, nil
+}
+
+// loadHotKeywords 读取热门关键词候选池 (未应用置顶/屏蔽)
+// 优先读取 Redis 缓存，缓存缺失或 Redis 不可用时直接统计；MongoDB 不可用时返回空列表
+func loadHotKeywords() []HotKeyword {
+	if config.RedisClient != nil {
+		if data, err := config.RedisClient.Get(context.Background(), HotKeywordsKey).Bytes(); err == nil {
+			var keywords []HotKeyword
+			if json.Unmarshal(data, &keywords) == nil {
+				return keywords
+			}
+		}
+	}
+	if config.MongoDB == nil {
+		return []HotKeyword{}
+	}
+	keywords, err := RefreshHotKeywords()
+	if err != nil {
+		log.Printf("Failed to aggregate hot keywords: %v", err)
+		return []HotKeyword{}
+	}
+	return keywords
+}
+
+// loadSearchTerms 读取管理员配置的置顶词 (按排序) 和屏蔽词
+func loadSearchTerms() (pinned []string, blocked map[string]bool) {
+	blocked = make(map[string]bool)
+	var terms []models.SearchTerm
+	if err := config.DB.Order("sort_order ASC, id ASC").Find(&terms).Error; err != nil {
+		log.Printf("Failed to load search terms: %v", err)
+		return nil, blocked
+	}
+	for _, t := range terms {
+		switch t.Action {
+		case models.SearchTermPin:
+			pinned = append(pinned, t.Keyword)
+		case models.SearchTermBlock:
+			blocked[t.Keyword] = true
+		}
+	}
+	return pinned, blocked
+}
+
+// HotKeywords 返回热搜榜: 置顶词在前，其余按热度排序，屏蔽词不出现
+func HotKeywords(limit int) []HotKeyword {
+	pinned, blocked := loadSearchTerms()
+
+	result := []HotKeyword{}
+	seen := make(map[string]bool)
+	for _, keyword := range pinned {
+		if len(result) >= limit {
+			return result
+		}
+		result = append(result, HotKeyword{Keyword: keyword, Pinned: true})
+		seen[keyword] = true
+	}
+	for _, hot := range loadHotKeywords() {
+		if len(result) >= limit {
+			break
+		}
+		if seen[hot.Keyword] || blocked[hot.Keyword] {
+			continue
+		}
+		result = append(result, hot)
+		seen[hot.Keyword] = true
+	}
+	return result
+}
+
+// SuggestKeywords 返回以 prefix 开头的热门关键词，置顶词优先，屏蔽词除外
+func SuggestKeywords(prefix string, limit int) []string {
+	prefix = normalizeKeyword(prefix)
+	pinned, blocked := loadSearchTerms()
+
+	candidates := make([]HotKeyword, 0, len(pinned))
+	for _, keyword := range pinned {
+		candidates = append(candidates, HotKeyword{Keyword: keyword, Pinned: true})
+	}
+	candidates = append(candidates, loadHotKeywords()...)
+
+	result := []string{}
+	seen := make(map[string]bool)
+	for _, c := range candidates {
+		if len(result) >= limit {
+			break
+		}
+		if !strings.HasPrefix(c.Keyword, prefix) || seen[c.Keyword] || blocked[c.Keyword] {
+			continue
+		}
+		result = append(result, c.Keyword)
+		seen[c.Keyword] = true
+	}
+	return result
+}
+
+// StartHotKeywordsRefresher 定时刷新热搜统计
+func StartHotKeywordsRefresher() {
+	if config.MongoDB == nil {
+		log.Println("MongoDB is disabled, hot keywords refresher will not start.")
+		return
+	}
+
+	go func() {
+		if _, err := RefreshHotKeywords(); err != nil {
+			log.Printf("Failed to refresh hot keywords: %v", err)
+		}
+		ticker := time.NewTicker(HotRefreshInterval)
+		for range ticker.C {
+			if _, err := RefreshHotKeywords(); err != nil {
+				log.Printf("Failed to refresh hot keywords: %v", err)
+			}
+		}
+	}()
+
+	log.Println("Hot keywords refresher started...")
+}
@@ -0,0 +1,213 @@
+package search
+
+import (
+	"log"
+	"strings"
+
+	"go-flutter-mall/backend/models"
+
+	"gorm.io/gorm"
+)
+
+// highlightWidth 高亮片段的最大字符数
+const highlightWidth = 60
+
+// PostgresEngine 基于 PostgreSQL 全文检索的搜索后端
+// products.search_vector 保存名称 (权重 A)、描述 (权重 B) 和名称拼音 (权重 C) 的 tsvector，使用 GIN 索引；
+// 安装 pg_trgm 时额外对名称建立 trigram 索引，用于拼写容错
+type PostgresEngine struct {
+	db       *gorm.DB
+	tsConfig string // 中文分词配置名，为空表示使用 n-gram 分词
+	trigram  bool   // pg_trgm 是否可用
+}
+
+// NewPostgresEngine 创建 PostgreSQL 搜索后端
+// 负责创建 search_vector 列和索引，并为尚未建立索引的商品回填 search_vector
+func NewPostgresEngine(db *gorm.DB, tsConfig string) (*PostgresEngine, error) {
+	e := &PostgresEngine{db: db}
+
+	if tsConfig != "" {
+		var count int64
+		if err := db.Raw("SELECT COUNT(*) FROM pg_ts_config WHERE cfgname = ?", tsConfig).Scan(&count).Error; err != nil || count == 0 {
+			log.Printf("Text search config %q not found, using n-gram tokenization", tsConfig)
+		} else {
+			e.tsConfig = tsConfig
+		}
+	}
+
+	if err := db.Exec("ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector tsvector").Error; err != nil {
+		return nil, err
+	}
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING GIN (search_vector)").Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		log.Printf("pg_trgm is not available, typo tolerance disabled: %v", err)
+	} else if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_products_name_trgm ON products USING GIN (name gin_trgm_ops)").Error; err != nil {
+		log.Printf("Failed to create trigram index, typo tolerance disabled: %v", err)
+	} else {
+		e.trigram = true
+	}
+
+	if err := e.reindex("search_vector IS NULL"); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// vectorExpr 返回计算商品 tsvector 的 SQL 表达式及参数
+// 拼音索引词只包含小写字母，统一使用 simple 配置
+func (e *PostgresEngine) vectorExpr(name, description string) (string, []interface{}) {
+	pinyinExpr := " || setweight(to_tsvector('simple', ?), 'C')"
+	pinyinArg := strings.Join(PinyinTokens(name), " ")
+	if e.tsConfig != "" {
+		return "setweight(to_tsvector(?::regconfig, ?), 'A') || setweight(to_tsvector(?::regconfig, ?), 'B')" + pinyinExpr,
+			[]interface{}{e.tsConfig, name, e.tsConfig, description, pinyinArg}
+	}
+	return "setweight(to_tsvector('simple', ?), 'A') || setweight(to_tsvector('simple', ?), 'B')" + pinyinExpr,
+		[]interface{}{strings.Join(Tokenize(name), " "), strings.Join(Tokenize(description), " "), pinyinArg}
+}
+
+// queryExpr 返回查询关键词对应的 tsquery 表达式及参数，无有效词时返回空字符串
+func (e *PostgresEngine) queryExpr(text string) (string, []interface{}) {
+	terms, prefixes := QueryTokens(text)
+	if e.tsConfig != "" {
+		// 字母串额外按前缀匹配拼音索引词
+		if len(prefixes) > 0 {
+			return "(websearch_to_tsquery(?::regconfig, ?) || to_tsquery('simple', ?))",
+				[]interface{}{e.tsConfig, text, strings.Join(prefixes, ":* & ") + ":*"}
+		}
+		return "websearch_to_tsquery(?::regconfig, ?)", []interface{}{e.tsConfig, text}
+	}
+
+	// n-gram 分词结果只包含字母、数字和汉字，可以直接拼接为 tsquery；
+	// 字母串的前缀匹配同时覆盖拼音索引词
+	parts := append([]string{}, terms...)
+	for _, p := range prefixes {
+		parts = append(parts, p+":*")
+	}
+	if len(parts) == 0 {
+		return "", nil
+	}
+	return "to_tsquery('simple', ?)", []interface{}{strings.Join(parts, " & ")}
+}
+
+// reindex 重新计算满足条件的商品的 search_vector
+func (e *PostgresEngine) reindex(where string) error {
+	var batch []models.Product
+	return e.db.Select("id", "name", "description").Where(where).
+		FindInBatches(&batch, 200, func(tx *gorm.DB, _ int) error {
+			for i := range batch {
+				if err := e.Index(&batch[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
+
+// Rebuild 重新计算全部商品的 search_vector
+func (e *PostgresEngine) Rebuild(db *gorm.DB) error {
+	return e.reindex("1 = 1")
+}
+
+// Index 更新商品的 search_vector
+func (e *PostgresEngine) Index(product *models.Product) error {
+	expr, args := e.vectorExpr(product.Name, product.Description)
+	return e.db.Exec("UPDATE products SET search_vector = "+expr+" WHERE id = ?", append(args, product.ID)...).Error
+}
+
+// Delete 商品软删除后查询会自动过滤，无需处理
+func (e *PostgresEngine) Delete(productID uint) error {
+	return nil
+}
+
+// Search 全文检索上架商品
+// 相关度 = ts_rank_cd (名称权重高于描述) + 名称 trigram 相似度；
+// 原查询或任一同义词改写命中即返回，全文未命中但名称 trigram 相似 (拼写错误) 的商品也会返回
+func (e *PostgresEngine) Search(q Query) (*Result, error) {
+	result := &Result{Hits: []Hit{}}
+
+	var matches, scores []string
+	var matchArgs, scoreArgs []interface{}
+	for _, text := range q.texts() {
+		tsQuery, tsArgs := e.queryExpr(text)
+		if tsQuery == "" {
+			continue
+		}
+		matches = append(matches, "products.search_vector @@ "+tsQuery)
+		matchArgs = append(matchArgs, tsArgs...)
+		scores = append(scores, "ts_rank_cd(products.search_vector, "+tsQuery+")")
+		scoreArgs = append(scoreArgs, tsArgs...)
+	}
+	if e.trigram && strings.TrimSpace(q.Text) != "" {
+		matches = append(matches, "products.name % ?")
+		matchArgs = append(matchArgs, q.Text)
+		scores = append(scores, "similarity(products.name, ?)")
+		scoreArgs = append(scoreArgs, q.Text)
+	}
+	if len(matches) == 0 {
+		return result, nil
+	}
+
+	base := func() *gorm.DB {
+		query := e.db.Model(&models.Product{}).
+			Where("products.status = ?", models.ProductStatusOn).
+			Where("("+strings.Join(matches, " OR ")+")", matchArgs...)
+		if len(q.CategoryIDs) > 0 {
+			query = query.Where("products.category_id IN ?", q.CategoryIDs)
+		}
+		return query
+	}
+
+	if err := base().Count(&result.Total).Error; err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		ID          uint
+		Name        string
+		Description string
+		Score       float64
+	}
+	if err := base().
+		Select("products.id, products.name, products.description, ("+strings.Join(scores, " + ")+") AS score", scoreArgs...).
+		Order("score DESC").Order("products.id DESC").
+		Limit(q.PageSize).Offset((q.Page - 1) * q.PageSize).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	// 分面统计
+	result.Facets = Facets{Categories: []FacetCount{}, PriceRanges: []FacetCount{}}
+	if err := base().Select("CAST(products.category_id AS TEXT) AS term, COUNT(*) AS count").
+		Group("products.category_id").Order("count DESC").
+		Scan(&result.Facets.Categories).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range PriceRanges {
+		facet := FacetCount{Term: r.Label()}
+		query := base().Where("products.price >= ?", r.Min)
+		if r.Max > 0 {
+			query = query.Where("products.price < ?", r.Max)
+		}
+		if err := query.Count(&facet.Count).Error; err != nil {
+			return nil, err
+		}
+		result.Facets.PriceRanges = append(result.Facets.PriceRanges, facet)
+	}
+
+	terms := q.highlightTerms()
+	for _, row := range rows {
+		result.Hits = append(result.Hits, Hit{
+			ProductID: row.ID,
+			Score:     row.Score,
+			Highlight: Highlight{
+				Name:        MakeHighlight(row.Name, terms, highlightWidth),
+				Description: MakeHighlight(row.Description, terms, highlightWidth),
+			},
+		})
+	}
+	return result, nil
+}
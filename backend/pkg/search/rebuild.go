@@ -0,0 +1,45 @@
+package search
+
+import (
+	"errors"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrEngineDisabled = errors.New("search engine is not available")
+	ErrRebuildRunning = errors.New("search index rebuild is already running")
+)
+
+// rebuilding 是否有重建任务正在执行
+var rebuilding atomic.Bool
+
+// StartRebuild 在后台从数据库重建当前搜索后端的索引，同一时间只执行一个重建任务
+// 在服务进程内执行，不需要停止服务 (bleve 索引文件被服务进程独占，无法由另一个进程打开)
+func StartRebuild(db *gorm.DB) error {
+	engine := Default
+	if engine == nil {
+		return ErrEngineDisabled
+	}
+	if !rebuilding.CompareAndSwap(false, true) {
+		return ErrRebuildRunning
+	}
+	go func() {
+		defer rebuilding.Store(false)
+		start := time.Now()
+		if err := engine.Rebuild(db); err != nil {
+			log.Printf("Failed to rebuild search index: %v", err)
+			return
+		}
+		log.Printf("Search index rebuilt in %v", time.Since(start))
+	}()
+	return nil
+}
+
+// Rebuilding 是否有重建任务正在执行
+func Rebuilding() bool {
+	return rebuilding.Load()
+}
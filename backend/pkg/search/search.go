@@ -0,0 +1,279 @@
+package search
+
+import (
+	"html"
+	"log"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go-flutter-mall/backend/config"
+	"go-flutter-mall/backend/models"
+
+	"gorm.io/gorm"
+)
+
+// Query 搜索请求
+type Query struct {
+	Text        string   // 搜索关键词
+	Synonyms    []string // 同义词改写后的其他查询文本，与 Text 任一匹配即命中 (由 Analyze 填充)
+	CategoryIDs []uint   // 限定分类 (调用方负责展开子孙分类)，为空表示不限
+	Page        int      // 页码，从 1 开始
+	PageSize    int      // 每页数量
+}
+
+// Highlight 高亮片段，命中的词使用 <em></em> 包裹
+type Highlight struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Hit 单条搜索结果
+type Hit struct {
+	ProductID uint      `json:"product_id"`
+	Score     float64   `json:"score"`
+	Highlight Highlight `json:"highlight"`
+}
+
+// FacetCount 分面统计项
+type FacetCount struct {
+	Term  string `json:"term"` // 分类 ID 或价格区间标签
+	Count int64  `json:"count"`
+}
+
+// Facets 搜索结果的分面统计 (基于全部命中，不受分页影响)
+type Facets struct {
+	Categories  []FacetCount `json:"categories"`
+	PriceRanges []FacetCount `json:"price_ranges"`
+}
+
+// Result 搜索结果，Hits 按相关度降序排列
+type Result struct {
+	Hits   []Hit  `json:"hits"`
+	Total  int64  `json:"total"`
+	Facets Facets `json:"facets"`
+}
+
+// PriceRange 价格区间，包含 Min 不包含 Max，Max 为 0 表示不设上限
+type PriceRange struct {
+	Min float64
+	Max float64
+}
+
+// Label 价格区间标签，如 "100-500"、"5000-"
+func (r PriceRange) Label() string {
+	label := strconv.FormatFloat(r.Min, 'f', -1, 64) + "-"
+	if r.Max > 0 {
+		label += strconv.FormatFloat(r.Max, 'f', -1, 64)
+	}
+	return label
+}
+
+// PriceRanges 价格区间分面，商品列表和各搜索后端共用
+var PriceRanges = []PriceRange{
+	{Min: 0, Max: 100},
+	{Min: 100, Max: 500},
+	{Min: 500, Max: 1000},
+	{Min: 1000, Max: 5000},
+	{Min: 5000, Max: 0},
+}
+
+// Engine 商品搜索后端
+// 商品写入时通过 Index/Delete 保持索引同步，只有上架商品会被搜索到
+type Engine interface {
+	Index(product *models.Product) error
+	Delete(productID uint) error
+	Search(q Query) (*Result, error)
+	Rebuild(db *gorm.DB) error // 从数据库重建全部索引
+}
+
+// Default 当前使用的搜索后端，Init 之前为 nil
+var Default Engine
+
+// Init 根据配置初始化搜索后端
+// bleve 索引为空 (首次使用或索引目录被删除) 时在后台从 config.DB 导入全部商品
+func Init() {
+	switch config.SearchBackend {
+	case "postgres", "":
+		engine, err := NewPostgresEngine(config.DB, config.SearchTSConfig)
+		if err != nil {
+			log.Printf("Failed to init postgres search: %v. Falling back to ILIKE search.", err)
+			return
+		}
+		Default = engine
+	case "bleve":
+		engine, err := NewBleveEngine(config.SearchIndexPath)
+		if err != nil {
+			log.Printf("Failed to open bleve index: %v. Falling back to ILIKE search.", err)
+			return
+		}
+		Default = engine
+		if engine.Empty() {
+			log.Println("Bleve index is empty, importing products from database")
+			StartRebuild(config.DB)
+		}
+	default:
+		log.Printf("Unknown search backend %q. Falling back to ILIKE search.", config.SearchBackend)
+	}
+}
+
+// IndexProduct 同步商品到搜索索引，失败只记录日志，不影响商品写入
+func IndexProduct(product *models.Product) {
+	if Default == nil {
+		return
+	}
+	if err := Default.Index(product); err != nil {
+		log.Printf("Failed to index product %d: %v", product.ID, err)
+	}
+}
+
+// DeleteProduct 从搜索索引中移除商品
+func DeleteProduct(productID uint) {
+	if Default == nil {
+		return
+	}
+	if err := Default.Delete(productID); err != nil {
+		log.Printf("Failed to remove product %d from search index: %v", productID, err)
+	}
+}
+
+// isHan 判断是否为汉字
+func isHan(r rune) bool {
+	return unicode.Is(unicode.Han, r)
+}
+
+// splitRuns 将文本切分为连续的汉字串和字母数字串，其余字符作为分隔符
+func splitRuns(text string) []string {
+	var runs []string
+	var current []rune
+	var currentHan bool
+	flush := func() {
+		if len(current) > 0 {
+			runs = append(runs, string(current))
+			current = current[:0]
+		}
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case isHan(r):
+			if !currentHan {
+				flush()
+			}
+			currentHan = true
+			current = append(current, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if currentHan {
+				flush()
+			}
+			currentHan = false
+			current = append(current, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return runs
+}
+
+// Tokenize 索引时的 n-gram 分词
+// 汉字串切分为单字和相邻两字 (bigram)，字母数字串按整词小写
+func Tokenize(text string) []string {
+	var tokens []string
+	for _, run := range splitRuns(text) {
+		runes := []rune(run)
+		if !isHan(runes[0]) {
+			tokens = append(tokens, run)
+			continue
+		}
+		for i := range runes {
+			tokens = append(tokens, string(runes[i]))
+			if i+1 < len(runes) {
+				tokens = append(tokens, string(runes[i:i+2]))
+			}
+		}
+	}
+	return tokens
+}
+
+// QueryTokens 查询时的 n-gram 分词
+// 汉字串只使用 bigram (单字时使用单字)，要求全部命中；字母数字串按前缀匹配
+func QueryTokens(text string) (terms []string, prefixes []string) {
+	for _, run := range splitRuns(text) {
+		runes := []rune(run)
+		if !isHan(runes[0]) {
+			prefixes = append(prefixes, run)
+			continue
+		}
+		if len(runes) == 1 {
+			terms = append(terms, run)
+			continue
+		}
+		for i := 0; i+1 < len(runes); i++ {
+			terms = append(terms, string(runes[i:i+2]))
+		}
+	}
+	return terms, prefixes
+}
+
+// Terms 返回用于高亮的查询词，汉字串按 bigram 切分以便部分命中也能高亮
+func Terms(text string) []string {
+	terms, prefixes := QueryTokens(text)
+	return append(terms, prefixes...)
+}
+
+// MakeHighlight 在文本中截取首个命中附近的片段，并用 <em></em> 包裹命中的词
+// width 为片段的最大字符数，未命中时返回文本开头
+func MakeHighlight(text string, terms []string, width int) string {
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	if len(lower) != len(runes) {
+		lower = runes
+	}
+
+	// 标记每个字符是否属于命中的词
+	marked := make([]bool, len(runes))
+	first := -1
+	for _, term := range terms {
+		t := []rune(term)
+		if len(t) == 0 {
+			continue
+		}
+		for i := 0; i+len(t) <= len(lower); i++ {
+			if string(lower[i:i+len(t)]) == term {
+				for j := i; j < i+len(t); j++ {
+					marked[j] = true
+				}
+				if first == -1 || i < first {
+					first = i
+				}
+			}
+		}
+	}
+
+	start := 0
+	if first > width/4 {
+		start = first - width/4
+	}
+	end := start + width
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("...")
+	}
+	for i := start; i < end; i++ {
+		if marked[i] && (i == start || !marked[i-1]) {
+			b.WriteString("<em>")
+		}
+		b.WriteString(html.EscapeString(string(runes[i])))
+		if marked[i] && (i+1 == end || !marked[i+1]) {
+			b.WriteString("</em>")
+		}
+	}
+	if end < len(runes) {
+		b.WriteString("...")
+	}
+	return b.String()
+}
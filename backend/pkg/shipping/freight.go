@@ -0,0 +1,133 @@
+package shipping
+
+import (
+	"math"
+
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/utils"
+
+	"gorm.io/gorm"
+)
+
+// Item 参与运费计算的商品行
+type Item struct {
+	TemplateID uint    // 商品引用的运费模板 ID，0 表示使用默认模板
+	Weight     float64 // 单件重量 (kg)
+	Quantity   int     // 数量
+	Amount     float64 // 该行优惠后金额，用于判断满额包邮
+}
+
+// MatchRule 根据收货地址匹配运费规则
+// 优先级: "省/市" 精确匹配 > "省" 匹配 > 默认规则
+func MatchRule(tpl *models.FreightTemplate, province, city string) *models.FreightRule {
+	var provinceRule, defaultRule *models.FreightRule
+	for i := range tpl.Rules {
+		rule := &tpl.Rules[i]
+		if len(rule.Regions) == 0 {
+			defaultRule = rule
+			continue
+		}
+		for _, region := range rule.Regions {
+			if region == province+"/"+city {
+				return rule
+			}
+			if region == province && provinceRule == nil {
+				provinceRule = rule
+			}
+		}
+	}
+	if provinceRule != nil {
+		return provinceRule
+	}
+	return defaultRule
+}
+
+// Fee 按规则计算一组商品的运费
+// units 为件数或重量，amount 为该组商品金额
+func Fee(rule *models.FreightRule, units float64, amount float64) float64 {
+	if rule == nil || units <= 0 {
+		return 0
+	}
+	if rule.FreeThreshold > 0 && amount >= rule.FreeThreshold {
+		return 0
+	}
+
+	fee := rule.FirstFee
+	if units > rule.FirstUnit && rule.AdditionalUnit > 0 {
+		steps := math.Ceil((units - rule.FirstUnit) / rule.AdditionalUnit)
+		fee += steps * rule.AdditionalFee
+	}
+	return utils.RoundAmount(fee)
+}
+
+// Calculate 计算订单运费
+// 商品按运费模板分组，每组独立计费后累加；address 为空时使用各模板的默认规则
+func Calculate(db *gorm.DB, address *models.Address, items []Item) (float64, error) {
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	// 1. 加载涉及的模板 (以及默认模板)
+	var ids []uint
+	for _, item := range items {
+		if item.TemplateID != 0 {
+			ids = append(ids, item.TemplateID)
+		}
+	}
+	var templates []models.FreightTemplate
+	query := db.Preload("Rules").Where("is_default = ?", true)
+	if len(ids) > 0 {
+		query = query.Or("id IN ?", ids)
+	}
+	if err := query.Find(&templates).Error; err != nil {
+		return 0, err
+	}
+
+	byID := make(map[uint]*models.FreightTemplate, len(templates))
+	var defaultTemplate *models.FreightTemplate
+	for i := range templates {
+		byID[templates[i].ID] = &templates[i]
+		if templates[i].IsDefault {
+			defaultTemplate = &templates[i]
+		}
+	}
+
+	// 2. 按模板分组汇总件数/重量和金额
+	type group struct {
+		units  float64
+		amount float64
+	}
+	groups := make(map[*models.FreightTemplate]*group)
+	for _, item := range items {
+		tpl := byID[item.TemplateID]
+		if tpl == nil {
+			tpl = defaultTemplate
+		}
+		if tpl == nil {
+			// 没有任何可用模板，视为包邮
+			continue
+		}
+		g := groups[tpl]
+		if g == nil {
+			g = &group{}
+			groups[tpl] = g
+		}
+		if tpl.ChargeType == models.FreightChargeByWeight {
+			g.units += item.Weight * float64(item.Quantity)
+		} else {
+			g.units += float64(item.Quantity)
+		}
+		g.amount += item.Amount
+	}
+
+	// 3. 分组计费
+	var province, city string
+	if address != nil {
+		province, city = address.Province, address.City
+	}
+	var total float64
+	for tpl, g := range groups {
+		total += Fee(MatchRule(tpl, province, city), g.units, g.amount)
+	}
+	return utils.RoundAmount(total), nil
+}
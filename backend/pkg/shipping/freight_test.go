@@ -0,0 +1,66 @@
+package shipping
+
+import (
+	"testing"
+
+	"go-flutter-mall/backend/models"
+)
+
+func TestMatchRule(t *testing.T) {
+	tpl := models.FreightTemplate{Rules: []models.FreightRule{
+		{FirstFee: 10},
+		{Regions: []string{"广东省"}, FirstFee: 8},
+		{Regions: []string{"北京市", "广东省/深圳市"}, FirstFee: 6},
+	}}
+	tests := []struct {
+		name     string
+		province string
+		city     string
+		wantFee  float64
+	}{
+		{"city match beats province match", "广东省", "深圳市", 6},
+		{"province match", "广东省", "广州市", 8},
+		{"province only region", "北京市", "北京市", 6},
+		{"default rule", "浙江省", "杭州市", 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := MatchRule(&tpl, tt.province, tt.city)
+			if rule == nil || rule.FirstFee != tt.wantFee {
+				t.Fatalf("MatchRule(%s, %s) = %+v, want rule with first fee %v", tt.province, tt.city, rule, tt.wantFee)
+			}
+		})
+	}
+
+	if rule := MatchRule(&models.FreightTemplate{Rules: []models.FreightRule{{Regions: []string{"北京市"}}}}, "上海市", "上海市"); rule != nil {
+		t.Errorf("MatchRule() without default rule = %+v, want nil", rule)
+	}
+}
+
+func TestFee(t *testing.T) {
+	byPiece := &models.FreightRule{FirstUnit: 1, FirstFee: 10, AdditionalUnit: 1, AdditionalFee: 5, FreeThreshold: 99}
+	byWeight := &models.FreightRule{FirstUnit: 1, FirstFee: 12, AdditionalUnit: 0.5, AdditionalFee: 2}
+	tests := []struct {
+		name   string
+		rule   *models.FreightRule
+		units  float64
+		amount float64
+		want   float64
+	}{
+		{"first unit only", byPiece, 1, 50, 10},
+		{"additional units", byPiece, 3, 50, 20},
+		{"free above threshold", byPiece, 3, 99, 0},
+		{"partial weight step rounds up", byWeight, 1.2, 50, 14},
+		{"several weight steps", byWeight, 2.5, 50, 18},
+		{"no threshold means never free", byWeight, 1, 10000, 12},
+		{"no units", byPiece, 0, 50, 0},
+		{"no rule", nil, 3, 50, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Fee(tt.rule, tt.units, tt.amount); got != tt.want {
+				t.Errorf("Fee() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
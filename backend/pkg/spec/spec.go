@@ -0,0 +1,133 @@
+package spec
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go-flutter-mall/backend/models"
+
+	"gorm.io/gorm"
+)
+
+// MaxCombinations 单个商品规格组合 (SKU) 数量上限
+const MaxCombinations = 500
+
+var (
+	ErrNoSpecs             = errors.New("product has no specs")
+	ErrEmptySpec           = errors.New("spec has no values")
+	ErrTooManyCombinations = errors.New("too many spec combinations")
+)
+
+// Combination 一个规格组合，每个规格属性各取一个值
+type Combination struct {
+	Key    string                    // 规格组合键，对应 ProductSKU.SpecKey
+	Name   string                    // 规格值按规格顺序以空格连接，如 "红 XL"
+	Specs  string                    // 规格名到规格值的 JSON，如 {"尺码":"XL","颜色":"红"}
+	Values []models.ProductSpecValue // 按规格顺序排列的规格值
+}
+
+// SKUState 规格组合对应的 SKU 及其可售状态
+type SKUState struct {
+	SKUID     uint    `json:"sku_id"`
+	Price     float64 `json:"price"`
+	Stock     int     `json:"stock"`
+	Image     string  `json:"image,omitempty"`
+	Available bool    `json:"available"` // 有库存即可售
+}
+
+// Key 生成规格组合键: 规格值 ID 升序以逗号连接，与选择顺序无关
+func Key(valueIDs []uint) string {
+	ids := append([]uint(nil), valueIDs...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatUint(uint64(id), 10)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Load 加载商品的规格属性及规格值，均按 SortOrder 排序
+func Load(db *gorm.DB, productID uint) ([]models.ProductSpec, error) {
+	specs := []models.ProductSpec{}
+	err := db.Preload("Values", Ordered).
+		Scopes(Ordered).
+		Where("product_id = ?", productID).
+		Find(&specs).Error
+	return specs, err
+}
+
+// Ordered 按排序权重和 ID 排序，用于规格属性及规格值的查询和预加载
+func Ordered(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order asc, id asc")
+}
+
+// Matrix 生成规格属性的笛卡尔积，顺序与规格及规格值的排序一致
+func Matrix(specs []models.ProductSpec) ([]Combination, error) {
+	if len(specs) == 0 {
+		return nil, ErrNoSpecs
+	}
+	total := 1
+	for _, s := range specs {
+		if len(s.Values) == 0 {
+			return nil, ErrEmptySpec
+		}
+		total *= len(s.Values)
+		if total > MaxCombinations {
+			return nil, ErrTooManyCombinations
+		}
+	}
+
+	combos := [][]models.ProductSpecValue{{}}
+	for _, s := range specs {
+		next := make([][]models.ProductSpecValue, 0, len(combos)*len(s.Values))
+		for _, prefix := range combos {
+			for _, v := range s.Values {
+				values := append(append([]models.ProductSpecValue(nil), prefix...), v)
+				next = append(next, values)
+			}
+		}
+		combos = next
+	}
+
+	result := make([]Combination, 0, len(combos))
+	for _, values := range combos {
+		ids := make([]uint, len(values))
+		names := make([]string, len(values))
+		specMap := make(map[string]string, len(values))
+		for i, v := range values {
+			ids[i] = v.ID
+			names[i] = v.Value
+			specMap[specs[i].Name] = v.Value
+		}
+		specJSON, _ := json.Marshal(specMap)
+		result = append(result, Combination{
+			Key:    Key(ids),
+			Name:   strings.Join(names, " "),
+			Specs:  string(specJSON),
+			Values: values,
+		})
+	}
+	return result, nil
+}
+
+// AvailabilityMap 规格组合键到 SKU 状态的映射，客户端据此置灰无货或不存在的组合
+// 没有规格组合键的 SKU (手动创建) 不参与映射
+func AvailabilityMap(skus []models.ProductSKU) map[string]SKUState {
+	result := make(map[string]SKUState)
+	for _, sku := range skus {
+		if sku.SpecKey == "" {
+			continue
+		}
+		result[sku.SpecKey] = SKUState{
+			SKUID:     sku.ID,
+			Price:     sku.Price,
+			Stock:     sku.Stock,
+			Image:     sku.Image,
+			Available: sku.Stock > 0,
+		}
+	}
+	return result
+}
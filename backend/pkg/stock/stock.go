@@ -0,0 +1,59 @@
+package stock
+
+import (
+	"errors"
+
+	"go-flutter-mall/backend/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrInsufficientStock 商品或 SKU 库存不足
+var ErrInsufficientStock = errors.New("Insufficient stock")
+
+// Deduct 扣减商品库存，skuID 不为 0 时同时扣减该 SKU 的库存
+// 使用带 stock >= quantity 条件的 UPDATE 原子扣减，任一库存不足时返回 ErrInsufficientStock (调用方需回滚事务)
+func Deduct(tx *gorm.DB, productID, skuID uint, quantity int) error {
+	result := tx.Model(&models.Product{}).
+		Where("id = ? AND stock >= ?", productID, quantity).
+		UpdateColumn("stock", gorm.Expr("stock - ?", quantity))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInsufficientStock
+	}
+
+	if skuID == 0 {
+		return nil
+	}
+	result = tx.Model(&models.ProductSKU{}).
+		Where("id = ? AND product_id = ? AND stock >= ?", skuID, productID, quantity).
+		UpdateColumn("stock", gorm.Expr("stock - ?", quantity))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInsufficientStock
+	}
+	return nil
+}
+
+// Restore 恢复订单商品的库存 (订单取消、超时或退款)，包括商品和 SKU 两级库存
+func Restore(tx *gorm.DB, items []models.OrderItem) error {
+	for _, item := range items {
+		if err := tx.Model(&models.Product{}).Where("id = ?", item.ProductID).
+			UpdateColumn("stock", gorm.Expr("stock + ?", item.Quantity)).Error; err != nil {
+			return err
+		}
+		if item.SKUID == 0 {
+			continue
+		}
+		// SKU 已被删除时 (软删除) 不再恢复
+		if err := tx.Model(&models.ProductSKU{}).Where("id = ? AND product_id = ?", item.SKUID, item.ProductID).
+			UpdateColumn("stock", gorm.Expr("stock + ?", item.Quantity)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image"
+	_ "image/gif" // 注册 GIF 解码器
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"time"
+
+	"go-flutter-mall/backend/config"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // 注册 WebP 解码器
+)
+
+// CacheControl 上传文件的缓存头，key 由内容哈希生成，内容不会变化，可长期缓存
+const CacheControl = "public, max-age=31536000, immutable"
+
+// 单张图片的最大像素数，防止解压炸弹
+const maxPixels = 40_000_000
+
+var (
+	ErrUnavailable     = errors.New("storage is not available")
+	ErrUnknownKind     = errors.New("unknown upload kind")
+	ErrTooLarge        = errors.New("image is too large")
+	ErrUnsupportedType = errors.New("unsupported image type, use jpeg, png, gif or webp")
+	ErrInvalidImage    = errors.New("invalid or oversized image")
+)
+
+// 上传用途
+const (
+	KindProduct = "products"
+	KindReview  = "reviews"
+	KindAvatar  = "avatars"
+	KindChat    = "chat"
+)
+
+// Variant 缩略图规格，按最长边缩放，原图更小时不放大
+type Variant struct {
+	Name    string
+	MaxSide int
+}
+
+// policy 各用途的大小限制和缩略图规格
+type policy struct {
+	MaxBytes int64
+	Variants []Variant
+}
+
+func policyOf(kind string) (policy, bool) {
+	maxBytes := int64(config.UploadMaxSizeMB) << 20
+	switch kind {
+	case KindProduct, KindReview:
+		return policy{MaxBytes: maxBytes, Variants: []Variant{{"thumb", 200}, {"medium", 800}}}, true
+	case KindAvatar:
+		return policy{MaxBytes: 2 << 20, Variants: []Variant{{"thumb", 128}}}, true
+	case KindChat:
+		return policy{MaxBytes: maxBytes, Variants: []Variant{{"thumb", 300}}}, true
+	}
+	return policy{}, false
+}
+
+// 允许的图片类型 (按文件内容识别) 及扩展名
+var imageExts = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// Image 上传结果
+// Variants 的键为缩略图规格名 (如 thumb、medium) 及其 WebP 版本 (如 thumb_webp)，值为访问地址
+type Image struct {
+	Key         string            `json:"key"`
+	URL         string            `json:"url"`
+	ContentType string            `json:"content_type"`
+	Size        int64             `json:"size"`
+	Width       int               `json:"width"`
+	Height      int               `json:"height"`
+	Variants    map[string]string `json:"variants"`
+}
+
+// SaveImage 校验并保存图片，生成缩略图及其 WebP 版本
+// 图片类型按文件内容识别，不信任客户端声明的 Content-Type；相同内容的图片得到相同的 key
+func SaveImage(ctx context.Context, kind string, r io.Reader) (*Image, error) {
+	if Default == nil {
+		return nil, ErrUnavailable
+	}
+	p, ok := policyOf(kind)
+	if !ok {
+		return nil, ErrUnknownKind
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, p.MaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > p.MaxBytes {
+		return nil, ErrTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := imageExts[contentType]
+	if !ok {
+		return nil, ErrUnsupportedType
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrInvalidImage
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	sum := sha256.Sum256(data)
+	base := kind + "/" + time.Now().Format("2006/01") + "/" + hex.EncodeToString(sum[:12])
+	result := &Image{
+		Key:         base + ext,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+		Variants:    make(map[string]string),
+	}
+
+	var saved []string
+	put := func(key string, body []byte, contentType string) error {
+		if err := Default.Put(ctx, key, bytes.NewReader(body), int64(len(body)), contentType); err != nil {
+			return err
+		}
+		saved = append(saved, key)
+		return nil
+	}
+	fail := func(err error) (*Image, error) {
+		// 清理已写入的文件，避免残留不完整的一组图片
+		for _, key := range saved {
+			Default.Delete(context.Background(), key)
+		}
+		return nil, err
+	}
+
+	if err := put(result.Key, data, contentType); err != nil {
+		return fail(err)
+	}
+	result.URL = Default.URL(result.Key)
+
+	for _, v := range p.Variants {
+		img := resize(src, v.MaxSide)
+
+		// 缩略图保持原格式类别: 可能带透明通道的 PNG/GIF 输出 PNG，其余输出 JPEG
+		var buf bytes.Buffer
+		thumbExt, thumbType := ".jpg", "image/jpeg"
+		if contentType == "image/png" || contentType == "image/gif" {
+			thumbExt, thumbType = ".png", "image/png"
+			err = png.Encode(&buf, img)
+		} else {
+			err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+		}
+		if err != nil {
+			return fail(err)
+		}
+		key := base + "_" + v.Name + thumbExt
+		if err := put(key, buf.Bytes(), thumbType); err != nil {
+			return fail(err)
+		}
+		result.Variants[v.Name] = Default.URL(key)
+
+		// WebP 编码器只支持无损格式，因此只为缩略图生成 WebP 版本
+		buf.Reset()
+		if err := nativewebp.Encode(&buf, img, nil); err != nil {
+			return fail(err)
+		}
+		key = base + "_" + v.Name + ".webp"
+		if err := put(key, buf.Bytes(), "image/webp"); err != nil {
+			return fail(err)
+		}
+		result.Variants[v.Name+"_webp"] = Default.URL(key)
+	}
+	return result, nil
+}
+
+// resize 按最长边等比缩放，原图不超过 maxSide 时返回原图
+func resize(src image.Image, maxSide int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= maxSide && h <= maxSide {
+		return src
+	}
+	if w >= h {
+		h = h * maxSide / w
+		w = maxSide
+	} else {
+		w = w * maxSide / h
+		h = maxSide
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, max(w, 1), max(h, 1)))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Over, nil)
+	return dst
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage 将文件保存在本地目录，由本服务以静态文件方式提供访问
+type LocalStorage struct {
+	Root    string // 本地根目录
+	BaseURL string // 访问 URL 前缀
+}
+
+// NewLocalStorage 创建本地存储，根目录不存在时自动创建
+func NewLocalStorage(root, baseURL string) (*LocalStorage, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalStorage{Root: root, BaseURL: strings.TrimRight(baseURL, "/")}, nil
+}
+
+// path 将 key 转换为本地路径，拒绝跳出根目录的 key
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || clean != "/"+key {
+		return "", errors.New("invalid storage key")
+	}
+	return filepath.Join(s.Root, filepath.FromSlash(clean)), nil
+}
+
+// Put 先写入临时文件再重命名，避免读取到写了一半的文件
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	dst, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
+
+// Delete 删除文件，文件不存在时不报错
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	dst, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// URL 返回文件的访问地址
+func (s *LocalStorage) URL(key string) string {
+	return s.BaseURL + "/" + key
+}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Config S3 兼容存储的连接参数
+type S3Config struct {
+	Endpoint  string // 不含协议，如 "s3.amazonaws.com"、"127.0.0.1:9000"
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+	PublicURL string // 访问 URL 前缀，为空时使用 endpoint/bucket
+}
+
+// S3Storage 将文件保存到 S3 兼容的对象存储
+type S3Storage struct {
+	client  *minio.Client
+	bucket  string
+	baseURL string
+}
+
+// NewS3Storage 创建 S3 存储并检查 bucket 是否存在
+func NewS3Storage(cfg S3Config) (*S3Storage, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, errors.New("S3_ENDPOINT and S3_BUCKET are required")
+	}
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	exists, err := client.BucketExists(ctx, cfg.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("bucket %q does not exist", cfg.Bucket)
+	}
+
+	baseURL := strings.TrimRight(cfg.PublicURL, "/")
+	if baseURL == "" {
+		scheme := "http"
+		if cfg.UseSSL {
+			scheme = "https"
+		}
+		baseURL = scheme + "://" + cfg.Endpoint + "/" + cfg.Bucket
+	}
+	return &S3Storage{client: client, bucket: cfg.Bucket, baseURL: baseURL}, nil
+}
+
+// Put 上传对象，设置与本地静态文件一致的缓存头
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, r, size, minio.PutObjectOptions{
+		ContentType:  contentType,
+		CacheControl: CacheControl,
+	})
+	return err
+}
+
+// Delete 删除对象，对象不存在时不报错
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+// URL 返回对象的访问地址
+func (s *S3Storage) URL(key string) string {
+	return s.baseURL + "/" + key
+}
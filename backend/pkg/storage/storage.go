@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"log"
+
+	"go-flutter-mall/backend/config"
+)
+
+// Storage 上传文件的存储后端
+// key 为不以 "/" 开头的相对路径，如 "products/2026/10/ab12cd34.jpg"
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string // 文件的公开访问地址
+}
+
+// Default 当前使用的存储后端，Init 之前为 nil
+var Default Storage
+
+// Init 根据配置初始化存储后端，S3 初始化失败时回退到本地存储
+func Init() {
+	switch config.StorageBackend {
+	case "local", "":
+	case "s3":
+		s, err := NewS3Storage(S3Config{
+			Endpoint:  config.S3Endpoint,
+			Region:    config.S3Region,
+			Bucket:    config.S3Bucket,
+			AccessKey: config.S3AccessKey,
+			SecretKey: config.S3SecretKey,
+			UseSSL:    config.S3UseSSL,
+			PublicURL: config.S3PublicURL,
+		})
+		if err == nil {
+			Default = s
+			return
+		}
+		log.Printf("Failed to init s3 storage: %v. Falling back to local storage.", err)
+	default:
+		log.Printf("Unknown storage backend %q. Falling back to local storage.", config.StorageBackend)
+	}
+
+	s, err := NewLocalStorage(config.UploadDir, config.UploadBaseURL)
+	if err != nil {
+		log.Printf("Failed to init local storage: %v. Uploads will be disabled.", err)
+		return
+	}
+	Default = s
+}
+
+// Local 返回本地存储后端 (用于注册静态文件路由)，使用其他后端时返回 nil
+func Local() *LocalStorage {
+	s, _ := Default.(*LocalStorage)
+	return s
+}
@@ -0,0 +1,210 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"go-flutter-mall/backend/models"
+	"go-flutter-mall/backend/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInsufficientBalance = errors.New("Insufficient wallet balance")
+	ErrInvalidAmount       = errors.New("Amount must be positive")
+	ErrGiftCardNotFound    = errors.New("Gift card not found")
+	ErrGiftCardRedeemed    = errors.New("Gift card has already been redeemed")
+	ErrGiftCardDisabled    = errors.New("Gift card has been disabled")
+	ErrGiftCardExpired     = errors.New("Gift card has expired")
+)
+
+// codeAlphabet 卡密字符集，去掉了容易混淆的 0/O/1/I
+const codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// codeLength 卡密长度
+const codeLength = 16
+
+// WalletAccount 用户钱包的记账账户
+func WalletAccount(userID uint) string {
+	return fmt.Sprintf("wallet:%d", userID)
+}
+
+// GiftCardAccount 礼品卡的记账账户
+func GiftCardAccount(id uint) string {
+	return fmt.Sprintf("gift_card:%d", id)
+}
+
+// OrderAccount 订单的记账账户
+func OrderAccount(id uint) string {
+	return fmt.Sprintf("order:%d", id)
+}
+
+// GatewayAccount 第三方支付的清算账户，订单的第三方支付部分退款时从该账户转入钱包
+const GatewayAccount = "gateway"
+
+// GenerateCode 生成随机卡密
+func GenerateCode() (string, error) {
+	code := make([]byte, codeLength)
+	max := big.NewInt(int64(len(codeAlphabet)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = codeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// FindWallet 查询用户钱包，不存在时返回空钱包 (不创建)
+func FindWallet(db *gorm.DB, userID uint) (models.Wallet, error) {
+	w := models.Wallet{UserID: userID}
+	if err := db.Where("user_id = ?", userID).Limit(1).Find(&w).Error; err != nil {
+		return w, err
+	}
+	return w, nil
+}
+
+// lockWallet 获取并锁定用户钱包，不存在时先创建
+func lockWallet(tx *gorm.DB, userID uint) (*models.Wallet, error) {
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Wallet{UserID: userID}).Error; err != nil {
+		return nil, err
+	}
+	var w models.Wallet
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", userID).First(&w).Error; err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// post 写入一笔借贷相等的复式分录
+func post(tx *gorm.DB, debit, credit string, amount float64, txType string, orderID uint, remark string) error {
+	// 时间戳精度不足时同一时刻的多笔业务 (如退款的钱包和第三方支付两部分) 可能重复，追加随机后缀
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return err
+	}
+	txnNo := fmt.Sprintf("%d%s%s", time.Now().UnixNano(), txType, hex.EncodeToString(suffix))
+	entries := []models.LedgerEntry{
+		{TxnNo: txnNo, Account: debit, Direction: models.LedgerDebit, Amount: amount, Type: txType, OrderID: orderID, Remark: remark},
+		{TxnNo: txnNo, Account: credit, Direction: models.LedgerCredit, Amount: amount, Type: txType, OrderID: orderID, Remark: remark},
+	}
+	return tx.Create(&entries).Error
+}
+
+// change 变更钱包余额并记账
+// amount 为正数表示入账 (贷记钱包)，负数表示出账 (借记钱包)，counter 为对方账户
+func change(tx *gorm.DB, userID uint, amount float64, counter string, txType string, orderID uint, remark string) error {
+	amount = utils.RoundAmount(amount)
+	if amount == 0 {
+		return ErrInvalidAmount
+	}
+
+	w, err := lockWallet(tx, userID)
+	if err != nil {
+		return err
+	}
+	balance := utils.RoundAmount(w.Balance + amount)
+	if balance < 0 {
+		return ErrInsufficientBalance
+	}
+	if err := tx.Model(w).Update("balance", balance).Error; err != nil {
+		return err
+	}
+
+	if amount > 0 {
+		return post(tx, counter, WalletAccount(userID), amount, txType, orderID, remark)
+	}
+	return post(tx, WalletAccount(userID), counter, -amount, txType, orderID, remark)
+}
+
+// Issue 批量发行礼品卡
+func Issue(tx *gorm.DB, batchNo string, count int, faceValue float64, expireAt time.Time) ([]models.GiftCard, error) {
+	if faceValue <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	cards := make([]models.GiftCard, count)
+	for i := range cards {
+		code, err := GenerateCode()
+		if err != nil {
+			return nil, err
+		}
+		cards[i] = models.GiftCard{
+			Code:      code,
+			BatchNo:   batchNo,
+			FaceValue: faceValue,
+			Balance:   faceValue,
+			ExpireAt:  expireAt,
+			Status:    models.GiftCardStatusActive,
+		}
+	}
+	if err := tx.Create(&cards).Error; err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
+// Redeem 兑换礼品卡，整卡余额转入用户钱包
+func Redeem(tx *gorm.DB, userID uint, code string) (*models.GiftCard, error) {
+	var card models.GiftCard
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("code = ?", code).First(&card).Error; err != nil {
+		return nil, ErrGiftCardNotFound
+	}
+
+	switch card.Status {
+	case models.GiftCardStatusRedeemed:
+		return nil, ErrGiftCardRedeemed
+	case models.GiftCardStatusDisabled:
+		return nil, ErrGiftCardDisabled
+	}
+	now := time.Now()
+	if now.After(card.ExpireAt) {
+		return nil, ErrGiftCardExpired
+	}
+
+	amount := card.Balance
+	if err := tx.Model(&card).Updates(map[string]interface{}{
+		"status":      models.GiftCardStatusRedeemed,
+		"balance":     0,
+		"redeemed_by": userID,
+		"redeemed_at": now,
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := change(tx, userID, amount, GiftCardAccount(card.ID), models.LedgerGiftCardRedeem, 0, "礼品卡兑换 "+card.Code); err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// Pay 使用钱包余额支付订单
+func Pay(tx *gorm.DB, userID uint, amount float64, orderID uint) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+	return change(tx, userID, -amount, OrderAccount(orderID), models.LedgerOrderPay, orderID, "订单支付")
+}
+
+// Refund 订单退款退回钱包
+// walletAmount 为订单的钱包支付部分，从订单账户转回；gatewayAmount 为第三方支付部分，订单账户从未收到这部分资金，从第三方支付清算账户转入
+func Refund(tx *gorm.DB, userID uint, walletAmount, gatewayAmount float64, orderID uint) error {
+	if walletAmount < 0 || gatewayAmount < 0 || walletAmount+gatewayAmount <= 0 {
+		return ErrInvalidAmount
+	}
+	if walletAmount > 0 {
+		if err := change(tx, userID, walletAmount, OrderAccount(orderID), models.LedgerOrderRefund, orderID, "订单退款 (钱包支付部分)"); err != nil {
+			return err
+		}
+	}
+	if gatewayAmount > 0 {
+		return change(tx, userID, gatewayAmount, GatewayAccount, models.LedgerOrderRefund, orderID, "订单退款 (第三方支付部分)")
+	}
+	return nil
+}
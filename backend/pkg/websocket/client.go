@@ -38,6 +38,9 @@ type Client struct {
 	// 发送消息的缓冲通道
 	Send chan *models.ChatMessage
 
+	// 推送通知的缓冲通道
+	Notifications chan *models.Notification
+
 	// 客户端标识
 	ID     string
 	UserID uint
@@ -105,6 +108,14 @@ func (c *Client) WritePump() {
 					"payload": msg,
 				})
 			}
+		case notification := <-c.Notifications:
+			c.Conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.Conn.WriteJSON(map[string]interface{}{
+				"type":    "notification",
+				"payload": notification,
+			}); err != nil {
+				return
+			}
 		case <-ticker.C:
 			c.Conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if err := c.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
@@ -160,12 +171,13 @@ func ServeWs(hub *Hub, c *gin.Context) {
 	}
 
 	client := &Client{
-		Hub:    hub,
-		Conn:   conn,
-		Send:   make(chan *models.ChatMessage, 256),
-		ID:     userID, // String ID for logging
-		Type:   userType,
-		UserID: uint(uid),
+		Hub:           hub,
+		Conn:          conn,
+		Send:          make(chan *models.ChatMessage, 256),
+		Notifications: make(chan *models.Notification, 16),
+		ID:            userID, // String ID for logging
+		Type:          userType,
+		UserID:        uint(uid),
 	}
 
 	// 注册
@@ -21,11 +21,18 @@ type Hub struct {
 
 	// 消息广播通道 (这里处理的是业务层面的消息)
 	Broadcast chan *models.ChatMessage
+
+	// 通知推送通道 (已保存的消息通知，推送给对应用户)
+	Notify chan *models.Notification
 }
 
+// Default 全局 Hub，供业务代码推送通知，由 main 在启动时设置
+var Default *Hub
+
 func NewHub() *Hub {
 	return &Hub{
 		Broadcast:  make(chan *models.ChatMessage),
+		Notify:     make(chan *models.Notification, 256),
 		Register:   make(chan *Client),
 		Unregister: make(chan *Client),
 		Clients:    make(map[*Client]bool),
@@ -46,6 +53,19 @@ func (h *Hub) Run() {
 				log.Printf("Client unregistered: %s", client.ID)
 			}
 
+		case notification := <-h.Notify:
+			// 推送给该用户的所有连接 (多端)
+			for client := range h.Clients {
+				if client.Type != "user" || client.UserID != notification.UserID {
+					continue
+				}
+				select {
+				case client.Notifications <- notification:
+				default:
+					// 发送缓冲已满，跳过本次推送 (通知已保存，用户可在消息列表中查看)
+				}
+			}
+
 		case message := <-h.Broadcast:
 			// 将消息保存到数据库
 			if err := config.DB.Create(message).Error; err != nil {
@@ -96,9 +116,21 @@ func (h *Hub) Run() {
 	}
 }
 
+// PushNotification 推送通知给在线用户，Hub 未启动或推送队列已满时丢弃 (通知已保存在数据库中)
+func PushNotification(notification *models.Notification) {
+	if Default == nil {
+		return
+	}
+	select {
+	case Default.Notify <- notification:
+	default:
+		log.Printf("Notification queue is full, drop push of notification %d", notification.ID)
+	}
+}
+
 // WSMessage 包装 WebSocket 传输的消息结构
 type WSMessage struct {
-	Type    string              `json:"type"`    // message, heartbeat
+	Type    string              `json:"type"`    // message, heartbeat；服务端推送的通知为 notification
 	Payload *models.ChatMessage `json:"payload"` // 实际的聊天消息
 }
 
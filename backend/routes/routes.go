@@ -1,15 +1,29 @@
 package routes
 
 import (
+	"strings"
+
 	"go-flutter-mall/backend/controllers"
 	"go-flutter-mall/backend/controllers/admin"
+	"go-flutter-mall/backend/controllers/brand"
+	"go-flutter-mall/backend/controllers/browsing"
 	"go-flutter-mall/backend/controllers/cart"
+	"go-flutter-mall/backend/controllers/category"
 	"go-flutter-mall/backend/controllers/chat"
+	"go-flutter-mall/backend/controllers/coupon"
+	"go-flutter-mall/backend/controllers/favorite"
 	"go-flutter-mall/backend/controllers/notification"
 	"go-flutter-mall/backend/controllers/order"
+	"go-flutter-mall/backend/controllers/points"
 	"go-flutter-mall/backend/controllers/product"
+	"go-flutter-mall/backend/controllers/promotion"
+	"go-flutter-mall/backend/controllers/recommend"
 	"go-flutter-mall/backend/controllers/search"
+	"go-flutter-mall/backend/controllers/shipping"
+	"go-flutter-mall/backend/controllers/upload"
+	"go-flutter-mall/backend/controllers/wallet"
 	"go-flutter-mall/backend/middleware"
+	"go-flutter-mall/backend/pkg/storage"
 	"go-flutter-mall/backend/pkg/websocket"
 
 	"github.com/gin-gonic/gin"
@@ -34,6 +48,16 @@ func SetupRoutes(r *gin.Engine, hub *websocket.Hub) {
 	// Swagger 文档路由
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// 本地存储的上传文件 (key 由内容哈希生成，可长期缓存；不列出目录)
+	// UPLOAD_BASE_URL 为完整地址 (如 CDN) 时仍在 /uploads 下提供，作为 CDN 回源地址
+	if local := storage.Local(); local != nil {
+		mount := "/uploads"
+		if strings.HasPrefix(local.BaseURL, "/") {
+			mount = local.BaseURL
+		}
+		r.Group(mount, middleware.CacheControl(storage.CacheControl)).StaticFS("/", gin.Dir(local.Root, false))
+	}
+
 	// 创建 /api 路由组
 	api := r.Group("/api")
 	{
@@ -59,26 +83,113 @@ func SetupRoutes(r *gin.Engine, hub *websocket.Hub) {
 		// 商品路由 (公开)
 		products := api.Group("/products")
 		{
-			products.GET("", product.GetProducts)                   // 获取商品列表
-			products.GET("/:id", product.GetProductDetail)          // 获取商品详情
-			products.GET("/:id/reviews", product.GetProductReviews) // 获取商品评价
+			products.GET("", middleware.OptionalAuthMiddleware(), product.GetProducts)          // 获取商品列表
+			products.GET("/:id", middleware.OptionalAuthMiddleware(), product.GetProductDetail) // 获取商品详情
+			products.GET("/:id/reviews", product.GetProductReviews)                             // 获取商品评价
+			products.GET("/:id/specs", product.GetProductSpecs)                                 // 获取商品规格
+			products.GET("/:id/related", recommend.GetRelatedProducts)                          // 相关商品 (买了还买)
+		}
+
+		// 商品管理路由 (需管理员 Token)
+		productAdmin := api.Group("/products", middleware.AdminMiddleware())
+		{
+			productAdmin.POST("", product.CreateProduct)                         // 创建商品
+			productAdmin.POST("/import", product.ImportProducts)                 // 批量导入商品 (CSV/XLSX)
+			productAdmin.GET("/export", product.ExportProducts)                  // 导出商品 (CSV/XLSX)
+			productAdmin.PUT("/:id", product.UpdateProduct)                      // 更新商品 (含 SKU 同步)
+			productAdmin.PATCH("/:id", product.PatchProduct)                     // 部分更新商品
+			productAdmin.PUT("/:id/status", product.UpdateProductStatus)         // 商品上下架
+			productAdmin.POST("/:id/images", product.AddProductImages)           // 追加轮播图
+			productAdmin.PUT("/:id/images", product.ReplaceProductImages)        // 替换/排序轮播图
+			productAdmin.DELETE("/:id/images", product.DeleteProductImage)       // 删除轮播图
+			productAdmin.PUT("/:id/specs", product.SaveProductSpecs)             // 设置商品规格
+			productAdmin.POST("/:id/skus/generate", product.GenerateProductSKUs) // 按规格生成 SKU 矩阵
+			productAdmin.DELETE("/:id", product.DeleteProduct)                   // 删除商品
+
+			// 定时上下架
+			productAdmin.PUT("/:id/schedule", product.UpdateProductSchedule) // 设置定时上下架
+			productAdmin.GET("/admin/calendar", product.GetProductCalendar)  // 定时上下架日历
+
+			productAdmin.GET("/:id/price-history", product.GetPriceHistory) // 价格历史 (商品及 SKU)
+		}
+
+		// 品牌路由
+		brandGroup := api.Group("/brands")
+		{
+			brandGroup.GET("", brand.GetBrands)           // 获取品牌列表
+			brandGroup.GET("/:id", brand.GetBrandLanding) // 品牌落地页 (品牌信息及商品)
+
+			// 管理员接口 (需管理员 Token)
+			brandAdmin := brandGroup.Group("/admin", middleware.AdminMiddleware())
+			brandAdmin.POST("", brand.CreateBrand)       // 创建品牌
+			brandAdmin.PUT("/:id", brand.UpdateBrand)    // 更新品牌
+			brandAdmin.DELETE("/:id", brand.DeleteBrand) // 删除品牌
+		}
+
+		// 降价提醒路由 (需认证)
+		priceAlertGroup := api.Group("/price-alerts", middleware.AuthMiddleware())
+		{
+			priceAlertGroup.GET("", product.GetPriceAlerts)                 // 获取我的降价提醒
+			priceAlertGroup.POST("", product.SubscribePriceAlert)           // 订阅降价提醒
+			priceAlertGroup.DELETE("/:productId", product.DeletePriceAlert) // 取消降价提醒
+		}
+
+		// 收藏路由
+		favoriteGroup := api.Group("/favorites")
+		{
+			favoriteGroup.GET("", middleware.AuthMiddleware(), favorite.GetFavorites)                 // 获取我的收藏
+			favoriteGroup.POST("", middleware.AuthMiddleware(), favorite.AddFavorite)                 // 收藏商品
+			favoriteGroup.DELETE("/:productId", middleware.AuthMiddleware(), favorite.RemoveFavorite) // 取消收藏
+
+			// 管理员接口 (需管理员 Token)
+			favoriteGroup.GET("/admin/top", middleware.AdminMiddleware(), favorite.GetMostFavorited) // 收藏排行
+		}
+
+		// 推荐路由 (未登录返回热门商品)
+		api.GET("/recommendations", middleware.OptionalAuthMiddleware(), recommend.GetRecommendations) // 猜你喜欢
+
+		// 浏览历史路由 (记录由商品详情接口写入)
+		historyGroup := api.Group("/browsing-history")
+		{
+			historyGroup.GET("", middleware.AuthMiddleware(), browsing.GetBrowsingHistory)           // 获取我的浏览历史
+			historyGroup.DELETE("", middleware.AuthMiddleware(), browsing.ClearBrowsingHistory)      // 清空浏览历史
+			historyGroup.DELETE("/:id", middleware.AuthMiddleware(), browsing.DeleteBrowsingHistory) // 删除一条浏览记录
+
+			// 管理员接口 (需管理员 Token)
+			historyGroup.GET("/admin/views", middleware.AdminMiddleware(), browsing.GetTopViewedProducts) // 商品浏览量排行
+		}
+
+		// 分类路由
+		categoryGroup := api.Group("/categories")
+		{
+			categoryGroup.GET("", category.GetCategoryTree)                  // 获取分类树
+			categoryGroup.GET("/:id/products", category.GetCategoryProducts) // 获取分类下的商品 (含子分类)
+
+			// 管理员接口 (需管理员 Token)
+			categoryAdmin := categoryGroup.Group("/admin", middleware.AdminMiddleware())
+			categoryAdmin.GET("/all", category.GetAllCategories)  // 获取扁平分类列表
+			categoryAdmin.POST("", category.CreateCategory)       // 创建分类
+			categoryAdmin.PUT("/sort", category.SortCategories)   // 批量调整排序
+			categoryAdmin.PUT("/:id", category.UpdateCategory)    // 更新分类
+			categoryAdmin.DELETE("/:id", category.DeleteCategory) // 删除分类
 
-			// 管理员接口 (需认证)
-			// TODO: Add AdminMiddleware
-			products.POST("", product.CreateProduct)       // 创建商品
-			products.PUT("/:id", product.UpdateProduct)    // 更新商品
-			products.DELETE("/:id", product.DeleteProduct) // 删除商品
+			// 分类属性模板
+			categoryGroup.GET("/:id/attributes", category.GetCategoryAttributes)      // 获取分类生效的属性模板 (含继承)
+			categoryAdmin.POST("/:id/attributes", category.CreateCategoryAttribute)   // 添加属性模板
+			categoryAdmin.PUT("/attributes/:id", category.UpdateCategoryAttribute)    // 更新属性模板
+			categoryAdmin.DELETE("/attributes/:id", category.DeleteCategoryAttribute) // 删除属性模板
 		}
 
 		// 购物车路由 (需认证)
 		// 使用 middleware.AuthMiddleware() 保护该组下的所有路由
 		cartGroup := api.Group("/cart", middleware.AuthMiddleware())
 		{
-			cartGroup.GET("", cart.GetCart)               // 获取购物车
-			cartGroup.GET("/count", cart.GetCartCount)    // 获取购物车数量
-			cartGroup.POST("", cart.AddToCart)            // 添加到购物车
-			cartGroup.PUT("/:id", cart.UpdateCartItem)    // 更新购物车项
-			cartGroup.DELETE("/:id", cart.DeleteCartItem) // 删除购物车项
+			cartGroup.GET("", cart.GetCart)                // 获取购物车
+			cartGroup.GET("/count", cart.GetCartCount)     // 获取购物车数量
+			cartGroup.GET("/summary", cart.GetCartSummary) // 获取选中商品价格汇总
+			cartGroup.POST("", cart.AddToCart)             // 添加到购物车
+			cartGroup.PUT("/:id", cart.UpdateCartItem)     // 更新购物车项
+			cartGroup.DELETE("/:id", cart.DeleteCartItem)  // 删除购物车项
 		}
 
 		// 地址路由 (需认证)
@@ -94,19 +205,90 @@ func SetupRoutes(r *gin.Engine, hub *websocket.Hub) {
 		orderGroup := api.Group("/orders", middleware.AuthMiddleware())
 		{
 			orderGroup.POST("", order.CreateOrder)                     // 创建订单
+			orderGroup.POST("/preview", order.PreviewOrder)            // 结算预览
 			orderGroup.GET("", order.GetOrders)                        // 获取订单列表
 			orderGroup.GET("/counts", order.GetOrderCounts)            // 获取订单数量统计
 			orderGroup.GET("/:id", order.GetOrderDetail)               // 获取订单详情
 			orderGroup.POST("/:id/pay", order.PayOrder)                // 支付订单
+			orderGroup.POST("/:id/cancel", order.CancelOrder)          // 取消订单
 			orderGroup.PUT("/:id/receipt", order.ConfirmReceipt)       // 确认收货
 			orderGroup.POST("/:id/review", order.ReviewOrder)          // 评价订单
 			orderGroup.POST("/:id/after-sales", order.ApplyAfterSales) // 申请售后
 
 			// 管理员接口 (临时放在这里，实际应有 AdminMiddleware)
-			orderGroup.PUT("/:id/status", order.UpdateOrderStatus) // 更新订单状态
-			orderGroup.DELETE("/:id", order.DeleteOrder)           // 删除订单
-			orderGroup.GET("/admin/all", order.GetAllOrders)       // 管理员获取所有订单
+			orderGroup.PUT("/:id/status", middleware.AdminMiddleware(), order.UpdateOrderStatus) // 更新订单状态 (需管理员 Token)
+			orderGroup.POST("/:id/refund", middleware.AdminMiddleware(), order.RefundOrder)      // 订单退款 (需管理员 Token)
+			orderGroup.DELETE("/:id", order.DeleteOrder)                                         // 删除订单
+			orderGroup.GET("/admin/all", order.GetAllOrders)                                     // 管理员获取所有订单
 		}
+
+		// 优惠券路由
+		couponGroup := api.Group("/coupons")
+		{
+			couponGroup.GET("", coupon.GetCoupons)                                          // 获取可领取的优惠券
+			couponGroup.GET("/mine", middleware.AuthMiddleware(), coupon.GetMyCoupons)      // 获取我的优惠券
+			couponGroup.POST("/:id/claim", middleware.AuthMiddleware(), coupon.ClaimCoupon) // 领取优惠券
+
+			// 管理员接口 (需管理员 Token)
+			couponAdmin := couponGroup.Group("/admin", middleware.AdminMiddleware())
+			couponAdmin.GET("/templates", coupon.GetCouponTemplates)          // 获取优惠券模板列表
+			couponAdmin.POST("/templates", coupon.CreateCouponTemplate)       // 创建优惠券模板
+			couponAdmin.PUT("/templates/:id", coupon.UpdateCouponTemplate)    // 更新优惠券模板
+			couponAdmin.DELETE("/templates/:id", coupon.DeleteCouponTemplate) // 删除优惠券模板
+		}
+
+		// 促销活动路由
+		promotionGroup := api.Group("/promotions")
+		{
+			promotionGroup.GET("", promotion.GetPromotions) // 获取进行中的促销活动
+
+			// 管理员接口 (需管理员 Token)
+			promotionAdmin := promotionGroup.Group("/admin", middleware.AdminMiddleware())
+			promotionAdmin.GET("/all", promotion.GetAllPromotions)   // 获取所有促销活动
+			promotionAdmin.POST("", promotion.CreatePromotion)       // 创建促销活动
+			promotionAdmin.PUT("/:id", promotion.UpdatePromotion)    // 更新促销活动
+			promotionAdmin.DELETE("/:id", promotion.DeletePromotion) // 删除促销活动
+		}
+
+		// 积分与会员路由
+		pointsGroup := api.Group("/points")
+		{
+			pointsGroup.GET("", middleware.AuthMiddleware(), points.GetPointsAccount)                   // 获取积分余额
+			pointsGroup.GET("/transactions", middleware.AuthMiddleware(), points.GetPointsTransactions) // 获取积分流水
+			pointsGroup.GET("/level", middleware.AuthMiddleware(), points.GetMemberLevel)               // 获取会员等级
+
+			// 管理员接口 (需管理员 Token)
+			pointsGroup.POST("/admin/adjust", middleware.AdminMiddleware(), points.AdjustPoints) // 调整用户积分
+		}
+
+		// 钱包路由 (需认证)
+		walletGroup := api.Group("/wallet", middleware.AuthMiddleware())
+		{
+			walletGroup.GET("", wallet.GetWallet)                          // 获取钱包余额
+			walletGroup.GET("/transactions", wallet.GetWalletTransactions) // 获取钱包流水
+			walletGroup.POST("/redeem", wallet.RedeemGiftCard)             // 兑换礼品卡
+		}
+
+		// 礼品卡路由
+		giftCardGroup := api.Group("/gift-cards", middleware.AdminMiddleware())
+		{
+			// 管理员接口 (需管理员 Token)
+			giftCardGroup.GET("/admin", wallet.GetGiftCards)                // 查询礼品卡
+			giftCardGroup.POST("/admin", wallet.IssueGiftCards)             // 批量发行礼品卡
+			giftCardGroup.PUT("/admin/:id/disable", wallet.DisableGiftCard) // 作废礼品卡
+		}
+
+		// 运费模板路由
+		shippingGroup := api.Group("/shipping")
+		{
+			// 管理员接口 (需管理员 Token)
+			shippingAdmin := shippingGroup.Group("/admin", middleware.AdminMiddleware())
+			shippingAdmin.GET("/templates", shipping.GetFreightTemplates)          // 获取运费模板列表
+			shippingAdmin.POST("/templates", shipping.CreateFreightTemplate)       // 创建运费模板
+			shippingAdmin.PUT("/templates/:id", shipping.UpdateFreightTemplate)    // 更新运费模板
+			shippingAdmin.DELETE("/templates/:id", shipping.DeleteFreightTemplate) // 删除运费模板
+		}
+
 		// 聊天路由 (需认证)
 		chatGroup := api.Group("/chat", middleware.AuthMiddleware())
 		{
@@ -116,12 +298,45 @@ func SetupRoutes(r *gin.Engine, hub *websocket.Hub) {
 			chatGroup.POST("/notification", chat.SendSystemNotification) // 发送系统消息
 		}
 
-		// 搜索历史路由 (需认证)
-		searchGroup := api.Group("/search", middleware.AuthMiddleware())
+		// 图片上传路由
+		uploadGroup := api.Group("/uploads")
 		{
-			searchGroup.POST("/history", search.AddSearchHistory)     // 添加搜索记录
-			searchGroup.GET("/history", search.GetSearchHistory)      // 获取搜索记录
-			searchGroup.DELETE("/history", search.ClearSearchHistory) // 清空搜索记录
+			uploadGroup.POST("/reviews", middleware.AuthMiddleware(), upload.UploadReviewImages) // 上传评价晒图
+			uploadGroup.POST("/avatar", middleware.AuthMiddleware(), upload.UploadAvatar)        // 上传并设置头像
+			uploadGroup.POST("/chat", middleware.AuthMiddleware(), upload.UploadChatImage)       // 上传聊天图片
+
+			// 管理员接口 (需管理员 Token)
+			uploadGroup.POST("/products", middleware.AdminMiddleware(), upload.UploadProductImages) // 上传商品图片
+		}
+
+		// 搜索路由 (搜索历史需认证)
+		searchGroup := api.Group("/search")
+		{
+			searchGroup.GET("/products", middleware.OptionalAuthMiddleware(), search.SearchProducts)  // 商品全文搜索
+			searchGroup.POST("/click", middleware.OptionalAuthMiddleware(), search.RecordSearchClick) // 上报搜索结果点击
+			searchGroup.GET("/suggest", search.GetSuggestions)                                        // 搜索建议
+			searchGroup.GET("/hot", search.GetHotKeywords)                                            // 热搜榜
+
+			searchGroup.POST("/history", middleware.AuthMiddleware(), search.AddSearchHistory)     // 添加搜索记录
+			searchGroup.GET("/history", middleware.AuthMiddleware(), search.GetSearchHistory)      // 获取搜索记录
+			searchGroup.DELETE("/history", middleware.AuthMiddleware(), search.ClearSearchHistory) // 清空搜索记录 (可指定单个关键词)
+
+			// 管理员接口 (需管理员 Token)
+			searchAdmin := searchGroup.Group("/admin", middleware.AdminMiddleware())
+			searchAdmin.GET("/terms", search.GetSearchTerms)                        // 置顶/屏蔽词列表
+			searchAdmin.POST("/terms", search.SaveSearchTerm)                       // 置顶或屏蔽热搜词
+			searchAdmin.DELETE("/terms/:id", search.DeleteSearchTerm)               // 取消置顶/屏蔽
+			searchAdmin.GET("/synonyms", search.GetSynonyms)                        // 同义词组列表
+			searchAdmin.POST("/synonyms", search.CreateSynonym)                     // 创建同义词组
+			searchAdmin.PUT("/synonyms/:id", search.UpdateSynonym)                  // 更新同义词组
+			searchAdmin.DELETE("/synonyms/:id", search.DeleteSynonym)               // 删除同义词组
+			searchAdmin.GET("/stopwords", search.GetStopwords)                      // 停用词列表
+			searchAdmin.POST("/stopwords", search.CreateStopword)                   // 添加停用词
+			searchAdmin.DELETE("/stopwords/:id", search.DeleteStopword)             // 删除停用词
+			searchAdmin.GET("/analytics/top", search.GetTopQueries)                 // 热门查询
+			searchAdmin.GET("/analytics/zero-results", search.GetZeroResultQueries) // 无结果查询
+			searchAdmin.GET("/analytics/ctr", search.GetSearchCTR)                  // 查询点击率
+			searchAdmin.POST("/rebuild", search.RebuildSearchIndex)                 // 重建搜索索引
 		}
 
 		// 消息通知路由 (需认证)
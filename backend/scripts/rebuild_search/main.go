@@ -0,0 +1,32 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"go-flutter-mall/backend/config"
+	searchpkg "go-flutter-mall/backend/pkg/search"
+)
+
+// main 从数据库重建商品搜索索引
+// 使用 SEARCH_BACKEND 指定的后端 (索引损坏或批量修改数据库后需要执行)
+// 运行方法: cd backend && SEARCH_BACKEND=bleve go run ./scripts/rebuild_search
+// 注意: bleve 索引文件被运行中的服务独占，需先停止服务；服务运行时请使用管理接口 POST /api/search/admin/rebuild
+func main() {
+	config.ConnectDatabase()
+
+	searchpkg.Init()
+	if searchpkg.Default == nil {
+		log.Fatalf("搜索后端 %q 初始化失败", config.SearchBackend)
+	}
+	// 空的 bleve 索引在 Init 时已开始后台导入，等待其结束后再完整重建，避免两个任务并发写入
+	for searchpkg.Rebuilding() {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	log.Printf("正在重建 %s 搜索索引...", config.SearchBackend)
+	if err := searchpkg.Default.Rebuild(config.DB); err != nil {
+		log.Fatalf("重建搜索索引失败: %v", err)
+	}
+	log.Println("✅ 搜索索引重建完成")
+}
@@ -25,7 +25,7 @@ func main() {
 
 	// 1. 清理现有数据
 	log.Println("正在清理旧数据...")
-	db.Exec("TRUNCATE TABLE reviews, order_items, orders, addresses, cart_items, product_skus, products, categories, admin_users, users RESTART IDENTITY CASCADE")
+	db.Exec("TRUNCATE TABLE reviews, order_items, orders, addresses, cart_items, favorites, price_alerts, price_histories, product_similarities, product_skus, products, brands, categories, admin_users, users RESTART IDENTITY CASCADE")
 
 	// 2. 创建管理员
 	adminPassword, _ := utils.HashPassword("admin123")
@@ -51,6 +51,31 @@ func main() {
 	db.Create(&fresh)
 	db.Create(&appliances)
 
+	// 二级、三级分类
+	phones := models.Category{ParentID: digital.ID, Level: 2, Name: "手机通讯", SortOrder: 1, Icon: "smartphone"}
+	audio := models.Category{ParentID: digital.ID, Level: 2, Name: "影音娱乐", SortOrder: 2, Icon: "headphones"}
+	peripherals := models.Category{ParentID: digital.ID, Level: 2, Name: "电脑外设", SortOrder: 3, Icon: "keyboard"}
+	db.Create(&phones)
+	db.Create(&audio)
+	db.Create(&peripherals)
+	smartphones := models.Category{ParentID: phones.ID, Level: 3, Name: "智能手机", SortOrder: 1, Icon: "phone_android"}
+	db.Create(&smartphones)
+
+	// 品牌
+	brands := map[string]*models.Brand{
+		"Apple":    {Name: "Apple", Logo: "https://ui-avatars.com/api/?name=Apple&background=random", Description: "Think Different.", SortOrder: 1},
+		"Sony":     {Name: "Sony", Logo: "https://ui-avatars.com/api/?name=Sony&background=random", Description: "日本索尼，影音娱乐领导品牌。", SortOrder: 2},
+		"Keychron": {Name: "Keychron", Logo: "https://ui-avatars.com/api/?name=Keychron&background=random", Description: "专注机械键盘的外设品牌。", SortOrder: 3},
+		"优衣库":      {Name: "优衣库", Logo: "https://ui-avatars.com/api/?name=UQ&background=random", Description: "基本款服饰，简约舒适。", SortOrder: 4},
+		"Nike":     {Name: "Nike", Logo: "https://ui-avatars.com/api/?name=Nike&background=random", Description: "Just Do It.", SortOrder: 5},
+		"鲜享":       {Name: "鲜享", Logo: "https://ui-avatars.com/api/?name=XX&background=random", Description: "产地直采的生鲜食品。", SortOrder: 6},
+		"海尔":       {Name: "海尔", Logo: "https://ui-avatars.com/api/?name=Haier&background=random", Description: "智慧家电品牌。", SortOrder: 7},
+	}
+	for _, b := range brands {
+		db.Create(b)
+	}
+	log.Printf("已创建 %d 个品牌", len(brands))
+
 	// 图片链接 (已修复失效链接)
 	const imgIphone = "https://images.unsplash.com/photo-1695048133142-1a20484d2569?q=80&w=800&auto=format&fit=crop"
 	const imgHeadphone = "https://images.unsplash.com/photo-1618366712010-f4ae9c647dcb?q=80&w=800&auto=format&fit=crop"
@@ -68,21 +93,21 @@ func main() {
 	// 4. 创建商品列表
 	products := []models.Product{
 		// 数码
-		{CategoryID: digital.ID, Name: "iPhone 15 Pro Max", Description: "钛金属设计，A17 Pro 芯片，史上最强大的 iPhone。", Price: 9999.00, Stock: 100, CoverImage: imgIphone, Images: pq.StringArray{imgIphone}, Status: 1},
-		{CategoryID: digital.ID, Name: "Sony WH-1000XM5", Description: "行业领先的降噪耳机，配备自动降噪优化器。", Price: 2499.00, Stock: 50, CoverImage: imgHeadphone, Images: pq.StringArray{imgHeadphone}, Status: 1},
-		{CategoryID: digital.ID, Name: "机械键盘 RGB", Description: "RGB 背光，红轴，紧凑设计，打字手感极佳。", Price: 499.00, Stock: 150, CoverImage: imgKeyboard, Images: pq.StringArray{imgKeyboard}, Status: 1},
+		{CategoryID: smartphones.ID, BrandID: brands["Apple"].ID, Name: "iPhone 15 Pro Max", Description: "钛金属设计，A17 Pro 芯片，史上最强大的 iPhone。", Price: 9999.00, Stock: 100, CoverImage: imgIphone, Images: pq.StringArray{imgIphone}, Status: 1},
+		{CategoryID: audio.ID, BrandID: brands["Sony"].ID, Name: "Sony WH-1000XM5", Description: "行业领先的降噪耳机，配备自动降噪优化器。", Price: 2499.00, Stock: 50, CoverImage: imgHeadphone, Images: pq.StringArray{imgHeadphone}, Status: 1},
+		{CategoryID: peripherals.ID, BrandID: brands["Keychron"].ID, Name: "机械键盘 RGB", Description: "RGB 背光，红轴，紧凑设计，打字手感极佳。", Price: 499.00, Stock: 150, CoverImage: imgKeyboard, Images: pq.StringArray{imgKeyboard}, Status: 1},
 		// 服饰
-		{CategoryID: clothing.ID, Name: "经典纯棉T恤", Description: "优质纯棉，透气舒适，百搭款式。", Price: 99.00, Stock: 200, CoverImage: imgTshirt, Images: pq.StringArray{imgTshirt}, Status: 1},
-		{CategoryID: clothing.ID, Name: "复古牛仔夹克", Description: "经典款式牛仔夹克，适合任何季节穿着。", Price: 399.00, Stock: 80, CoverImage: imgJacket, Images: pq.StringArray{imgJacket}, Status: 1},
-		{CategoryID: clothing.ID, Name: "专业跑步鞋", Description: "轻量化设计，减震鞋底，完美适合慢跑和训练。", Price: 599.00, Stock: 120, CoverImage: imgShoes, Images: pq.StringArray{imgShoes}, Status: 1},
+		{CategoryID: clothing.ID, BrandID: brands["优衣库"].ID, Name: "经典纯棉T恤", Description: "优质纯棉，透气舒适，百搭款式。", Price: 99.00, Stock: 200, CoverImage: imgTshirt, Images: pq.StringArray{imgTshirt}, Status: 1},
+		{CategoryID: clothing.ID, BrandID: brands["优衣库"].ID, Name: "复古牛仔夹克", Description: "经典款式牛仔夹克，适合任何季节穿着。", Price: 399.00, Stock: 80, CoverImage: imgJacket, Images: pq.StringArray{imgJacket}, Status: 1},
+		{CategoryID: clothing.ID, BrandID: brands["Nike"].ID, Name: "专业跑步鞋", Description: "轻量化设计，减震鞋底，完美适合慢跑和训练。", Price: 599.00, Stock: 120, CoverImage: imgShoes, Images: pq.StringArray{imgShoes}, Status: 1},
 		// 食品
-		{CategoryID: food.ID, Name: "健康沙拉碗", Description: "新鲜蔬菜搭配特制酱料，健康美味。", Price: 35.00, Stock: 999, CoverImage: imgSalad, Images: pq.StringArray{imgSalad}, Status: 1},
+		{CategoryID: food.ID, BrandID: brands["鲜享"].ID, Name: "健康沙拉碗", Description: "新鲜蔬菜搭配特制酱料，健康美味。", Price: 35.00, Stock: 999, CoverImage: imgSalad, Images: pq.StringArray{imgSalad}, Status: 1},
 		// 生鲜
-		{CategoryID: fresh.ID, Name: "进口甜橙 (5kg)", Description: "阳光充足，果肉饱满，汁多味甜。", Price: 88.00, Stock: 300, CoverImage: imgFruit, Images: pq.StringArray{imgFruit}, Status: 1},
-		{CategoryID: fresh.ID, Name: "新鲜三文鱼切片", Description: "深海捕捞，极速冷链，口感鲜美。", Price: 128.00, Stock: 50, CoverImage: imgSeafood, Images: pq.StringArray{imgSeafood}, Status: 1},
+		{CategoryID: fresh.ID, BrandID: brands["鲜享"].ID, Name: "进口甜橙 (5kg)", Description: "阳光充足，果肉饱满，汁多味甜。", Price: 88.00, Stock: 300, CoverImage: imgFruit, Images: pq.StringArray{imgFruit}, Status: 1},
+		{CategoryID: fresh.ID, BrandID: brands["鲜享"].ID, Name: "新鲜三文鱼切片", Description: "深海捕捞，极速冷链，口感鲜美。", Price: 128.00, Stock: 50, CoverImage: imgSeafood, Images: pq.StringArray{imgSeafood}, Status: 1},
 		// 家电
-		{CategoryID: appliances.ID, Name: "智能双开门冰箱", Description: "大容量，风冷无霜，智能温控。", Price: 3999.00, Stock: 20, CoverImage: imgFridge, Images: pq.StringArray{imgFridge}, Status: 1},
-		{CategoryID: appliances.ID, Name: "全自动滚筒洗衣机", Description: "洗烘一体，静音变频，除菌洗。", Price: 2599.00, Stock: 30, CoverImage: imgWasher, Images: pq.StringArray{imgWasher}, Status: 1},
+		{CategoryID: appliances.ID, BrandID: brands["海尔"].ID, Name: "智能双开门冰箱", Description: "大容量，风冷无霜，智能温控。", Price: 3999.00, Stock: 20, CoverImage: imgFridge, Images: pq.StringArray{imgFridge}, Status: 1},
+		{CategoryID: appliances.ID, BrandID: brands["海尔"].ID, Name: "全自动滚筒洗衣机", Description: "洗烘一体，静音变频，除菌洗。", Price: 2599.00, Stock: 30, CoverImage: imgWasher, Images: pq.StringArray{imgWasher}, Status: 1},
 		{CategoryID: appliances.ID, Name: "现代护眼台灯", Description: "LED 护眼台灯，可调节亮度和色温。", Price: 159.00, Stock: 300, CoverImage: imgLamp, Images: pq.StringArray{imgLamp}, Status: 1},
 	}
 
@@ -0,0 +1,8 @@
+package utils
+
+import "math"
+
+// RoundAmount 金额保留两位小数 (四舍五入)
+func RoundAmount(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -13,16 +13,18 @@ var jwtSecret = []byte("your_super_secret_key_change_this_in_production")
 
 // Claims 定义了 Token 中包含的载荷信息
 type Claims struct {
-	UserID uint `json:"user_id"` // 用户 ID
+	UserID uint   `json:"user_id"` // 用户 ID
+	Role   string `json:"role"`    // 角色: 普通用户为 users.role，管理员为 admin_users.role
 	jwt.RegisteredClaims
 }
 
-// GenerateToken 为指定用户 ID 生成 JWT Token
+// GenerateToken 为指定用户 ID 和角色生成 JWT Token
 // Token 有效期为 24 小时
-func GenerateToken(userID uint) (string, error) {
+func GenerateToken(userID uint, role string) (string, error) {
 	// 设置载荷
 	claims := Claims{
 		UserID: userID,
+		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)), // 过期时间
 			IssuedAt:  jwt.NewNumericDate(time.Now()),                     // 签发时间